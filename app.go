@@ -0,0 +1,500 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gleip/backend/agent"
+	"gleip/backend/automation"
+	"gleip/backend/cert"
+	"gleip/backend/events"
+	"gleip/backend/gleipflow"
+	"gleip/backend/metrics"
+	"gleip/backend/network"
+	"gleip/backend/project"
+	"gleip/backend/protodec"
+	"gleip/backend/proxy"
+	"gleip/backend/settings"
+	"gleip/backend/stats"
+	"gleip/backend/telemetry"
+	"gleip/backend/tracing"
+)
+
+// App is the backend facade bound to the frontend. Every exported method on
+// App (and its app_*.go siblings) is callable from the UI.
+type App struct {
+	ctx context.Context
+
+	dataDir  string
+	settings *settings.Manager
+	certs    *cert.CertificateManager
+	store    network.TransactionStore
+	proxy    *proxy.ProxyServer
+	project  *project.Project
+
+	apiTokens *automation.TokenStore
+	apiServer *automation.APIServer
+	executor  *gleipflow.Executor
+
+	macros       macroRecorder
+	stepSync     stepSyncState
+	triggers     triggerState
+	agentClient  *agent.Client
+	telemetry    *telemetry.Classifier
+	protoSchemas *protodec.SchemaRegistry
+
+	metrics       *metrics.Collector
+	metricsServer *metrics.Server
+	tracer        *tracing.Tracer
+	hostStats     *stats.Aggregator
+
+	// autoLock is the idle-session watchdog; see app_autolock.go.
+	autoLock autoLockState
+}
+
+// NewApp wires up the backend subsystems but does not start any listeners;
+// that happens in Startup once the UI is ready.
+func NewApp() (*App, error) {
+	dataDir, err := defaultDataDir()
+	if err != nil {
+		return nil, err
+	}
+	settingsMgr, err := settings.NewManager(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+	certs, err := cert.NewCertificateManager(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize certificate manager: %w", err)
+	}
+	tokens, err := automation.NewTokenStore(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API tokens: %w", err)
+	}
+	store := network.NewInMemoryTransactionStore()
+	if settingsMgr.Get().OffloadHistory {
+		if err := store.SetOffloadDir(filepath.Join(dataDir, "history")); err != nil {
+			return nil, err
+		}
+	}
+	app := &App{
+		dataDir:   dataDir,
+		settings:  settingsMgr,
+		certs:     certs,
+		store:     store,
+		proxy:     proxy.NewProxyServer(settingsMgr.Get().ProxyAddr, certs, store),
+		project:   project.New("Untitled"),
+		apiTokens: tokens,
+	}
+	app.proxy.SetSpoolDir(filepath.Join(dataDir, "spool"))
+	app.proxy.SetListenerTLS(settingsMgr.Get().ListenerTLS)
+	app.proxy.SetAcceptProxyProtocol(settingsMgr.Get().AcceptProxyProtocol)
+	app.executor = gleipflow.NewExecutor(app.proxy.Hosts)
+	if name := settingsMgr.Get().TLSFingerprint; name != "" {
+		if err := app.proxy.SetTLSFingerprint(name); err != nil {
+			return nil, fmt.Errorf("failed to apply TLS fingerprint: %w", err)
+		}
+		app.executor.SetTLSFingerprint(name)
+	}
+	if opts := settingsMgr.Get().OutboundTLS; opts.MinVersion != "" || opts.MaxVersion != "" || len(opts.CipherSuites) > 0 || opts.AllowLegacyRenegotiation {
+		if err := app.proxy.SetOutboundTLSOptions(opts); err != nil {
+			return nil, fmt.Errorf("failed to apply outbound TLS options: %w", err)
+		}
+		app.executor.SetOutboundTLSOptions(opts)
+	}
+	if opts := settingsMgr.Get().ConnectionPool; !opts.IsZero() {
+		app.proxy.SetConnectionPoolOptions(opts)
+		app.executor.SetConnectionPoolOptions(opts)
+	}
+	if policy := settingsMgr.Get().Retry; !policy.IsZero() {
+		app.proxy.SetRetryPolicy(policy)
+	}
+	if s := settingsMgr.Get(); s.InterceptAutoForwardSeconds > 0 {
+		app.proxy.Intercepts.SetAutoForward(time.Duration(s.InterceptAutoForwardSeconds)*time.Second, s.InterceptAutoDrop)
+	}
+	if s := settingsMgr.Get(); s.InterceptQueueLimit > 0 {
+		if err := app.proxy.Intercepts.SetLimit(s.InterceptQueueLimit, proxy.OverflowPolicy(s.InterceptOverflowPolicy)); err != nil {
+			return nil, fmt.Errorf("failed to apply intercept queue limit: %w", err)
+		}
+	}
+	if s := settingsMgr.Get(); len(s.RemoteAllowedClients) > 0 || s.RemoteAccessToken != "" {
+		if err := app.proxy.SetRemoteAccess(s.RemoteAllowedClients, s.RemoteAccessToken); err != nil {
+			return nil, fmt.Errorf("failed to apply remote access settings: %w", err)
+		}
+	}
+	if cfg := settingsMgr.Get().UpstreamProxy; cfg.Addr != "" {
+		app.proxy.SetUpstreamProxy(cfg)
+		app.executor.SetUpstreamProxy(cfg)
+	}
+	app.executor.ProfileLookup = app.lookupClientProfile
+	app.executor.VariableLookup = app.lookupFlowVariable
+	app.stepSync.pending = make(map[string]*PendingStepSync)
+	app.triggers.lastFired = make(map[string]time.Time)
+	app.telemetry = telemetry.NewClassifier()
+	app.protoSchemas = protodec.NewSchemaRegistry()
+	app.metrics = metrics.NewCollector()
+	app.initMetrics()
+	app.hostStats = stats.NewAggregator()
+	app.tracer = tracing.NewTracer()
+	if endpoint := settingsMgr.Get().OTLPEndpoint; endpoint != "" {
+		app.tracer.SetEndpoint(endpoint)
+	}
+	app.proxy.AddTransactionObserver(app.observeStepBindings)
+	app.proxy.AddTransactionObserver(app.observeFlowTriggers)
+	app.proxy.AddTransactionObserver(app.observeTelemetry)
+	app.proxy.AddTransactionObserver(app.observeHighlights)
+	app.proxy.AddTransactionObserver(app.observeMetrics)
+	app.proxy.AddTransactionObserver(app.observeHostStats)
+	app.proxy.AddTransactionObserver(app.observeTracing)
+	return app, nil
+}
+
+// Startup is called when the application starts; ctx is retained for event
+// emission and shutdown.
+func (a *App) Startup(ctx context.Context) {
+	a.ctx = ctx
+	a.project.StartUsageSession()
+	a.autoLock.idleTimeout = time.Duration(a.settings.Get().AutoLockMinutes) * time.Minute
+	a.startAutoLockWatchdog()
+}
+
+// Shutdown stops background listeners.
+func (a *App) Shutdown() {
+	a.project.StopUsageSession()
+	a.stopAutoLockWatchdog()
+	if a.apiServer != nil {
+		a.apiServer.Stop()
+	}
+	if a.metricsServer != nil {
+		a.metricsServer.Stop()
+	}
+	a.proxy.Stop()
+}
+
+// StartProxy starts the intercepting proxy on the configured address.
+func (a *App) StartProxy() error {
+	return a.proxy.Start()
+}
+
+// StopProxy stops the proxy listener.
+func (a *App) StopProxy() error {
+	return a.proxy.Stop()
+}
+
+// SetPreserveChunkedForwarding toggles restoring chunked framing when
+// forwarding edited requests that originally used Transfer-Encoding: chunked
+// (off means they are forwarded with Content-Length).
+func (a *App) SetPreserveChunkedForwarding(enabled bool) {
+	a.proxy.SetPreserveChunkedForwarding(enabled)
+}
+
+// SetPreserveHeaderBytes toggles byte-for-byte request forwarding, which
+// keeps the original header order and casing on the wire for servers and
+// WAF-evasion tests that depend on exact bytes.
+func (a *App) SetPreserveHeaderBytes(enabled bool) {
+	a.proxy.SetPreserveHeaderBytes(enabled)
+}
+
+// SetInterceptAutoForward persists and applies automatic resolution of
+// untouched intercepted requests: after seconds of waiting they are
+// forwarded unmodified, or dropped when drop is set (0 disables).
+func (a *App) SetInterceptAutoForward(seconds int, drop bool) error {
+	if seconds < 0 {
+		return fmt.Errorf("auto-forward seconds must not be negative")
+	}
+	a.proxy.Intercepts.SetAutoForward(time.Duration(seconds)*time.Second, drop)
+	return a.settings.Update(func(s *settings.Settings) {
+		s.InterceptAutoForwardSeconds = seconds
+		s.InterceptAutoDrop = drop
+	})
+}
+
+// SetInterceptQueueLimit persists and applies a cap on pending intercepted
+// requests, with an overflow policy of "forward-oldest" or "reject-new"
+// (0 removes the cap).
+func (a *App) SetInterceptQueueLimit(limit int, policy string) error {
+	if limit < 0 {
+		return fmt.Errorf("intercept queue limit must not be negative")
+	}
+	if err := a.proxy.Intercepts.SetLimit(limit, proxy.OverflowPolicy(policy)); err != nil {
+		return err
+	}
+	return a.settings.Update(func(s *settings.Settings) {
+		s.InterceptQueueLimit = limit
+		s.InterceptOverflowPolicy = policy
+	})
+}
+
+// SetInterceptEnabled toggles request interception.
+func (a *App) SetInterceptEnabled(enabled bool) {
+	a.proxy.SetInterceptEnabled(enabled)
+}
+
+// GetInterceptedRequests returns the pending intercept queue.
+func (a *App) GetInterceptedRequests() []*proxy.InterceptedRequest {
+	return a.proxy.Intercepts.List()
+}
+
+// SearchInterceptedRequests filters the pending intercept queue
+// server-side: host, method, path, and dump are matched case-insensitively.
+func (a *App) SearchInterceptedRequests(query string) []*proxy.InterceptedRequest {
+	return a.proxy.Intercepts.Search(query)
+}
+
+// GetInterceptQueueStats returns the queue depth broken down by host. The
+// same payload is pushed as "intercept:queue" events whenever the queue
+// changes.
+func (a *App) GetInterceptQueueStats() proxy.InterceptQueueStats {
+	return a.proxy.Intercepts.Stats()
+}
+
+// ForwardRequest releases an intercepted request, optionally with a modified
+// dump (empty string keeps the original).
+func (a *App) ForwardRequest(id string, modifiedDump string) error {
+	return a.proxy.Intercepts.Forward(id, modifiedDump)
+}
+
+// ForwardRequestAndInterceptResponse releases an intercepted request like
+// ForwardRequest and additionally parks its response for editing before it
+// reaches the client.
+func (a *App) ForwardRequestAndInterceptResponse(id string, modifiedDump string) error {
+	return a.proxy.Intercepts.ForwardAndInterceptResponse(id, modifiedDump)
+}
+
+// GetInterceptedResponses returns the pending response intercept queue.
+func (a *App) GetInterceptedResponses() []*proxy.InterceptedResponse {
+	return a.proxy.InterceptResponses.List()
+}
+
+// ForwardResponse releases an intercepted response, optionally with a
+// modified dump (empty string keeps the original).
+func (a *App) ForwardResponse(id string, modifiedDump string) error {
+	return a.proxy.InterceptResponses.Forward(id, modifiedDump)
+}
+
+// DropResponse discards an intercepted response; the client connection is
+// torn down.
+func (a *App) DropResponse(id string) error {
+	return a.proxy.InterceptResponses.Drop(id)
+}
+
+// GetInterceptedRequestBody returns a pending request's body encoded as
+// "base64" or "hex", so binary payloads survive the trip to the editor
+// instead of being mangled as text.
+func (a *App) GetInterceptedRequestBody(id, encoding string) (string, error) {
+	req, ok := a.proxy.Intercepts.Get(id)
+	if !ok {
+		return "", fmt.Errorf("no intercepted request with ID %s", id)
+	}
+	_, body := network.SplitDump(req.RequestDump)
+	return encodeBinaryBody([]byte(body), encoding)
+}
+
+// ModifyInterceptedRequest forwards a pending request with its body replaced
+// by the decoded payload ("base64" or "hex"), keeping the headers from the
+// intercepted dump and fixing Content-Length. This is the binary-safe
+// counterpart to ForwardRequest.
+func (a *App) ModifyInterceptedRequest(id, encoding, encodedBody string) error {
+	req, ok := a.proxy.Intercepts.Get(id)
+	if !ok {
+		return fmt.Errorf("no intercepted request with ID %s", id)
+	}
+	body, err := decodeBinaryBody(encodedBody, encoding)
+	if err != nil {
+		return err
+	}
+	return a.proxy.Intercepts.Forward(id, network.ReplaceDumpBody(req.RequestDump, body))
+}
+
+func encodeBinaryBody(body []byte, encoding string) (string, error) {
+	switch encoding {
+	case "base64":
+		return base64.StdEncoding.EncodeToString(body), nil
+	case "hex":
+		return hex.EncodeToString(body), nil
+	}
+	return "", fmt.Errorf("unsupported body encoding %q (want base64 or hex)", encoding)
+}
+
+func decodeBinaryBody(encoded, encoding string) ([]byte, error) {
+	switch encoding {
+	case "base64":
+		body, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 body: %w", err)
+		}
+		return body, nil
+	case "hex":
+		body, err := hex.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode hex body: %w", err)
+		}
+		return body, nil
+	}
+	return nil, fmt.Errorf("unsupported body encoding %q (want base64 or hex)", encoding)
+}
+
+// DropRequest discards an intercepted request.
+func (a *App) DropRequest(id string) error {
+	return a.proxy.Intercepts.Drop(id)
+}
+
+// GetProxyRequests returns summaries of all captured transactions. When the
+// project excludes telemetry, tagged third-party noise is filtered out.
+func (a *App) GetProxyRequests() []network.HTTPTransactionSummary {
+	summaries := a.store.Summaries()
+	if !a.GetExcludeTelemetry() {
+		return summaries
+	}
+	out := make([]network.HTTPTransactionSummary, 0, len(summaries))
+	for _, s := range summaries {
+		if summaryHasTag(s, telemetry.Tag) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func summaryHasTag(s network.HTTPTransactionSummary, tag string) bool {
+	for _, have := range s.Tags {
+		if strings.EqualFold(have, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTransaction returns a full transaction by ID.
+func (a *App) GetTransaction(id string) (*network.HTTPTransaction, error) {
+	tx, ok := a.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", id)
+	}
+	return tx, nil
+}
+
+// GetTransactionTLSInfo returns the negotiated TLS parameters and server
+// certificate chain for a transaction, or an error if it was plain HTTP.
+func (a *App) GetTransactionTLSInfo(id string) (*network.TLSInfo, error) {
+	tx, ok := a.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", id)
+	}
+	if tx.TLSInfo == nil {
+		return nil, fmt.Errorf("transaction %s has no TLS connection details", id)
+	}
+	return tx.TLSInfo, nil
+}
+
+// GetTransactionChunk returns up to length bytes of a transaction's response
+// body starting at offset. It works for both inline and disk-spooled bodies,
+// so viewers can page through very large downloads.
+func (a *App) GetTransactionChunk(id string, offset int64, length int) ([]byte, error) {
+	tx, ok := a.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", id)
+	}
+	if tx.Response == nil {
+		return nil, fmt.Errorf("transaction %s has no response", id)
+	}
+	if offset < 0 || length < 0 {
+		return nil, fmt.Errorf("offset and length must be non-negative")
+	}
+	if tx.Response.BodyFile != "" {
+		f, err := os.Open(tx.Response.BodyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open spooled body: %w", err)
+		}
+		defer f.Close()
+		buf := make([]byte, length)
+		n, err := f.ReadAt(buf, offset)
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read spooled body: %w", err)
+		}
+		return buf[:n], nil
+	}
+	_, body := network.SplitDump(tx.Response.Dump)
+	if offset >= int64(len(body)) {
+		return []byte{}, nil
+	}
+	end := offset + int64(length)
+	if end > int64(len(body)) {
+		end = int64(len(body))
+	}
+	return []byte(body[offset:end]), nil
+}
+
+// GetTransactionSSEEvents returns the events captured so far from a
+// text/event-stream response; the list grows while the stream is open.
+func (a *App) GetTransactionSSEEvents(id string) ([]network.SSEEvent, error) {
+	tx, ok := a.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", id)
+	}
+	if tx.Response == nil || !tx.Response.EventStream {
+		return nil, fmt.Errorf("transaction %s is not an event stream", id)
+	}
+	return tx.SSEEvents, nil
+}
+
+// SaveProject persists the current project to path ("" re-saves in place).
+func (a *App) SaveProject(path string) error {
+	return a.project.Save(path)
+}
+
+// OpenProject loads a project from disk, replacing the current one.
+func (a *App) OpenProject(path string) error {
+	p, err := project.Load(path)
+	if err != nil {
+		return err
+	}
+	a.project.StopUsageSession()
+	a.project = p
+	a.project.StartUsageSession()
+	a.applyProjectToRuntime()
+	// Switch history stores when the project keeps its transactions in
+	// SQLite, or when leaving a project that did.
+	_, usingSQLite := a.store.(*network.SQLiteTransactionStore)
+	if p.StorePath != "" || usingSQLite {
+		if err := a.applyProjectStore(p.StorePath); err != nil {
+			return fmt.Errorf("failed to open project history database: %w", err)
+		}
+	}
+	// Subset exports embed their transactions; surface them in history.
+	for _, tx := range p.Transactions {
+		tx.SeqNumber = a.proxy.NextSeq()
+		a.store.Add(tx)
+		events.Emit("proxy:request", tx.Summary())
+	}
+	p.Transactions = nil
+	a.RebuildHostStats()
+	return nil
+}
+
+// applyProjectToRuntime pushes project-persisted configuration into the
+// running subsystems after a project is created or opened.
+func (a *App) applyProjectToRuntime() {
+	a.syncMatchReplaceRules()
+	a.syncInterceptRules()
+	a.syncHeaderStripRules()
+	a.syncBlocklist()
+	a.syncHostsOverrides()
+	a.syncTelemetry()
+	a.proxy.Scope.Set(a.GetScope())
+}
+
+func defaultDataDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve config dir: %w", err)
+	}
+	return filepath.Join(base, "gleip"), nil
+}
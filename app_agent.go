@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/agent"
+	"gleip/backend/events"
+	"gleip/backend/network"
+)
+
+// ConnectRemoteAgent subscribes to a remote capture agent's transaction
+// stream; received transactions land in history tagged "remote" alongside
+// locally proxied traffic.
+func (a *App) ConnectRemoteAgent(url, token string) error {
+	if a.agentClient != nil {
+		return fmt.Errorf("already connected to a remote agent; disconnect first")
+	}
+	client, err := agent.Connect(url, token, func(tx *network.HTTPTransaction) {
+		tx.SeqNumber = a.proxy.NextSeq()
+		tx.AddTag("remote")
+		a.store.Add(tx)
+		events.Emit("proxy:request", tx.Summary())
+		if tx.Response != nil {
+			events.Emit("proxy:response", tx.Summary())
+		}
+	})
+	if err != nil {
+		return err
+	}
+	a.agentClient = client
+	events.Emit("agent:connected", url)
+	return nil
+}
+
+// DisconnectRemoteAgent drops the remote agent stream.
+func (a *App) DisconnectRemoteAgent() {
+	if a.agentClient == nil {
+		return
+	}
+	a.agentClient.Close()
+	a.agentClient = nil
+	events.Emit("agent:disconnected", nil)
+}
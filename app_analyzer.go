@@ -0,0 +1,29 @@
+package main
+
+import (
+	"gleip/backend/analyzer"
+	"gleip/backend/network"
+)
+
+// GetSecurityHeaderFindings analyzes the most recent response per host and
+// path for CSP, HSTS, clickjacking, cookie, and CORS weaknesses, returning
+// graded findings with the offending header values as evidence.
+func (a *App) GetSecurityHeaderFindings() []analyzer.HeaderFinding {
+	// Keep only the latest response per endpoint so a host hammered during
+	// testing doesn't produce the same finding hundreds of times.
+	latest := make(map[string]*network.HTTPTransaction)
+	for _, tx := range a.store.All() {
+		if tx.Response == nil {
+			continue
+		}
+		key := tx.Request.Host + " " + tx.Request.Path
+		if prev, ok := latest[key]; !ok || tx.SeqNumber > prev.SeqNumber {
+			latest[key] = tx
+		}
+	}
+	var findings []analyzer.HeaderFinding
+	for _, tx := range latest {
+		findings = append(findings, analyzer.AnalyzeTransaction(tx)...)
+	}
+	return findings
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// SetTransactionStarred pins (or unpins) a transaction as a favorite, so
+// key requests for a finding stay separate from the general tag system.
+func (a *App) SetTransactionStarred(txID string, starred bool) error {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return fmt.Errorf("no transaction with ID %s", txID)
+	}
+	tx.Starred = starred
+	a.store.Update(tx)
+	return nil
+}
+
+// GetStarredTransactions lists summaries of starred transactions in sequence
+// order.
+func (a *App) GetStarredTransactions() []network.HTTPTransactionSummary {
+	var out []network.HTTPTransactionSummary
+	for _, s := range a.store.Summaries() {
+		if s.Starred {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// UpdateTransactionNote sets (or clears, with "") the free-text comment on a
+// transaction.
+func (a *App) UpdateTransactionNote(txID, note string) error {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return fmt.Errorf("no transaction with ID %s", txID)
+	}
+	tx.Note = note
+	a.store.Update(tx)
+	return nil
+}
+
+// AddResponseAnnotation anchors a note to a byte range of a transaction's
+// response dump and returns it. The range must fall inside the stored dump.
+func (a *App) AddResponseAnnotation(txID string, start, end int, note, color string) (*network.ResponseAnnotation, error) {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", txID)
+	}
+	if tx.Response == nil {
+		return nil, fmt.Errorf("transaction %s has no response to annotate", txID)
+	}
+	if start < 0 || end <= start || end > len(tx.Response.Dump) {
+		return nil, fmt.Errorf("annotation range %d-%d is outside the response dump (%d bytes)", start, end, len(tx.Response.Dump))
+	}
+	annotation := network.ResponseAnnotation{
+		ID:        network.NewID(),
+		Start:     start,
+		End:       end,
+		Note:      note,
+		Color:     color,
+		CreatedAt: time.Now(),
+	}
+	tx.Annotations = append(tx.Annotations, annotation)
+	a.store.Update(tx)
+	return &annotation, nil
+}
+
+// GetResponseAnnotations lists a transaction's response annotations.
+func (a *App) GetResponseAnnotations(txID string) ([]network.ResponseAnnotation, error) {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", txID)
+	}
+	return tx.Annotations, nil
+}
+
+// UpdateResponseAnnotation replaces an annotation's note and color.
+func (a *App) UpdateResponseAnnotation(txID, annotationID, note, color string) error {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return fmt.Errorf("no transaction with ID %s", txID)
+	}
+	for i := range tx.Annotations {
+		if tx.Annotations[i].ID == annotationID {
+			tx.Annotations[i].Note = note
+			tx.Annotations[i].Color = color
+			a.store.Update(tx)
+			return nil
+		}
+	}
+	return fmt.Errorf("no annotation with ID %s on transaction %s", annotationID, txID)
+}
+
+// DeleteResponseAnnotation removes an annotation from a transaction.
+func (a *App) DeleteResponseAnnotation(txID, annotationID string) error {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return fmt.Errorf("no transaction with ID %s", txID)
+	}
+	for i := range tx.Annotations {
+		if tx.Annotations[i].ID == annotationID {
+			tx.Annotations = append(tx.Annotations[:i], tx.Annotations[i+1:]...)
+			a.store.Update(tx)
+			return nil
+		}
+	}
+	return fmt.Errorf("no annotation with ID %s on transaction %s", annotationID, txID)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gleip/backend/events"
+	"gleip/backend/settings"
+)
+
+// autoLockPoll is how often the idle watchdog checks the last activity
+// timestamp.
+const autoLockPoll = 15 * time.Second
+
+// autoLockState tracks idle time and the lock itself. Locking is a backend
+// state so automation bindings can refuse to serve while the UI shows the
+// lock screen; if project encryption is ever added, this is also where the
+// in-memory key gets dropped.
+type autoLockState struct {
+	mu           sync.Mutex
+	locked       bool
+	idleTimeout  time.Duration
+	lastActivity time.Time
+	stop         chan struct{}
+}
+
+// SetAutoLockMinutes persists and applies the idle timeout after which the
+// session locks (0 disables auto-lock).
+func (a *App) SetAutoLockMinutes(minutes int) error {
+	if minutes < 0 {
+		return fmt.Errorf("auto-lock minutes must not be negative")
+	}
+	a.autoLock.mu.Lock()
+	a.autoLock.idleTimeout = time.Duration(minutes) * time.Minute
+	a.autoLock.lastActivity = time.Now()
+	a.autoLock.mu.Unlock()
+	return a.settings.Update(func(s *settings.Settings) { s.AutoLockMinutes = minutes })
+}
+
+// TouchActivity resets the idle clock; the UI calls this on user input.
+func (a *App) TouchActivity() {
+	a.autoLock.mu.Lock()
+	a.autoLock.lastActivity = time.Now()
+	a.autoLock.mu.Unlock()
+}
+
+// IsLocked reports whether the session is locked.
+func (a *App) IsLocked() bool {
+	a.autoLock.mu.Lock()
+	defer a.autoLock.mu.Unlock()
+	return a.autoLock.locked
+}
+
+// UnlockSession clears the lock after the user re-authenticates in the UI.
+func (a *App) UnlockSession() {
+	a.autoLock.mu.Lock()
+	a.autoLock.locked = false
+	a.autoLock.lastActivity = time.Now()
+	a.autoLock.mu.Unlock()
+	events.Emit("app:unlocked", nil)
+}
+
+// LockSession locks immediately, without waiting for the idle timeout.
+func (a *App) LockSession() {
+	a.lockNow()
+}
+
+func (a *App) lockNow() {
+	a.autoLock.mu.Lock()
+	already := a.autoLock.locked
+	a.autoLock.locked = true
+	a.autoLock.mu.Unlock()
+	if !already {
+		events.Emit("app:locked", nil)
+	}
+}
+
+// startAutoLockWatchdog begins the idle poll loop; stopAutoLockWatchdog
+// ends it at shutdown.
+func (a *App) startAutoLockWatchdog() {
+	a.autoLock.stop = make(chan struct{})
+	a.autoLock.lastActivity = time.Now()
+	go func() {
+		ticker := time.NewTicker(autoLockPoll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.autoLock.stop:
+				return
+			case <-ticker.C:
+				a.autoLock.mu.Lock()
+				timeout := a.autoLock.idleTimeout
+				idle := time.Since(a.autoLock.lastActivity)
+				locked := a.autoLock.locked
+				a.autoLock.mu.Unlock()
+				if timeout > 0 && !locked && idle >= timeout {
+					a.lockNow()
+				}
+			}
+		}
+	}()
+}
+
+func (a *App) stopAutoLockWatchdog() {
+	if a.autoLock.stop != nil {
+		close(a.autoLock.stop)
+		a.autoLock.stop = nil
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"gleip/backend/automation"
+)
+
+const automationAPIAddr = "127.0.0.1:9091"
+
+// CreatedAPIToken is returned once at creation time: the only moment the
+// plaintext secret is available.
+type CreatedAPIToken struct {
+	Token  *automation.APIToken `json:"token"`
+	Secret string               `json:"secret"`
+}
+
+// StartAutomationAPI starts the local REST API. Routes are registered with
+// the minimum scope a token needs to call them.
+func (a *App) StartAutomationAPI() error {
+	if a.apiServer != nil {
+		return fmt.Errorf("automation API already started")
+	}
+	server := automation.NewAPIServer(automationAPIAddr, a.apiTokens)
+	server.Handle("/api/history", automation.ScopeReadHistory, func(w http.ResponseWriter, r *http.Request) {
+		automation.WriteJSON(w, a.GetProxyRequests())
+	})
+	server.Handle("/api/flows/execute", automation.ScopeExecuteFlows, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "POST required", http.StatusMethodNotAllowed)
+			return
+		}
+		flowID := r.URL.Query().Get("id")
+		a.project.AppendAudit("webhook", "execute flow", "flow "+flowID)
+		results, err := a.ExecuteGleipFlow(flowID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		automation.WriteJSON(w, results)
+	})
+	server.Handle("/api/transactions/", automation.ScopeReadHistory, func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[len("/api/transactions/"):]
+		tx, err := a.GetTransaction(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		automation.WriteJSON(w, tx)
+	})
+	if err := server.Start(); err != nil {
+		return err
+	}
+	a.apiServer = server
+	return nil
+}
+
+// StopAutomationAPI stops the local REST API.
+func (a *App) StopAutomationAPI() error {
+	if a.apiServer == nil {
+		return nil
+	}
+	err := a.apiServer.Stop()
+	a.apiServer = nil
+	return err
+}
+
+// CreateAPIToken issues a scoped token for the automation API and returns
+// the plaintext secret exactly once.
+func (a *App) CreateAPIToken(name string, scope string) (*CreatedAPIToken, error) {
+	token, secret, err := a.apiTokens.Create(name, automation.TokenScope(scope))
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedAPIToken{Token: token, Secret: secret}, nil
+}
+
+// RevokeAPIToken permanently disables a token.
+func (a *App) RevokeAPIToken(id string) error {
+	return a.apiTokens.Revoke(id)
+}
+
+// GetAPITokens lists all issued tokens with their last-used timestamps.
+func (a *App) GetAPITokens() []*automation.APIToken {
+	return a.apiTokens.List()
+}
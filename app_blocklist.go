@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/network"
+	"gleip/backend/proxy"
+)
+
+// GetBlocklist lists the project's blocked host patterns.
+func (a *App) GetBlocklist() []*proxy.BlocklistEntry {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make([]*proxy.BlocklistEntry, len(a.project.Blocklist))
+	copy(out, a.project.Blocklist)
+	return out
+}
+
+// AddBlocklistEntry validates and stores a new blocked host pattern.
+func (a *App) AddBlocklistEntry(entry proxy.BlocklistEntry) (*proxy.BlocklistEntry, error) {
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	entry.ID = network.NewID()
+	a.project.Lock()
+	a.project.Blocklist = append(a.project.Blocklist, &entry)
+	a.project.Unlock()
+	a.syncBlocklist()
+	return &entry, nil
+}
+
+// UpdateBlocklistEntry replaces an existing entry by ID.
+func (a *App) UpdateBlocklistEntry(entry proxy.BlocklistEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.Blocklist {
+		if existing.ID == entry.ID {
+			a.project.Blocklist[i] = &entry
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no blocklist entry with ID %s", entry.ID)
+	}
+	a.syncBlocklist()
+	return nil
+}
+
+// DeleteBlocklistEntry removes an entry by ID.
+func (a *App) DeleteBlocklistEntry(id string) error {
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.Blocklist {
+		if existing.ID == id {
+			a.project.Blocklist = append(a.project.Blocklist[:i], a.project.Blocklist[i+1:]...)
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no blocklist entry with ID %s", id)
+	}
+	a.syncBlocklist()
+	return nil
+}
+
+// syncBlocklist pushes the project's blocklist into the proxy.
+func (a *App) syncBlocklist() {
+	a.project.RLock()
+	entries := append([]*proxy.BlocklistEntry(nil), a.project.Blocklist...)
+	a.project.RUnlock()
+	a.proxy.Blocklist.Set(entries)
+}
@@ -0,0 +1,14 @@
+package main
+
+// ImportCA replaces the proxy's root CA with an external certificate/key
+// pair, e.g. an organization CA or one exported from Burp, so test devices
+// that already trust it need no re-configuration.
+func (a *App) ImportCA(certPath, keyPath string) error {
+	return a.certs.ImportCAFromFiles(certPath, keyPath)
+}
+
+// ExportCA writes the current root CA certificate (and its key when
+// includeKey is set) as PEM files into dir, returning the written paths.
+func (a *App) ExportCA(dir string, includeKey bool) ([]string, error) {
+	return a.certs.ExportCA(dir, includeKey)
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"gleip/backend/gleipflow"
+	"gleip/backend/importers"
+	"gleip/backend/network"
+)
+
+// CollectionDiff reports what changed when a collection was refreshed,
+// keyed by "METHOD /path".
+type CollectionDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+// ImportAPICollectionFromURL fetches an OpenAPI document from a URL (with
+// optional auth headers, which are stored for later refreshes) and imports
+// it as an API collection on the project.
+func (a *App) ImportAPICollectionFromURL(sourceURL string, headers map[string]string) (*importers.APICollection, error) {
+	data, err := importers.FetchOpenAPI(sourceURL, headers)
+	if err != nil {
+		return nil, err
+	}
+	col, err := importers.ParseOpenAPI(data)
+	if err != nil {
+		return nil, err
+	}
+	col.ID = network.NewID()
+	col.SourceURL = sourceURL
+	col.SourceHeaders = headers
+	for _, e := range col.Endpoints {
+		e.ID = network.NewID()
+	}
+	a.project.Lock()
+	a.project.APICollections = append(a.project.APICollections, col)
+	a.project.Unlock()
+	return col, nil
+}
+
+// GetAPICollections lists the project's imported API collections.
+func (a *App) GetAPICollections() []*importers.APICollection {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return append([]*importers.APICollection(nil), a.project.APICollections...)
+}
+
+// SetCollectionSecurityValue stores a user-entered security value (API key,
+// token) on a collection. These survive refreshes.
+func (a *App) SetCollectionSecurityValue(collectionID, name, value string) error {
+	a.project.Lock()
+	defer a.project.Unlock()
+	col := a.findCollectionLocked(collectionID)
+	if col == nil {
+		return fmt.Errorf("no API collection with ID %s", collectionID)
+	}
+	if col.SecurityValues == nil {
+		col.SecurityValues = make(map[string]string)
+	}
+	if value == "" {
+		delete(col.SecurityValues, name)
+	} else {
+		col.SecurityValues[name] = value
+	}
+	return nil
+}
+
+// OverrideCollectionEndpoint replaces an endpoint's request dump with a
+// user-edited one; overridden dumps are preserved across refreshes.
+func (a *App) OverrideCollectionEndpoint(collectionID, endpointID, dump string) error {
+	if _, err := network.ParseRequestDump(dump); err != nil {
+		return fmt.Errorf("invalid request dump: %w", err)
+	}
+	a.project.Lock()
+	defer a.project.Unlock()
+	col := a.findCollectionLocked(collectionID)
+	if col == nil {
+		return fmt.Errorf("no API collection with ID %s", collectionID)
+	}
+	for _, e := range col.Endpoints {
+		if e.ID == endpointID {
+			e.Dump = dump
+			e.Overridden = true
+			return nil
+		}
+	}
+	return fmt.Errorf("no endpoint with ID %s in collection", endpointID)
+}
+
+// RefreshAPICollection re-fetches a collection from its source URL and
+// re-imports it, preserving user-entered security values and overridden
+// endpoint dumps, and reports which endpoints were added, removed, or
+// changed.
+func (a *App) RefreshAPICollection(collectionID string) (*CollectionDiff, error) {
+	a.project.RLock()
+	col := a.findCollectionLocked(collectionID)
+	var sourceURL string
+	var headers map[string]string
+	if col != nil {
+		sourceURL = col.SourceURL
+		headers = col.SourceHeaders
+	}
+	a.project.RUnlock()
+	if col == nil {
+		return nil, fmt.Errorf("no API collection with ID %s", collectionID)
+	}
+	if sourceURL == "" {
+		return nil, fmt.Errorf("collection was not imported from a URL")
+	}
+
+	data, err := importers.FetchOpenAPI(sourceURL, headers)
+	if err != nil {
+		return nil, err
+	}
+	fresh, err := importers.ParseOpenAPI(data)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &CollectionDiff{}
+	a.project.Lock()
+	defer a.project.Unlock()
+	old := make(map[string]*importers.APIEndpoint, len(col.Endpoints))
+	for _, e := range col.Endpoints {
+		old[e.Key()] = e
+	}
+	seen := make(map[string]bool, len(fresh.Endpoints))
+	for _, e := range fresh.Endpoints {
+		seen[e.Key()] = true
+		prev, existed := old[e.Key()]
+		switch {
+		case !existed:
+			e.ID = network.NewID()
+			diff.Added = append(diff.Added, e.Key())
+		case prev.Overridden:
+			// Keep the user's edit wholesale.
+			*e = *prev
+		default:
+			e.ID = prev.ID
+			if e.Dump != prev.Dump {
+				diff.Changed = append(diff.Changed, e.Key())
+			}
+		}
+	}
+	for key := range old {
+		if !seen[key] {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	col.Name = fresh.Name
+	col.BaseURL = fresh.BaseURL
+	col.Endpoints = fresh.Endpoints
+	col.FetchedAt = time.Now()
+	return diff, nil
+}
+
+// pathParamPattern matches OpenAPI-style {param} path segments (but not
+// {{variable}} placeholders, which are already flow syntax).
+var pathParamPattern = regexp.MustCompile(`\{+([A-Za-z0-9_.-]+)\}+`)
+
+// CreateFlowFromCollectionFolder builds a flow with one request step per
+// collection endpoint under the given path prefix ("" takes the whole
+// collection), in collection order. {param} path segments become {{param}}
+// flow variables, so an ID returned by a POST can be fed into the
+// subsequent GET/PUT/DELETE steps by filling a single variable — the usual
+// CRUD chain needs no per-step editing.
+func (a *App) CreateFlowFromCollectionFolder(collectionID, folder string) (*gleipflow.GleipFlow, error) {
+	a.project.RLock()
+	col := a.findCollectionLocked(collectionID)
+	var endpoints []*importers.APIEndpoint
+	var baseURL string
+	if col != nil {
+		baseURL = col.BaseURL
+		for _, e := range col.Endpoints {
+			if folder == "" || strings.HasPrefix(e.Path, folder) {
+				endpoints = append(endpoints, e)
+			}
+		}
+	}
+	a.project.RUnlock()
+	if col == nil {
+		return nil, fmt.Errorf("no API collection with ID %s", collectionID)
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints under %q in collection", folder)
+	}
+
+	host, useTLS := "example.com", true
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Host
+		useTLS = u.Scheme != "http"
+	}
+
+	name := col.Name
+	if folder != "" {
+		name += " " + folder
+	}
+	flow := a.CreateGleipFlow(name)
+	a.project.Lock()
+	for _, e := range endpoints {
+		dump := pathParamPattern.ReplaceAllStringFunc(e.Dump, func(match string) string {
+			if strings.HasPrefix(match, "{{") {
+				// Already flow-variable syntax (overridden dumps).
+				return match
+			}
+			param := strings.Trim(match, "{}")
+			if _, seen := flow.Variables[param]; !seen {
+				flow.Variables[param] = ""
+			}
+			return "{{" + param + "}}"
+		})
+		stepName := e.Key()
+		if e.Summary != "" {
+			stepName = e.Summary
+		}
+		flow.Steps = append(flow.Steps, &gleipflow.Step{
+			ID:      network.NewID(),
+			Type:    gleipflow.StepRequest,
+			Name:    stepName,
+			Request: &gleipflow.RequestStep{Dump: dump, Host: host, TLS: useTLS},
+		})
+	}
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return flow, nil
+}
+
+// findCollectionLocked returns the collection with the given ID; callers
+// hold the project lock.
+func (a *App) findCollectionLocked(id string) *importers.APICollection {
+	for _, col := range a.project.APICollections {
+		if col.ID == id {
+			return col
+		}
+	}
+	return nil
+}
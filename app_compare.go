@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/analyzer"
+	"gleip/backend/network"
+)
+
+// CompareTransactions produces a consensus/outlier diff across two or more
+// captured transactions, so the one response that actually differs among
+// many similar ones stands out.
+func (a *App) CompareTransactions(ids []string) (*analyzer.NWayDiff, error) {
+	txs := make([]*network.HTTPTransaction, 0, len(ids))
+	for _, id := range ids {
+		tx, ok := a.store.Get(id)
+		if !ok {
+			return nil, fmt.Errorf("no transaction with ID %s", id)
+		}
+		txs = append(txs, tx)
+	}
+	return analyzer.CompareTransactions(txs)
+}
+
+// DiffTransactions compares two transactions' responses pairwise and returns
+// structured hunks for a comparer view. granularity is "line", "word", or
+// "byte" ("" means word).
+func (a *App) DiffTransactions(aID, bID, granularity string) (*analyzer.ResponseDiff, error) {
+	aTx, ok := a.store.Get(aID)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", aID)
+	}
+	bTx, ok := a.store.Get(bID)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", bID)
+	}
+	if aTx.Response == nil || bTx.Response == nil {
+		return nil, fmt.Errorf("both transactions need a response to compare")
+	}
+	return analyzer.DiffDumps(aTx.Response.Dump, bTx.Response.Dump, granularity)
+}
+
+// DiffStepResults compares the latest execution results of two steps (from
+// the same flow or different ones), for diffing fuzz variants.
+func (a *App) DiffStepResults(flowAID, stepAID, flowBID, stepBID, granularity string) (*analyzer.ResponseDiff, error) {
+	aDump, err := a.stepResultDump(flowAID, stepAID)
+	if err != nil {
+		return nil, err
+	}
+	bDump, err := a.stepResultDump(flowBID, stepBID)
+	if err != nil {
+		return nil, err
+	}
+	return analyzer.DiffDumps(aDump, bDump, granularity)
+}
+
+// stepResultDump returns the response dump of a step's most recent execution.
+func (a *App) stepResultDump(flowID, stepID string) (string, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return "", err
+	}
+	a.project.RLock()
+	defer a.project.RUnlock()
+	result := flow.ExecutionResults[stepID]
+	if result == nil || result.ResponseDump == "" {
+		return "", fmt.Errorf("step %s in flow %s has no execution result to compare", stepID, flowID)
+	}
+	return result.ResponseDump, nil
+}
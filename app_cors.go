@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"gleip/backend/analyzer"
+	"gleip/backend/network"
+)
+
+// TestCORS replays a captured request with a matrix of Origin values and
+// grades the Access-Control-Allow-* responses, flagging combinations an
+// attacker's page could actually exploit.
+func (a *App) TestCORS(transactionID string) ([]analyzer.CORSProbeResult, error) {
+	tx, ok := a.store.Get(transactionID)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", transactionID)
+	}
+	transport := a.executor.Transport()
+	var results []analyzer.CORSProbeResult
+	for _, origin := range analyzer.CORSTestOrigins(tx.Request.Host) {
+		req, err := network.ParseRequestDump(tx.Request.Dump)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse request dump: %w", err)
+		}
+		req.URL.Host = tx.Request.Host
+		if tx.Request.TLS {
+			req.URL.Scheme = "https"
+		} else {
+			req.URL.Scheme = "http"
+		}
+		req.RequestURI = ""
+		req.Header.Set("Origin", origin)
+
+		result := analyzer.CORSProbeResult{Origin: origin}
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			result.Note = err.Error()
+			results = append(results, result)
+			continue
+		}
+		resp.Body.Close()
+		result.StatusCode = resp.StatusCode
+		result.AllowOrigin = resp.Header.Get("Access-Control-Allow-Origin")
+		result.AllowCredentials = strings.EqualFold(resp.Header.Get("Access-Control-Allow-Credentials"), "true")
+		result.Exploitable, result.Severity, result.Note = analyzer.EvaluateCORSProbe(origin, result.AllowOrigin, result.AllowCredentials)
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gleip/backend/gleipflow"
+	"gleip/backend/network"
+)
+
+// CopyTransactionAsCurl renders a history transaction's request as a curl
+// command for sharing repro steps.
+func (a *App) CopyTransactionAsCurl(txID string) (string, error) {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return "", fmt.Errorf("no transaction with ID %s", txID)
+	}
+	return network.CurlCommand(tx.Request.Dump, tx.Request.Host, tx.Request.TLS)
+}
+
+// GenerateCodeSnippet renders a history transaction's request as
+// reproduction code in the given language ("python", "go", or "javascript"),
+// for handing repro steps to developers.
+func (a *App) GenerateCodeSnippet(txID, language string) (string, error) {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return "", fmt.Errorf("no transaction with ID %s", txID)
+	}
+	return network.GenerateCodeSnippet(tx.Request.Dump, tx.Request.Host, tx.Request.TLS, language)
+}
+
+// CopyStepAsCurl renders a flow request step as a curl command. Variables in
+// the dump are substituted with their current flow values first.
+func (a *App) CopyStepAsCurl(flowID, stepID string) (string, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return "", err
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil || step.Request == nil {
+		return "", fmt.Errorf("no request step with ID %s in flow %s", stepID, flowID)
+	}
+	a.project.RLock()
+	dump := gleipflow.SubstituteVariables(step.Request.Dump, flow.Variables)
+	a.project.RUnlock()
+	return network.CurlCommand(dump, step.Request.Host, step.Request.TLS)
+}
+
+// CopyTransactionAsMarkdown renders a transaction as fenced Markdown code
+// blocks (request and response), ready to paste into tickets and reports.
+// When maxBodyBytes > 0, bodies longer than that are truncated with a note;
+// 0 includes them whole.
+func (a *App) CopyTransactionAsMarkdown(txID string, maxBodyBytes int) (string, error) {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return "", fmt.Errorf("no transaction with ID %s", txID)
+	}
+	var b strings.Builder
+	title := fmt.Sprintf("### %s %s — %s", tx.Request.Method, tx.Request.Path, tx.Request.Host)
+	if tx.Response != nil {
+		title += fmt.Sprintf(" (%d)", tx.Response.StatusCode)
+	}
+	b.WriteString(title + "\n\n")
+	b.WriteString("**Request**\n\n")
+	b.WriteString(markdownFence(truncateDumpBody(tx.Request.Dump, maxBodyBytes)))
+	if tx.Response != nil {
+		b.WriteString("\n**Response**\n\n")
+		b.WriteString(markdownFence(truncateDumpBody(tx.Response.Dump, maxBodyBytes)))
+	}
+	return b.String(), nil
+}
+
+// truncateDumpBody caps a dump's body at maxBodyBytes (0 = no cap), marking
+// how much was cut.
+func truncateDumpBody(dump string, maxBodyBytes int) string {
+	if maxBodyBytes <= 0 {
+		return dump
+	}
+	headers, body := network.SplitDump(dump)
+	if len(body) <= maxBodyBytes {
+		return dump
+	}
+	note := fmt.Sprintf("… (%d bytes truncated)", len(body)-maxBodyBytes)
+	return headers + "\r\n\r\n" + body[:maxBodyBytes] + note
+}
+
+// markdownFence wraps text in an http code fence, widening the fence when
+// the text itself contains backtick runs.
+func markdownFence(text string) string {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return fence + "http\n" + strings.TrimRight(text, "\r\n") + "\n" + fence + "\n"
+}
+
+// CopyEndpointAsCurl renders an API collection endpoint as a curl command,
+// targeting the collection's base URL.
+func (a *App) CopyEndpointAsCurl(collectionID, endpointID string) (string, error) {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	col := a.findCollectionLocked(collectionID)
+	if col == nil {
+		return "", fmt.Errorf("no API collection with ID %s", collectionID)
+	}
+	host, useTLS := "", true
+	if u, err := url.Parse(col.BaseURL); err == nil && u.Host != "" {
+		host = u.Host
+		useTLS = u.Scheme != "http"
+	}
+	for _, e := range col.Endpoints {
+		if e.ID == endpointID {
+			return network.CurlCommand(e.Dump, host, useTLS)
+		}
+	}
+	return "", fmt.Errorf("no endpoint with ID %s in collection", endpointID)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// driftHeaders are the headers whose evolution is tracked per host: server
+// banners and the security headers a mid-test deployment is most likely to
+// touch.
+var driftHeaders = []string{
+	"Server",
+	"X-Powered-By",
+	"Via",
+	"Content-Security-Policy",
+	"Strict-Transport-Security",
+	"X-Frame-Options",
+}
+
+// HeaderObservation is one observed value of a tracked header, recorded only
+// when the value differs from the previous observation.
+type HeaderObservation struct {
+	Time          time.Time `json:"time"`
+	TransactionID string    `json:"transactionId"`
+	Value         string    `json:"value"`
+}
+
+// HeaderTimeline is one header's history on a host.
+type HeaderTimeline struct {
+	Header       string              `json:"header"`
+	Observations []HeaderObservation `json:"observations"`
+	// Changed marks headers whose value shifted during the engagement.
+	Changed bool `json:"changed"`
+}
+
+// HostDrift is the drift report for one host.
+type HostDrift struct {
+	Host      string           `json:"host"`
+	Timelines []HeaderTimeline `json:"timelines"`
+	Changed   bool             `json:"changed"`
+}
+
+// GetConfigurationDrift walks the full history in capture order and reports
+// how tracked headers changed per host, surfacing mid-test deployments that
+// may invalidate earlier results.
+func (a *App) GetConfigurationDrift() []HostDrift {
+	type timelineKey struct{ host, header string }
+	timelines := make(map[timelineKey]*HeaderTimeline)
+	hosts := make(map[string]bool)
+
+	for _, tx := range a.store.All() {
+		if tx.Response == nil {
+			continue
+		}
+		resp, err := network.ParseResponseDump(tx.Response.Dump)
+		if err != nil {
+			continue
+		}
+		hosts[tx.Request.Host] = true
+		for _, header := range driftHeaders {
+			value := resp.Header.Get(header)
+			key := timelineKey{tx.Request.Host, header}
+			tl := timelines[key]
+			if tl == nil {
+				tl = &HeaderTimeline{Header: header}
+				timelines[key] = tl
+			}
+			if n := len(tl.Observations); n > 0 && tl.Observations[n-1].Value == value {
+				continue
+			}
+			tl.Observations = append(tl.Observations, HeaderObservation{
+				Time:          tx.Timestamp,
+				TransactionID: tx.ID,
+				Value:         value,
+			})
+		}
+	}
+
+	var report []HostDrift
+	for host := range hosts {
+		drift := HostDrift{Host: host}
+		for _, header := range driftHeaders {
+			tl := timelines[timelineKey{host, header}]
+			if tl == nil || (len(tl.Observations) == 1 && tl.Observations[0].Value == "") {
+				continue
+			}
+			tl.Changed = len(tl.Observations) > 1
+			if tl.Changed {
+				drift.Changed = true
+			}
+			drift.Timelines = append(drift.Timelines, *tl)
+		}
+		if len(drift.Timelines) > 0 {
+			report = append(report, drift)
+		}
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Host < report[j].Host })
+	return report
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"gleip/backend/gleipflow"
+	"gleip/backend/network"
+	"gleip/backend/oast"
+	"gleip/backend/project"
+	"gleip/backend/scope"
+	"gleip/backend/telemetry"
+)
+
+// ExportCriteria selects what goes into a subset export. Zero values mean
+// "no restriction" for that dimension.
+type ExportCriteria struct {
+	// HostPattern keeps only transactions and flows touching matching hosts
+	// (glob, e.g. "*.example.com").
+	HostPattern string `json:"hostPattern,omitempty"`
+	// From / To bound the capture time.
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+	// Tags keeps only transactions carrying at least one of these tags.
+	Tags []string `json:"tags,omitempty"`
+}
+
+func (c *ExportCriteria) matchesTime(t time.Time) bool {
+	if !c.From.IsZero() && t.Before(c.From) {
+		return false
+	}
+	if !c.To.IsZero() && t.After(c.To) {
+		return false
+	}
+	return true
+}
+
+func (c *ExportCriteria) matchesTransaction(tx *network.HTTPTransaction) bool {
+	if c.HostPattern != "" && !scope.MatchGlob(c.HostPattern, hostOnly(tx.Request.Host)) {
+		return false
+	}
+	if !c.matchesTime(tx.Timestamp) {
+		return false
+	}
+	if len(c.Tags) > 0 {
+		found := false
+		for _, tag := range c.Tags {
+			if tx.HasTag(tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ExportCriteria) matchesFlow(flow *gleipflow.GleipFlow) bool {
+	if !c.matchesTime(flow.UpdatedAt) {
+		return false
+	}
+	if c.HostPattern == "" {
+		return true
+	}
+	for _, step := range flow.Steps {
+		if step.Request != nil && scope.MatchGlob(c.HostPattern, hostOnly(step.Request.Host)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportProjectSubset writes a valid .gleip file to path containing only the
+// transactions, flows, and OAST findings matching the criteria, so a client
+// can be handed exactly the evidence relevant to their asset.
+func (a *App) ExportProjectSubset(criteria ExportCriteria, path string) error {
+	a.project.RLock()
+	sub := project.New(a.project.Name + " (subset)")
+	sub.Scope = a.project.Scope
+	sub.ClientProfiles = append([]*gleipflow.ClientProfile(nil), a.project.ClientProfiles...)
+	for _, flow := range a.project.GleipFlows {
+		if criteria.matchesFlow(flow) {
+			sub.GleipFlows = append(sub.GleipFlows, flow)
+		}
+	}
+	oastDomain := a.project.OASTDomain
+	payloads := append([]*oast.IssuedPayload(nil), a.project.OASTPayloads...)
+	a.project.RUnlock()
+
+	exported := make(map[string]bool)
+	excludeTelemetry := a.GetExcludeTelemetry()
+	for _, tx := range a.store.All() {
+		if excludeTelemetry && tx.HasTag(telemetry.Tag) {
+			continue
+		}
+		if criteria.matchesTransaction(tx) {
+			sub.Transactions = append(sub.Transactions, tx)
+			exported[tx.ID] = true
+		}
+	}
+	sub.OASTDomain = oastDomain
+	for _, p := range payloads {
+		if len(p.Interactions) == 0 {
+			continue
+		}
+		if !criteria.matchesTime(p.CreatedAt) {
+			continue
+		}
+		if criteria.HostPattern != "" && (p.TransactionID == "" || !exported[p.TransactionID]) {
+			continue
+		}
+		sub.OASTPayloads = append(sub.OASTPayloads, p)
+	}
+
+	if len(sub.Transactions) == 0 && len(sub.GleipFlows) == 0 && len(sub.OASTPayloads) == 0 {
+		return fmt.Errorf("nothing matches the export criteria")
+	}
+	return sub.Save(path)
+}
+
+// csvColumns maps selectable column names to their summary values.
+var csvColumns = map[string]func(network.HTTPTransactionSummary) string{
+	"seq":      func(s network.HTTPTransactionSummary) string { return strconv.FormatInt(s.SeqNumber, 10) },
+	"time":     func(s network.HTTPTransactionSummary) string { return s.Timestamp.Format(time.RFC3339) },
+	"method":   func(s network.HTTPTransactionSummary) string { return s.Method },
+	"host":     func(s network.HTTPTransactionSummary) string { return s.Host },
+	"path":     func(s network.HTTPTransactionSummary) string { return s.Path },
+	"status":   func(s network.HTTPTransactionSummary) string { return strconv.Itoa(s.StatusCode) },
+	"size":     func(s network.HTTPTransactionSummary) string { return strconv.Itoa(s.BodySize) },
+	"duration": func(s network.HTTPTransactionSummary) string { return strconv.FormatInt(s.DurationMS, 10) },
+}
+
+// ExportHistoryCSV writes history summaries to a CSV file with the selected
+// columns (nil takes all of seq, time, method, host, path, status, size,
+// duration in that order), for reporting and spreadsheet triage. It returns
+// the number of rows written.
+func (a *App) ExportHistoryCSV(path string, columns []string) (int, error) {
+	if len(columns) == 0 {
+		columns = []string{"seq", "time", "method", "host", "path", "status", "size", "duration"}
+	}
+	for _, column := range columns {
+		if _, ok := csvColumns[column]; !ok {
+			return 0, fmt.Errorf("unknown CSV column %q", column)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.Write(columns); err != nil {
+		return 0, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	rows := 0
+	record := make([]string, len(columns))
+	for _, s := range a.GetProxyRequests() {
+		for i, column := range columns {
+			record[i] = csvColumns[column](s)
+		}
+		if err := w.Write(record); err != nil {
+			return rows, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+		rows++
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return rows, fmt.Errorf("failed to flush CSV file: %w", err)
+	}
+	return rows, nil
+}
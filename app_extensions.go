@@ -0,0 +1,22 @@
+package main
+
+import (
+	"gleip/backend/extension"
+)
+
+// GetExtensions lists registered extensions and their runtime state.
+func (a *App) GetExtensions() []extension.Extension {
+	return a.proxy.Hooks.List()
+}
+
+// SetExtensionEnabled toggles an extension; disabled extensions' traffic
+// hooks are skipped without being unregistered.
+func (a *App) SetExtensionEnabled(id string, enabled bool) error {
+	return a.proxy.Hooks.SetEnabled(id, enabled)
+}
+
+// SetExtensionBudget caps an extension's per-hook execution time in
+// milliseconds; zero restores the default.
+func (a *App) SetExtensionBudget(id string, ms int) error {
+	return a.proxy.Hooks.SetBudget(id, ms)
+}
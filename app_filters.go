@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"gleip/backend/network"
+	"gleip/backend/project"
+)
+
+// SaveFilter stores a named history view on the project; an existing filter
+// with the same name is replaced.
+func (a *App) SaveFilter(filter project.SavedFilter) (*project.SavedFilter, error) {
+	if filter.Name == "" {
+		return nil, fmt.Errorf("filter name must not be empty")
+	}
+	if _, err := filter.Filters.Matcher(); err != nil {
+		return nil, err
+	}
+	switch filter.Sort {
+	case "", "seq", "duration", "size", "status":
+	default:
+		return nil, fmt.Errorf("unknown sort order %q", filter.Sort)
+	}
+	filter.ID = network.NewID()
+	a.project.Lock()
+	replaced := false
+	for i, existing := range a.project.SavedFilters {
+		if existing.Name == filter.Name {
+			filter.ID = existing.ID
+			a.project.SavedFilters[i] = &filter
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		a.project.SavedFilters = append(a.project.SavedFilters, &filter)
+	}
+	a.project.Unlock()
+	return &filter, nil
+}
+
+// GetSavedFilters lists the project's saved history views.
+func (a *App) GetSavedFilters() []*project.SavedFilter {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return append([]*project.SavedFilter(nil), a.project.SavedFilters...)
+}
+
+// DeleteSavedFilter removes a saved filter by ID.
+func (a *App) DeleteSavedFilter(id string) error {
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.SavedFilters {
+		if existing.ID == id {
+			a.project.SavedFilters = append(a.project.SavedFilters[:i], a.project.SavedFilters[i+1:]...)
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no saved filter with ID %s", id)
+	}
+	return nil
+}
+
+// ApplySavedFilter runs a saved filter against history and returns matching
+// summaries in its sort order.
+func (a *App) ApplySavedFilter(id string) ([]network.HTTPTransactionSummary, error) {
+	a.project.RLock()
+	var filter *project.SavedFilter
+	for _, existing := range a.project.SavedFilters {
+		if existing.ID == id {
+			filter = existing
+			break
+		}
+	}
+	a.project.RUnlock()
+	if filter == nil {
+		return nil, fmt.Errorf("no saved filter with ID %s", id)
+	}
+	matches, err := a.SearchTransactions(filter.Filters)
+	if err != nil {
+		return nil, err
+	}
+	sortSummaries(matches, filter.Sort, filter.SortDesc)
+	return matches, nil
+}
+
+// sortSummaries orders summaries by the saved filter's sort key.
+func sortSummaries(summaries []network.HTTPTransactionSummary, key string, desc bool) {
+	var less func(i, j int) bool
+	switch key {
+	case "duration":
+		less = func(i, j int) bool { return summaries[i].DurationMS < summaries[j].DurationMS }
+	case "size":
+		less = func(i, j int) bool { return summaries[i].BodySize < summaries[j].BodySize }
+	case "status":
+		less = func(i, j int) bool { return summaries[i].StatusCode < summaries[j].StatusCode }
+	default:
+		less = func(i, j int) bool { return summaries[i].SeqNumber < summaries[j].SeqNumber }
+	}
+	if desc {
+		inner := less
+		less = func(i, j int) bool { return inner(j, i) }
+	}
+	sort.SliceStable(summaries, less)
+}
@@ -0,0 +1,450 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gleip/backend/events"
+	"gleip/backend/gleipflow"
+	"gleip/backend/network"
+)
+
+// CreateGleipFlow creates an empty flow on the project.
+func (a *App) CreateGleipFlow(name string) *gleipflow.GleipFlow {
+	now := time.Now()
+	flow := &gleipflow.GleipFlow{
+		ID:        network.NewID(),
+		Name:      name,
+		Variables: make(map[string]string),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	a.project.Lock()
+	a.project.GleipFlows = append(a.project.GleipFlows, flow)
+	a.project.Unlock()
+	return flow
+}
+
+// GetGleipFlows lists the project's flows.
+func (a *App) GetGleipFlows() []*gleipflow.GleipFlow {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make([]*gleipflow.GleipFlow, len(a.project.GleipFlows))
+	copy(out, a.project.GleipFlows)
+	return out
+}
+
+// GetGleipFlow returns one flow by ID.
+func (a *App) GetGleipFlow(id string) (*gleipflow.GleipFlow, error) {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, flow := range a.project.GleipFlows {
+		if flow.ID == id {
+			return flow, nil
+		}
+	}
+	return nil, fmt.Errorf("no flow with ID %s", id)
+}
+
+// DeleteGleipFlow removes a flow from the project.
+func (a *App) DeleteGleipFlow(id string) error {
+	a.project.Lock()
+	defer a.project.Unlock()
+	for i, flow := range a.project.GleipFlows {
+		if flow.ID == id {
+			a.project.GleipFlows = append(a.project.GleipFlows[:i], a.project.GleipFlows[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no flow with ID %s", id)
+}
+
+// AddRequestStep appends a request step to a flow and returns it.
+func (a *App) AddRequestStep(flowID, name, dump, host string, useTLS bool) (*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	step := &gleipflow.Step{
+		ID:      network.NewID(),
+		Type:    gleipflow.StepRequest,
+		Name:    name,
+		Request: &gleipflow.RequestStep{Dump: dump, Host: host, TLS: useTLS},
+	}
+	a.project.Lock()
+	flow.Steps = append(flow.Steps, step)
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return step, nil
+}
+
+// AddConditionStep appends a condition (if/else) step to a flow and returns
+// it. Branch steps are added with AddBranchRequestStep.
+func (a *App) AddConditionStep(flowID, name string, cond gleipflow.Condition) (*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	if err := cond.Validate(); err != nil {
+		return nil, err
+	}
+	step := &gleipflow.Step{
+		ID:        network.NewID(),
+		Type:      gleipflow.StepCondition,
+		Name:      name,
+		Condition: &gleipflow.ConditionStep{If: cond},
+	}
+	a.project.Lock()
+	flow.Steps = append(flow.Steps, step)
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return step, nil
+}
+
+// AddBranchRequestStep appends a request step to one branch ("then" or
+// "else") of a condition step and returns it.
+func (a *App) AddBranchRequestStep(flowID, conditionStepID, branch, name, dump, host string, useTLS bool) (*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	parent, _ := flow.FindStep(conditionStepID)
+	if parent == nil || parent.Condition == nil {
+		return nil, fmt.Errorf("no condition step with ID %s in flow %s", conditionStepID, flowID)
+	}
+	step := &gleipflow.Step{
+		ID:      network.NewID(),
+		Type:    gleipflow.StepRequest,
+		Name:    name,
+		Request: &gleipflow.RequestStep{Dump: dump, Host: host, TLS: useTLS},
+	}
+	a.project.Lock()
+	defer a.project.Unlock()
+	switch branch {
+	case "then":
+		parent.Condition.Then = append(parent.Condition.Then, step)
+	case "else":
+		parent.Condition.Else = append(parent.Condition.Else, step)
+	default:
+		return nil, fmt.Errorf("unknown branch %q (use then or else)", branch)
+	}
+	flow.UpdatedAt = time.Now()
+	return step, nil
+}
+
+// AddLoopStep appends a loop step to a flow and returns it. Any steps in
+// loop.Body are discarded; body steps are added with AddLoopBodyRequestStep.
+func (a *App) AddLoopStep(flowID, name string, loop gleipflow.LoopStep) (*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	if err := loop.Validate(); err != nil {
+		return nil, err
+	}
+	loop.Body = nil
+	step := &gleipflow.Step{
+		ID:   network.NewID(),
+		Type: gleipflow.StepLoop,
+		Name: name,
+		Loop: &loop,
+	}
+	a.project.Lock()
+	flow.Steps = append(flow.Steps, step)
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return step, nil
+}
+
+// AddLoopBodyRequestStep appends a request step to a loop step's body and
+// returns it.
+func (a *App) AddLoopBodyRequestStep(flowID, loopStepID, name, dump, host string, useTLS bool) (*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	parent, _ := flow.FindStep(loopStepID)
+	if parent == nil || parent.Loop == nil {
+		return nil, fmt.Errorf("no loop step with ID %s in flow %s", loopStepID, flowID)
+	}
+	step := &gleipflow.Step{
+		ID:      network.NewID(),
+		Type:    gleipflow.StepRequest,
+		Name:    name,
+		Request: &gleipflow.RequestStep{Dump: dump, Host: host, TLS: useTLS},
+	}
+	a.project.Lock()
+	parent.Loop.Body = append(parent.Loop.Body, step)
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return step, nil
+}
+
+// AddParallelStep appends a parallel group step to a flow and returns it.
+// workers caps concurrency (0 runs all group members at once); member
+// requests are added with AddParallelRequestStep.
+func (a *App) AddParallelStep(flowID, name string, workers int) (*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	group := gleipflow.ParallelStep{Workers: workers}
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+	step := &gleipflow.Step{
+		ID:       network.NewID(),
+		Type:     gleipflow.StepParallel,
+		Name:     name,
+		Parallel: &group,
+	}
+	a.project.Lock()
+	flow.Steps = append(flow.Steps, step)
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return step, nil
+}
+
+// AddParallelRequestStep appends a request step to a parallel group and
+// returns it.
+func (a *App) AddParallelRequestStep(flowID, parallelStepID, name, dump, host string, useTLS bool) (*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	parent, _ := flow.FindStep(parallelStepID)
+	if parent == nil || parent.Parallel == nil {
+		return nil, fmt.Errorf("no parallel step with ID %s in flow %s", parallelStepID, flowID)
+	}
+	step := &gleipflow.Step{
+		ID:      network.NewID(),
+		Type:    gleipflow.StepRequest,
+		Name:    name,
+		Request: &gleipflow.RequestStep{Dump: dump, Host: host, TLS: useTLS},
+	}
+	a.project.Lock()
+	parent.Parallel.Steps = append(parent.Parallel.Steps, step)
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return step, nil
+}
+
+// AddDelayStep appends a delay step to a flow and returns it.
+func (a *App) AddDelayStep(flowID, name string, delay gleipflow.DelayStep) (*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	if err := delay.Validate(); err != nil {
+		return nil, err
+	}
+	step := &gleipflow.Step{
+		ID:    network.NewID(),
+		Type:  gleipflow.StepDelay,
+		Name:  name,
+		Delay: &delay,
+	}
+	a.project.Lock()
+	flow.Steps = append(flow.Steps, step)
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return step, nil
+}
+
+// lookupFlowVariable reads a flow variable under the project lock; wired to
+// the executor so until-variable delay steps see values set mid-run.
+func (a *App) lookupFlowVariable(flowID, name string) (string, bool) {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, flow := range a.project.GleipFlows {
+		if flow.ID == flowID {
+			value, ok := flow.Variables[name]
+			return value, ok
+		}
+	}
+	return "", false
+}
+
+// SetFlowVariable sets a variable in a flow's substitution context.
+func (a *App) SetFlowVariable(flowID, name, value string) error {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return err
+	}
+	a.project.Lock()
+	if flow.Variables == nil {
+		flow.Variables = make(map[string]string)
+	}
+	flow.Variables[name] = value
+	a.project.Unlock()
+	return nil
+}
+
+// ExecuteGleipFlow runs a flow and returns this run's step results.
+func (a *App) ExecuteGleipFlow(id string) ([]*gleipflow.ExecutionResult, error) {
+	flow, err := a.GetGleipFlow(id)
+	if err != nil {
+		return nil, err
+	}
+	results := a.executor.Execute(flow)
+	a.recordFlowResults(flow, results)
+	a.metrics.Inc("gleip_flow_runs_total", map[string]string{"flow": flow.Name})
+	for _, result := range results {
+		a.metrics.Observe("gleip_flow_step_duration_seconds", map[string]string{"flow": flow.Name}, result.Duration.Seconds())
+		if result.Error != "" {
+			a.metrics.Inc("gleip_flow_step_errors_total", map[string]string{"flow": flow.Name})
+		}
+	}
+	a.traceFlowResults(flow.Name, results)
+	events.Emit("flow:executed", flow.ID)
+	return results, nil
+}
+
+// GetClientProfiles lists built-in and project-defined client fingerprint
+// profiles.
+func (a *App) GetClientProfiles() []*gleipflow.ClientProfile {
+	out := gleipflow.BuiltinProfiles()
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return append(out, a.project.ClientProfiles...)
+}
+
+// CreateClientProfile stores a custom client profile on the project.
+func (a *App) CreateClientProfile(name string, headers []gleipflow.ProfileHeader) *gleipflow.ClientProfile {
+	profile := &gleipflow.ClientProfile{ID: network.NewID(), Name: name, Headers: headers}
+	a.project.Lock()
+	a.project.ClientProfiles = append(a.project.ClientProfiles, profile)
+	a.project.Unlock()
+	return profile
+}
+
+// DeleteClientProfile removes a custom profile; built-ins cannot be deleted.
+func (a *App) DeleteClientProfile(id string) error {
+	a.project.Lock()
+	defer a.project.Unlock()
+	for i, p := range a.project.ClientProfiles {
+		if p.ID == id {
+			a.project.ClientProfiles = append(a.project.ClientProfiles[:i], a.project.ClientProfiles[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no client profile with ID %s", id)
+}
+
+// SetFlowClientProfile selects the fingerprint profile for all of a flow's
+// request steps ("" clears it).
+func (a *App) SetFlowClientProfile(flowID, profileID string) error {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return err
+	}
+	if profileID != "" && a.lookupClientProfile(profileID) == nil {
+		return fmt.Errorf("no client profile with ID %s", profileID)
+	}
+	a.project.Lock()
+	flow.ClientProfileID = profileID
+	a.project.Unlock()
+	return nil
+}
+
+// SetStepClientProfile overrides the fingerprint profile for a single
+// request step ("" falls back to the flow profile).
+func (a *App) SetStepClientProfile(flowID, stepID, profileID string) error {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return err
+	}
+	if profileID != "" && a.lookupClientProfile(profileID) == nil {
+		return fmt.Errorf("no client profile with ID %s", profileID)
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil || step.Request == nil {
+		return fmt.Errorf("no request step with ID %s in flow %s", stepID, flowID)
+	}
+	a.project.Lock()
+	step.Request.ClientProfileID = profileID
+	a.project.Unlock()
+	return nil
+}
+
+// SetStepRawMode toggles byte-for-byte sending for a request step; see
+// RequestStep.Raw.
+func (a *App) SetStepRawMode(flowID, stepID string, raw bool) error {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return err
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil || step.Request == nil {
+		return fmt.Errorf("no request step with ID %s in flow %s", stepID, flowID)
+	}
+	a.project.Lock()
+	step.Request.Raw = raw
+	a.project.Unlock()
+	return nil
+}
+
+// ValidateRequestStep lints a request step against the flow's variables
+// before sending, so flows fail fast with clear messages instead of opaque
+// network errors. An empty result means the step looks sendable.
+func (a *App) ValidateRequestStep(flowID, stepID string) ([]gleipflow.LintWarning, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil || step.Request == nil {
+		return nil, fmt.Errorf("no request step with ID %s in flow %s", stepID, flowID)
+	}
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return gleipflow.LintRequestDump(step.Request.Dump, flow.Variables), nil
+}
+
+// SendRawRequest transmits dump to host byte-for-byte (repeater-style, no
+// flow involved) and returns the response dump.
+func (a *App) SendRawRequest(host string, useTLS bool, dump string) (string, error) {
+	return a.executor.SendRaw(host, useTLS, dump)
+}
+
+// SendRawRequestTo is SendRawRequest with a per-request DNS override: the
+// connection goes to resolveTo (ip or ip:port) while the dump's Host header
+// stays untouched, so prod hostnames can be replayed against staging.
+func (a *App) SendRawRequestTo(host, resolveTo string, useTLS bool, dump string) (string, error) {
+	return a.executor.SendRawResolved(host, resolveTo, useTLS, dump)
+}
+
+// SetStepResolveTo sets or clears ("" restores DNS) the per-step resolve-to
+// IP override on a request step.
+func (a *App) SetStepResolveTo(flowID, stepID, resolveTo string) error {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return err
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil || step.Request == nil {
+		return fmt.Errorf("no request step with ID %s in flow %s", stepID, flowID)
+	}
+	a.project.Lock()
+	step.Request.ResolveTo = resolveTo
+	a.project.Unlock()
+	return nil
+}
+
+// lookupClientProfile resolves a profile ID against built-ins and the
+// project's custom profiles.
+func (a *App) lookupClientProfile(id string) *gleipflow.ClientProfile {
+	for _, p := range gleipflow.BuiltinProfiles() {
+		if p.ID == id {
+			return p
+		}
+	}
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, p := range a.project.ClientProfiles {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
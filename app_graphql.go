@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/network"
+)
+
+// GetGraphQLOperations parses a transaction's request body as GraphQL and
+// returns its operations (one, or several for batched requests), so the UI
+// and flow variable extraction can target operation names, top-level fields,
+// and variables instead of raw JSON strings.
+func (a *App) GetGraphQLOperations(id string) ([]network.GraphQLOperation, error) {
+	tx, ok := a.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", id)
+	}
+	req, err := network.ParseRequestDump(tx.Request.Dump)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request: %w", err)
+	}
+	_, body := network.SplitDump(tx.Request.Dump)
+	if !network.IsGraphQLRequest(req.Header.Get("Content-Type"), body) {
+		return nil, fmt.Errorf("transaction %s does not carry a GraphQL request", id)
+	}
+	return network.ParseGraphQL(body)
+}
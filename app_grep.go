@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"gleip/backend/network"
+	"gleip/backend/telemetry"
+)
+
+// Caps on grep output so a low-entropy pattern against a huge capture returns
+// a bounded payload instead of every byte of every response.
+const (
+	grepMaxMatchesPerTransaction = 10
+	grepMaxMatchesTotal          = 1000
+	grepSnippetContext           = 40
+)
+
+// GrepMatch is one occurrence of a grep pattern in a response body. Start and
+// End are byte offsets into the stored response dump, so they can be fed
+// straight into AddResponseAnnotation.
+type GrepMatch struct {
+	TransactionID string `json:"transactionId"`
+	SeqNumber     int64  `json:"seqNumber"`
+	Host          string `json:"host"`
+	Path          string `json:"path"`
+	Start         int    `json:"start"`
+	End           int    `json:"end"`
+	// Snippet is the matched text with surrounding context for preview.
+	Snippet string `json:"snippet"`
+}
+
+// GrepResponses searches every stored response body for a pattern (a regex
+// when isRegex is set, a literal substring otherwise; both case-insensitive)
+// and returns the matches with offsets and context snippets. It streams
+// transactions from the store one at a time, so hunting reflected values and
+// leaked secrets stays cheap even on day-long captures.
+func (a *App) GrepResponses(pattern string, isRegex bool) ([]GrepMatch, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("grep pattern is required")
+	}
+	if !isRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	re, err := network.CompileCached("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grep pattern: %w", err)
+	}
+	excludeTelemetry := a.GetExcludeTelemetry()
+	var matches []GrepMatch
+	a.store.ForEach(func(tx *network.HTTPTransaction) bool {
+		if tx.Response == nil || tx.Response.Dump == "" {
+			return true
+		}
+		if excludeTelemetry && tx.HasTag(telemetry.Tag) {
+			return true
+		}
+		_, body := network.SplitDump(tx.Response.Dump)
+		bodyOffset := len(tx.Response.Dump) - len(body)
+		for _, loc := range re.FindAllStringIndex(body, grepMaxMatchesPerTransaction) {
+			start, end := loc[0], loc[1]
+			snipStart := start - grepSnippetContext
+			if snipStart < 0 {
+				snipStart = 0
+			}
+			snipEnd := end + grepSnippetContext
+			if snipEnd > len(body) {
+				snipEnd = len(body)
+			}
+			matches = append(matches, GrepMatch{
+				TransactionID: tx.ID,
+				SeqNumber:     tx.SeqNumber,
+				Host:          tx.Request.Host,
+				Path:          tx.Request.Path,
+				Start:         bodyOffset + start,
+				End:           bodyOffset + end,
+				Snippet:       body[snipStart:snipEnd],
+			})
+			if len(matches) >= grepMaxMatchesTotal {
+				return false
+			}
+		}
+		return true
+	})
+	return matches, nil
+}
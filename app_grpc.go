@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gleip/backend/network"
+	"gleip/backend/protodec"
+)
+
+// GRPCDecoded is the protobuf view of one transaction: decoded request and
+// response frames.
+type GRPCDecoded struct {
+	Request  []protodec.Frame `json:"request,omitempty"`
+	Response []protodec.Frame `json:"response,omitempty"`
+}
+
+// LoadProtoFile parses message definitions from a .proto file into the
+// schema registry, returning how many messages were registered. Loaded
+// schemas add field names to gRPC decoding.
+func (a *App) LoadProtoFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read proto file: %w", err)
+	}
+	return a.protoSchemas.LoadProto(string(data))
+}
+
+// GetProtoMessageNames lists the message types loaded from .proto files.
+func (a *App) GetProtoMessageNames() []string {
+	return a.protoSchemas.MessageNames()
+}
+
+// DecodeGRPCTransaction decodes the protobuf payloads of a transaction.
+// requestMessage and responseMessage optionally name loaded schema types to
+// decode against; empty strings fall back to schemaless heuristics.
+func (a *App) DecodeGRPCTransaction(id, requestMessage, responseMessage string) (*GRPCDecoded, error) {
+	tx, ok := a.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", id)
+	}
+	out := &GRPCDecoded{}
+	decodedAny := false
+
+	req, err := network.ParseRequestDump(tx.Request.Dump)
+	if err == nil && protodec.IsProtobufContentType(req.Header.Get("Content-Type")) {
+		_, body := network.SplitDump(tx.Request.Dump)
+		frames, err := protodec.DecodeBody(req.Header.Get("Content-Type"), []byte(body), a.protoSchemas.Lookup(requestMessage))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode request: %w", err)
+		}
+		out.Request = frames
+		decodedAny = true
+	}
+	if tx.Response != nil {
+		resp, err := network.ParseResponseDump(tx.Response.Dump)
+		if err == nil && protodec.IsProtobufContentType(resp.Header.Get("Content-Type")) {
+			_, body := network.SplitDump(tx.Response.Dump)
+			frames, err := protodec.DecodeBody(resp.Header.Get("Content-Type"), []byte(body), a.protoSchemas.Lookup(responseMessage))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode response: %w", err)
+			}
+			out.Response = frames
+			decodedAny = true
+		}
+	}
+	if !decodedAny {
+		return nil, fmt.Errorf("transaction %s carries no protobuf content", id)
+	}
+	return out, nil
+}
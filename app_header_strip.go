@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/network"
+	"gleip/backend/proxy"
+)
+
+// GetHeaderStripRules lists the project's response header stripping rules.
+func (a *App) GetHeaderStripRules() []*proxy.HeaderStripRule {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make([]*proxy.HeaderStripRule, len(a.project.HeaderStripRules))
+	copy(out, a.project.HeaderStripRules)
+	return out
+}
+
+// SetHeaderStrippingEnabled flips the master switch for header stripping.
+func (a *App) SetHeaderStrippingEnabled(enabled bool) {
+	a.project.Lock()
+	a.project.HeaderStripEnabled = enabled
+	a.project.Unlock()
+	a.syncHeaderStripRules()
+}
+
+// AddHeaderStripRule validates and stores a new stripping rule.
+func (a *App) AddHeaderStripRule(rule proxy.HeaderStripRule) (*proxy.HeaderStripRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.ID = network.NewID()
+	a.project.Lock()
+	a.project.HeaderStripRules = append(a.project.HeaderStripRules, &rule)
+	a.project.Unlock()
+	a.syncHeaderStripRules()
+	return &rule, nil
+}
+
+// UpdateHeaderStripRule replaces an existing rule by ID.
+func (a *App) UpdateHeaderStripRule(rule proxy.HeaderStripRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.HeaderStripRules {
+		if existing.ID == rule.ID {
+			a.project.HeaderStripRules[i] = &rule
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no header strip rule with ID %s", rule.ID)
+	}
+	a.syncHeaderStripRules()
+	return nil
+}
+
+// DeleteHeaderStripRule removes a rule by ID.
+func (a *App) DeleteHeaderStripRule(id string) error {
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.HeaderStripRules {
+		if existing.ID == id {
+			a.project.HeaderStripRules = append(a.project.HeaderStripRules[:i], a.project.HeaderStripRules[i+1:]...)
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no header strip rule with ID %s", id)
+	}
+	a.syncHeaderStripRules()
+	return nil
+}
+
+// AddDefaultHeaderStripRules seeds the project with the common set of
+// testing-hostile headers (CSP, HSTS, X-Frame-Options, caching).
+func (a *App) AddDefaultHeaderStripRules() []*proxy.HeaderStripRule {
+	defaults := proxy.DefaultHeaderStripRules()
+	a.project.Lock()
+	for _, rule := range defaults {
+		rule.ID = network.NewID()
+		rule.Enabled = true
+		a.project.HeaderStripRules = append(a.project.HeaderStripRules, rule)
+	}
+	a.project.Unlock()
+	a.syncHeaderStripRules()
+	return defaults
+}
+
+// syncHeaderStripRules pushes the project's stripping config into the proxy.
+func (a *App) syncHeaderStripRules() {
+	a.project.RLock()
+	enabled := a.project.HeaderStripEnabled
+	rules := append([]*proxy.HeaderStripRule(nil), a.project.HeaderStripRules...)
+	a.project.RUnlock()
+	a.proxy.HeaderStrip.Set(enabled, rules)
+}
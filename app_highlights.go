@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/network"
+)
+
+// observeHighlights applies the project's highlight rules to each completed
+// transaction. Registered as a proxy transaction observer.
+func (a *App) observeHighlights(tx *network.HTTPTransaction) {
+	a.project.RLock()
+	rules := append([]*network.HighlightRule(nil), a.project.HighlightRules...)
+	a.project.RUnlock()
+	tagged := false
+	for _, rule := range rules {
+		if rule.Enabled && rule.Matches(tx) {
+			tx.AddTag(rule.AppliedTag())
+			tagged = true
+		}
+	}
+	if tagged {
+		a.store.Update(tx)
+	}
+}
+
+// GetHighlightRules lists the project's highlight rules.
+func (a *App) GetHighlightRules() []*network.HighlightRule {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return append([]*network.HighlightRule(nil), a.project.HighlightRules...)
+}
+
+// AddHighlightRule validates and stores a new highlight rule, active
+// immediately for subsequent captures.
+func (a *App) AddHighlightRule(rule network.HighlightRule) (*network.HighlightRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.ID = network.NewID()
+	a.project.Lock()
+	a.project.HighlightRules = append(a.project.HighlightRules, &rule)
+	a.project.Unlock()
+	return &rule, nil
+}
+
+// UpdateHighlightRule replaces an existing highlight rule by ID.
+func (a *App) UpdateHighlightRule(rule network.HighlightRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.HighlightRules {
+		if existing.ID == rule.ID {
+			a.project.HighlightRules[i] = &rule
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no highlight rule with ID %s", rule.ID)
+	}
+	return nil
+}
+
+// DeleteHighlightRule removes a highlight rule by ID.
+func (a *App) DeleteHighlightRule(id string) error {
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.HighlightRules {
+		if existing.ID == id {
+			a.project.HighlightRules = append(a.project.HighlightRules[:i], a.project.HighlightRules[i+1:]...)
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no highlight rule with ID %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gleip/backend/network"
+	"gleip/backend/project"
+)
+
+// GetHostNotes returns the notes and stored credentials for a host, or an
+// empty note when none exist yet. The host may include a port.
+func (a *App) GetHostNotes(host string) *project.HostNote {
+	host = project.NormalizeHost(host)
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, note := range a.project.HostNotes {
+		if note.Host == host {
+			return note
+		}
+	}
+	return &project.HostNote{Host: host}
+}
+
+// SetHostNotes stores the free-form notes for a host, creating the entry if
+// needed. Empty notes with no credentials remove the entry.
+func (a *App) SetHostNotes(host, notes string) {
+	host = project.NormalizeHost(host)
+	a.project.Lock()
+	defer a.project.Unlock()
+	for i, note := range a.project.HostNotes {
+		if note.Host != host {
+			continue
+		}
+		if notes == "" && len(note.Credentials) == 0 {
+			a.project.HostNotes = append(a.project.HostNotes[:i], a.project.HostNotes[i+1:]...)
+			return
+		}
+		note.Notes = notes
+		note.UpdatedAt = time.Now()
+		return
+	}
+	if notes == "" {
+		return
+	}
+	a.project.HostNotes = append(a.project.HostNotes, &project.HostNote{
+		Host: host, Notes: notes, UpdatedAt: time.Now(),
+	})
+}
+
+// AddHostCredential stores a test credential against a host.
+func (a *App) AddHostCredential(host string, cred project.HostCredential) (*project.HostCredential, error) {
+	if cred.Username == "" {
+		return nil, fmt.Errorf("credential username is required")
+	}
+	host = project.NormalizeHost(host)
+	cred.ID = network.NewID()
+	a.project.Lock()
+	defer a.project.Unlock()
+	for _, note := range a.project.HostNotes {
+		if note.Host == host {
+			note.Credentials = append(note.Credentials, &cred)
+			note.UpdatedAt = time.Now()
+			return &cred, nil
+		}
+	}
+	a.project.HostNotes = append(a.project.HostNotes, &project.HostNote{
+		Host: host, Credentials: []*project.HostCredential{&cred}, UpdatedAt: time.Now(),
+	})
+	return &cred, nil
+}
+
+// DeleteHostCredential removes a stored credential by ID.
+func (a *App) DeleteHostCredential(host, credentialID string) error {
+	host = project.NormalizeHost(host)
+	a.project.Lock()
+	defer a.project.Unlock()
+	for _, note := range a.project.HostNotes {
+		if note.Host != host {
+			continue
+		}
+		for i, cred := range note.Credentials {
+			if cred.ID == credentialID {
+				note.Credentials = append(note.Credentials[:i], note.Credentials[i+1:]...)
+				note.UpdatedAt = time.Now()
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("no credential with ID %s for host %s", credentialID, host)
+}
+
+// GetAnnotatedHosts lists every host that has notes or credentials, for the
+// quick panel.
+func (a *App) GetAnnotatedHosts() []string {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make([]string, 0, len(a.project.HostNotes))
+	for _, note := range a.project.HostNotes {
+		out = append(out, note.Host)
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"net"
+)
+
+// GetHostsOverrides returns the project's hostname→IP override table.
+func (a *App) GetHostsOverrides() map[string]string {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make(map[string]string, len(a.project.HostsOverrides))
+	for host, ip := range a.project.HostsOverrides {
+		out[host] = ip
+	}
+	return out
+}
+
+// SetHostsOverride maps a hostname to an IP for outbound resolution by the
+// proxy and flow executor.
+func (a *App) SetHostsOverride(hostname, ip string) error {
+	if hostname == "" {
+		return fmt.Errorf("hostname is required")
+	}
+	if net.ParseIP(ip) == nil {
+		return fmt.Errorf("%q is not a valid IP address", ip)
+	}
+	a.project.Lock()
+	if a.project.HostsOverrides == nil {
+		a.project.HostsOverrides = make(map[string]string)
+	}
+	a.project.HostsOverrides[hostname] = ip
+	a.project.Unlock()
+	a.syncHostsOverrides()
+	return nil
+}
+
+// DeleteHostsOverride removes a hostname mapping.
+func (a *App) DeleteHostsOverride(hostname string) error {
+	a.project.Lock()
+	_, ok := a.project.HostsOverrides[hostname]
+	delete(a.project.HostsOverrides, hostname)
+	a.project.Unlock()
+	if !ok {
+		return fmt.Errorf("no hosts override for %q", hostname)
+	}
+	a.syncHostsOverrides()
+	return nil
+}
+
+// syncHostsOverrides pushes the project's table into the shared resolver.
+func (a *App) syncHostsOverrides() {
+	a.proxy.Hosts.Set(a.GetHostsOverrides())
+}
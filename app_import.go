@@ -0,0 +1,58 @@
+package main
+
+import (
+	"gleip/backend/events"
+	"gleip/backend/importers"
+)
+
+// ImportResult summarizes an import run for the UI.
+type ImportResult struct {
+	Imported int      `json:"imported"`
+	Skipped  []string `json:"skipped,omitempty"`
+}
+
+// ImportRawTransactions ingests raw HTTP message files (.txt/.req with
+// optional matching .res) from a directory into history with synthetic
+// timestamps.
+func (a *App) ImportRawTransactions(directory string) (*ImportResult, error) {
+	txs, skipped, err := importers.ImportRawTransactions(directory)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		tx.SeqNumber = a.proxy.NextSeq()
+		a.store.Add(tx)
+		events.Emit("proxy:request", tx.Summary())
+	}
+	return &ImportResult{Imported: len(txs), Skipped: skipped}, nil
+}
+
+// ImportMitmproxyFlows ingests a mitmproxy dump file (.mitm/.flow) into
+// history, preserving the original capture timestamps.
+func (a *App) ImportMitmproxyFlows(path string) (*ImportResult, error) {
+	txs, skipped, err := importers.ImportMitmproxyFlows(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		tx.SeqNumber = a.proxy.NextSeq()
+		a.store.Add(tx)
+		events.Emit("proxy:request", tx.Summary())
+	}
+	return &ImportResult{Imported: len(txs), Skipped: skipped}, nil
+}
+
+// ImportBurpItems ingests a Burp Suite XML item export into history,
+// preserving the original capture timestamps.
+func (a *App) ImportBurpItems(path string) (*ImportResult, error) {
+	txs, skipped, err := importers.ImportBurpItems(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		tx.SeqNumber = a.proxy.NextSeq()
+		a.store.Add(tx)
+		events.Emit("proxy:request", tx.Summary())
+	}
+	return &ImportResult{Imported: len(txs), Skipped: skipped}, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"time"
+
+	"gleip/backend/network"
+)
+
+// ResponseObservation is one response seen for a repeated request.
+type ResponseObservation struct {
+	TransactionID string    `json:"transactionId"`
+	Timestamp     time.Time `json:"timestamp"`
+	StatusCode    int       `json:"statusCode"`
+	BodyHash      string    `json:"bodyHash"`
+}
+
+// EndpointIntegrity tracks response stability for one request signature:
+// whether repeating the same request produced different content over time.
+type EndpointIntegrity struct {
+	Signature    string                `json:"signature"`
+	Method       string                `json:"method"`
+	URL          string                `json:"url"`
+	Observations []ResponseObservation `json:"observations"`
+	// Changed is true when at least two observations carry different body
+	// hashes — the endpoint's content drifted during the engagement.
+	Changed bool `json:"changed"`
+}
+
+// GetEndpointIntegrity groups history by request signature and reports, per
+// repeated request, whether the response content changed between
+// repetitions. Signatures seen only once are omitted unless includeSingles
+// is set.
+func (a *App) GetEndpointIntegrity(includeSingles bool) []EndpointIntegrity {
+	bySig := make(map[string]*EndpointIntegrity)
+	var order []string
+	for _, tx := range a.store.All() {
+		if tx.Response == nil || tx.Response.BodyHash == "" {
+			continue
+		}
+		sig := network.RequestSignature(tx)
+		entry, ok := bySig[sig]
+		if !ok {
+			entry = &EndpointIntegrity{
+				Signature: sig,
+				Method:    tx.Request.Method,
+				URL:       tx.Request.URL,
+			}
+			bySig[sig] = entry
+			order = append(order, sig)
+		}
+		entry.Observations = append(entry.Observations, ResponseObservation{
+			TransactionID: tx.ID,
+			Timestamp:     tx.Timestamp,
+			StatusCode:    tx.Response.StatusCode,
+			BodyHash:      tx.Response.BodyHash,
+		})
+	}
+	var out []EndpointIntegrity
+	for _, sig := range order {
+		entry := bySig[sig]
+		for _, obs := range entry.Observations[1:] {
+			if obs.BodyHash != entry.Observations[0].BodyHash {
+				entry.Changed = true
+				break
+			}
+		}
+		if len(entry.Observations) > 1 || includeSingles {
+			out = append(out, *entry)
+		}
+	}
+	return out
+}
+
+// GetResponseHashTimeline returns the observations for a single transaction's
+// request signature, so the UI can show "this exact request returned 3
+// different responses over time".
+func (a *App) GetResponseHashTimeline(transactionID string) ([]ResponseObservation, error) {
+	target, err := a.GetTransaction(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	sig := network.RequestSignature(target)
+	var out []ResponseObservation
+	for _, tx := range a.store.All() {
+		if tx.Response == nil || network.RequestSignature(tx) != sig {
+			continue
+		}
+		out = append(out, ResponseObservation{
+			TransactionID: tx.ID,
+			Timestamp:     tx.Timestamp,
+			StatusCode:    tx.Response.StatusCode,
+			BodyHash:      tx.Response.BodyHash,
+		})
+	}
+	return out, nil
+}
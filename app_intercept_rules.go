@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/network"
+	"gleip/backend/proxy"
+)
+
+// GetInterceptRules lists the project's conditional intercept rules.
+func (a *App) GetInterceptRules() []*proxy.InterceptRule {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make([]*proxy.InterceptRule, len(a.project.InterceptRules))
+	copy(out, a.project.InterceptRules)
+	return out
+}
+
+// AddInterceptRule validates and stores a new intercept rule, activating it
+// immediately.
+func (a *App) AddInterceptRule(rule proxy.InterceptRule) (*proxy.InterceptRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.ID = network.NewID()
+	a.project.Lock()
+	a.project.InterceptRules = append(a.project.InterceptRules, &rule)
+	a.project.Unlock()
+	a.syncInterceptRules()
+	return &rule, nil
+}
+
+// UpdateInterceptRule replaces an existing intercept rule by ID.
+func (a *App) UpdateInterceptRule(rule proxy.InterceptRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.InterceptRules {
+		if existing.ID == rule.ID {
+			a.project.InterceptRules[i] = &rule
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no intercept rule with ID %s", rule.ID)
+	}
+	a.syncInterceptRules()
+	return nil
+}
+
+// DeleteInterceptRule removes an intercept rule by ID.
+func (a *App) DeleteInterceptRule(id string) error {
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.InterceptRules {
+		if existing.ID == id {
+			a.project.InterceptRules = append(a.project.InterceptRules[:i], a.project.InterceptRules[i+1:]...)
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no intercept rule with ID %s", id)
+	}
+	a.syncInterceptRules()
+	return nil
+}
+
+// SetInterceptRuleEnabled toggles an intercept rule without editing it.
+func (a *App) SetInterceptRuleEnabled(id string, enabled bool) error {
+	a.project.Lock()
+	found := false
+	for _, existing := range a.project.InterceptRules {
+		if existing.ID == id {
+			existing.Enabled = enabled
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no intercept rule with ID %s", id)
+	}
+	a.syncInterceptRules()
+	return nil
+}
+
+// CreateInterceptRuleFromTransaction builds and activates an intercept rule
+// matching a historical transaction's method, host, and path, so "intercept
+// future requests like this" is one action away from history.
+func (a *App) CreateInterceptRuleFromTransaction(txID string) (*proxy.InterceptRule, error) {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", txID)
+	}
+	rule := proxy.InterceptRule{
+		Name:        fmt.Sprintf("%s %s%s", tx.Request.Method, hostOnly(tx.Request.Host), tx.Request.Path),
+		Enabled:     true,
+		Direction:   proxy.DirectionRequest,
+		Methods:     []string{tx.Request.Method},
+		HostPattern: hostOnly(tx.Request.Host),
+		PathPattern: tx.Request.Path,
+	}
+	return a.AddInterceptRule(rule)
+}
+
+// syncInterceptRules pushes the project's intercept rules into the proxy.
+func (a *App) syncInterceptRules() {
+	a.project.RLock()
+	rules := append([]*proxy.InterceptRule(nil), a.project.InterceptRules...)
+	a.project.RUnlock()
+	a.proxy.InterceptRules.Set(rules)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gleip/backend/network"
+	"gleip/backend/proxy"
+)
+
+// macroRecorder accumulates tamper operations while the user edits one
+// intercepted request, so the sequence can be saved as a reusable macro.
+type macroRecorder struct {
+	mu     sync.Mutex
+	active bool
+	ops    []proxy.TamperOp
+}
+
+// StartMacroRecording begins capturing tamper operations. Any previous
+// unsaved recording is discarded.
+func (a *App) StartMacroRecording() {
+	a.macros.mu.Lock()
+	defer a.macros.mu.Unlock()
+	a.macros.active = true
+	a.macros.ops = nil
+}
+
+// CancelMacroRecording discards the current recording.
+func (a *App) CancelMacroRecording() {
+	a.macros.mu.Lock()
+	defer a.macros.mu.Unlock()
+	a.macros.active = false
+	a.macros.ops = nil
+}
+
+// RecordTamperOperation applies one tamper operation to a request dump and
+// returns the result. While a recording is active the operation is appended
+// to it; outside a recording this is just a one-off edit helper.
+func (a *App) RecordTamperOperation(op proxy.TamperOp, dump string) (string, error) {
+	result, err := op.Apply(dump)
+	if err != nil {
+		return "", err
+	}
+	a.macros.mu.Lock()
+	if a.macros.active {
+		a.macros.ops = append(a.macros.ops, op)
+	}
+	a.macros.mu.Unlock()
+	return result, nil
+}
+
+// SaveMacroRecording finishes the active recording and stores it on the
+// project as a macro bound to the given endpoint.
+func (a *App) SaveMacroRecording(name, method, host, path string) (*proxy.InterceptMacro, error) {
+	a.macros.mu.Lock()
+	defer a.macros.mu.Unlock()
+	if !a.macros.active {
+		return nil, fmt.Errorf("no macro recording in progress")
+	}
+	if len(a.macros.ops) == 0 {
+		return nil, fmt.Errorf("recording contains no operations")
+	}
+	macro := &proxy.InterceptMacro{
+		ID:        network.NewID(),
+		Name:      name,
+		Method:    method,
+		Host:      host,
+		Path:      path,
+		Ops:       a.macros.ops,
+		CreatedAt: time.Now(),
+	}
+	a.macros.active = false
+	a.macros.ops = nil
+	a.project.Lock()
+	a.project.InterceptMacros = append(a.project.InterceptMacros, macro)
+	a.project.Unlock()
+	return macro, nil
+}
+
+// GetInterceptMacros lists the project's saved macros.
+func (a *App) GetInterceptMacros() []*proxy.InterceptMacro {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make([]*proxy.InterceptMacro, len(a.project.InterceptMacros))
+	copy(out, a.project.InterceptMacros)
+	return out
+}
+
+// DeleteInterceptMacro removes a saved macro from the project.
+func (a *App) DeleteInterceptMacro(id string) error {
+	a.project.Lock()
+	defer a.project.Unlock()
+	for i, m := range a.project.InterceptMacros {
+		if m.ID == id {
+			a.project.InterceptMacros = append(a.project.InterceptMacros[:i], a.project.InterceptMacros[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no intercept macro with ID %s", id)
+}
+
+// GetMatchingMacros returns the saved macros recorded against the same
+// endpoint as a pending intercepted request.
+func (a *App) GetMatchingMacros(interceptID string) ([]*proxy.InterceptMacro, error) {
+	req, err := a.findInterceptedRequest(interceptID)
+	if err != nil {
+		return nil, err
+	}
+	var matches []*proxy.InterceptMacro
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, m := range a.project.InterceptMacros {
+		if m.Matches(req) {
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+// ApplyInterceptMacro runs a saved macro against a pending intercepted
+// request's dump and returns the transformed dump for review; the caller
+// forwards it explicitly.
+func (a *App) ApplyInterceptMacro(interceptID, macroID string) (string, error) {
+	req, err := a.findInterceptedRequest(interceptID)
+	if err != nil {
+		return "", err
+	}
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, m := range a.project.InterceptMacros {
+		if m.ID == macroID {
+			return m.Apply(req.RequestDump)
+		}
+	}
+	return "", fmt.Errorf("no intercept macro with ID %s", macroID)
+}
+
+func (a *App) findInterceptedRequest(id string) (*proxy.InterceptedRequest, error) {
+	for _, req := range a.proxy.Intercepts.List() {
+		if req.ID == id {
+			return req, nil
+		}
+	}
+	return nil, fmt.Errorf("no intercepted request with ID %s", id)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/network"
+	"gleip/backend/proxy"
+)
+
+// GetMatchReplaceRules lists the project's match/replace rules.
+func (a *App) GetMatchReplaceRules() []*proxy.MatchReplaceRule {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make([]*proxy.MatchReplaceRule, len(a.project.MatchReplaceRules))
+	copy(out, a.project.MatchReplaceRules)
+	return out
+}
+
+// AddMatchReplaceRule validates and stores a new rule, activating it in the
+// proxy immediately.
+func (a *App) AddMatchReplaceRule(rule proxy.MatchReplaceRule) (*proxy.MatchReplaceRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.ID = network.NewID()
+	a.project.Lock()
+	a.project.MatchReplaceRules = append(a.project.MatchReplaceRules, &rule)
+	a.project.Unlock()
+	a.syncMatchReplaceRules()
+	return &rule, nil
+}
+
+// UpdateMatchReplaceRule replaces an existing rule by ID.
+func (a *App) UpdateMatchReplaceRule(rule proxy.MatchReplaceRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.MatchReplaceRules {
+		if existing.ID == rule.ID {
+			a.project.MatchReplaceRules[i] = &rule
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no match/replace rule with ID %s", rule.ID)
+	}
+	a.syncMatchReplaceRules()
+	return nil
+}
+
+// DeleteMatchReplaceRule removes a rule by ID.
+func (a *App) DeleteMatchReplaceRule(id string) error {
+	a.project.Lock()
+	found := false
+	for i, existing := range a.project.MatchReplaceRules {
+		if existing.ID == id {
+			a.project.MatchReplaceRules = append(a.project.MatchReplaceRules[:i], a.project.MatchReplaceRules[i+1:]...)
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no match/replace rule with ID %s", id)
+	}
+	a.syncMatchReplaceRules()
+	return nil
+}
+
+// SetMatchReplaceRuleEnabled toggles a rule without editing it.
+func (a *App) SetMatchReplaceRuleEnabled(id string, enabled bool) error {
+	a.project.Lock()
+	found := false
+	for _, existing := range a.project.MatchReplaceRules {
+		if existing.ID == id {
+			existing.Enabled = enabled
+			found = true
+			break
+		}
+	}
+	a.project.Unlock()
+	if !found {
+		return fmt.Errorf("no match/replace rule with ID %s", id)
+	}
+	a.syncMatchReplaceRules()
+	return nil
+}
+
+// syncMatchReplaceRules pushes the project's rule set into the proxy engine.
+func (a *App) syncMatchReplaceRules() {
+	a.project.RLock()
+	rules := append([]*proxy.MatchReplaceRule(nil), a.project.MatchReplaceRules...)
+	a.project.RUnlock()
+	a.proxy.MatchReplace.SetRules(rules)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"gleip/backend/metrics"
+	"gleip/backend/network"
+)
+
+// initMetrics registers gauges and help text on the collector; counters are
+// recorded as traffic and flows happen.
+func (a *App) initMetrics() {
+	m := a.metrics
+	m.Help("gleip_proxy_requests_total", "Captured proxy transactions by host.")
+	m.Help("gleip_host_request_duration_seconds", "Upstream round-trip latency by host.")
+	m.Help("gleip_intercept_queue_depth", "Requests currently held in the intercept queue.")
+	m.Help("gleip_flow_runs_total", "Flow executions by flow name.")
+	m.Help("gleip_flow_step_duration_seconds", "Step execution time by flow name.")
+	m.Help("gleip_flow_step_errors_total", "Failed step executions by flow name.")
+	m.Help("gleip_memory_heap_alloc_bytes", "Bytes of allocated heap objects.")
+	m.Help("gleip_memory_sys_bytes", "Bytes of memory obtained from the OS.")
+	m.Help("gleip_goroutines", "Number of live goroutines.")
+
+	m.Gauge("gleip_intercept_queue_depth", nil, func() float64 {
+		return float64(len(a.proxy.Intercepts.List()))
+	})
+	m.Gauge("gleip_memory_heap_alloc_bytes", nil, func() float64 {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		return float64(ms.HeapAlloc)
+	})
+	m.Gauge("gleip_memory_sys_bytes", nil, func() float64 {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		return float64(ms.Sys)
+	})
+	m.Gauge("gleip_goroutines", nil, func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+}
+
+// observeMetrics records request counts and per-host latency. Registered as
+// a proxy transaction observer.
+func (a *App) observeMetrics(tx *network.HTTPTransaction) {
+	host := hostOnly(tx.Request.Host)
+	a.metrics.Inc("gleip_proxy_requests_total", map[string]string{"host": host})
+	if tx.Duration > 0 {
+		a.metrics.Observe("gleip_host_request_duration_seconds", map[string]string{"host": host}, tx.Duration.Seconds())
+	}
+}
+
+// StartMetricsServer exposes Prometheus-style metrics on addr (loopback
+// only) at /metrics.
+func (a *App) StartMetricsServer(addr string) error {
+	if a.metricsServer != nil {
+		return fmt.Errorf("metrics server already running; stop it first")
+	}
+	server := metrics.NewServer(addr, a.metrics)
+	if err := server.Start(); err != nil {
+		return err
+	}
+	a.metricsServer = server
+	return nil
+}
+
+// StopMetricsServer stops the metrics endpoint if running.
+func (a *App) StopMetricsServer() error {
+	if a.metricsServer == nil {
+		return nil
+	}
+	err := a.metricsServer.Stop()
+	a.metricsServer = nil
+	return err
+}
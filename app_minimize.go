@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/analyzer"
+	"gleip/backend/network"
+)
+
+// minimizeMaxAttempts bounds how many replays a single minimization run may
+// send, so a request with hundreds of headers cannot hammer the target.
+const minimizeMaxAttempts = 100
+
+// MinimizationResult reports what a minimization run established.
+type MinimizationResult struct {
+	// MinimizedDump is the smallest request found that still returns an
+	// equivalent response.
+	MinimizedDump string `json:"minimizedDump"`
+	// Removed lists the elements proven unnecessary, in removal order.
+	Removed []analyzer.MinimizeCandidate `json:"removed,omitempty"`
+	// Kept lists the elements whose removal changed the response.
+	Kept []analyzer.MinimizeCandidate `json:"kept,omitempty"`
+	// Attempts counts the replays sent, including the baseline.
+	Attempts int `json:"attempts"`
+	// BaselineStatus is the status code the unmodified request returned.
+	BaselineStatus int `json:"baselineStatus"`
+}
+
+// MinimizeRequest repeatedly replays a captured request, removing headers,
+// cookies, and parameters one at a time and keeping each removal whose
+// response stays equivalent to the baseline. The result is the minimal
+// request that still reproduces the behavior — ideal for clean PoCs.
+func (a *App) MinimizeRequest(transactionID string) (*MinimizationResult, error) {
+	tx, ok := a.store.Get(transactionID)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", transactionID)
+	}
+	baseline, err := a.replayDump(tx.Request.Dump, tx.Request.Host, tx.Request.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay baseline request: %w", err)
+	}
+	baselineResp, err := network.ParseResponseDump(baseline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse baseline response: %w", err)
+	}
+
+	result := &MinimizationResult{
+		MinimizedDump:  tx.Request.Dump,
+		Attempts:       1,
+		BaselineStatus: baselineResp.StatusCode,
+	}
+	for _, candidate := range analyzer.ListMinimizeCandidates(tx.Request.Dump) {
+		if result.Attempts >= minimizeMaxAttempts {
+			break
+		}
+		trial, err := analyzer.RemoveCandidate(result.MinimizedDump, candidate)
+		if err != nil {
+			// Already gone (e.g. its whole header was removed earlier).
+			continue
+		}
+		respDump, err := a.replayDump(trial, tx.Request.Host, tx.Request.TLS)
+		result.Attempts++
+		if err != nil || !analyzer.ResponsesEquivalent(baseline, respDump) {
+			result.Kept = append(result.Kept, candidate)
+			continue
+		}
+		result.MinimizedDump = trial
+		result.Removed = append(result.Removed, candidate)
+	}
+	return result, nil
+}
+
+// replayDump sends a request dump to host over the executor's transport and
+// returns the response dump.
+func (a *App) replayDump(dump, host string, useTLS bool) (string, error) {
+	req, err := network.ParseRequestDump(dump)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse request dump: %w", err)
+	}
+	req.URL.Host = host
+	if useTLS {
+		req.URL.Scheme = "https"
+	} else {
+		req.URL.Scheme = "http"
+	}
+	req.RequestURI = ""
+	resp, err := a.executor.Transport().RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return network.DumpResponse(resp)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/network"
+)
+
+// GetTransactionParts parses a transaction's multipart/form-data request body
+// into its parts so file uploads can be inspected part by part.
+func (a *App) GetTransactionParts(id string) ([]network.MultipartPart, error) {
+	tx, ok := a.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", id)
+	}
+	req, err := network.ParseRequestDump(tx.Request.Dump)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request: %w", err)
+	}
+	contentType := req.Header.Get("Content-Type")
+	if !network.IsMultipartRequest(contentType) {
+		return nil, fmt.Errorf("transaction %s does not carry a multipart request", id)
+	}
+	_, body := network.SplitDump(tx.Request.Dump)
+	return network.ParseMultipart(contentType, body)
+}
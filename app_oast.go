@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gleip/backend/events"
+	"gleip/backend/oast"
+)
+
+// OASTFinding pairs a payload that received a callback with the transaction
+// it was injected into, ready to paste into a report.
+type OASTFinding struct {
+	Payload      *oast.IssuedPayload `json:"payload"`
+	RequestDump  string              `json:"requestDump,omitempty"`
+	ResponseDump string              `json:"responseDump,omitempty"`
+}
+
+// SetOASTDomain sets the project's out-of-band callback domain (e.g. an
+// interactsh or Collaborator domain the tester controls).
+func (a *App) SetOASTDomain(domain string) {
+	a.project.Lock()
+	a.project.OASTDomain = domain
+	a.project.Unlock()
+}
+
+// GetOASTDomain returns the configured callback domain.
+func (a *App) GetOASTDomain() string {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return a.project.OASTDomain
+}
+
+// GenerateOASTPayloads issues a fresh payload set of the given kind
+// ("open-redirect" or "ssrf"), each embedding a unique correlation token
+// under the project's callback domain. The issued payloads are persisted so
+// callbacks arriving later still correlate.
+func (a *App) GenerateOASTPayloads(kind string) ([]*oast.IssuedPayload, error) {
+	a.project.Lock()
+	defer a.project.Unlock()
+	payloads, err := oast.Generate(oast.PayloadKind(kind), a.project.OASTDomain)
+	if err != nil {
+		return nil, err
+	}
+	a.project.OASTPayloads = append(a.project.OASTPayloads, payloads...)
+	return payloads, nil
+}
+
+// GetOASTPayloads returns every payload issued in this project.
+func (a *App) GetOASTPayloads() []*oast.IssuedPayload {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return append([]*oast.IssuedPayload(nil), a.project.OASTPayloads...)
+}
+
+// BindOASTPayload records which transaction a payload was injected into, so a
+// later callback points at the exact request.
+func (a *App) BindOASTPayload(token string, transactionID string) error {
+	if _, ok := a.store.Get(transactionID); !ok {
+		return fmt.Errorf("no transaction with ID %s", transactionID)
+	}
+	a.project.Lock()
+	defer a.project.Unlock()
+	for _, p := range a.project.OASTPayloads {
+		if p.Token == token {
+			p.TransactionID = transactionID
+			return nil
+		}
+	}
+	return fmt.Errorf("no issued payload with token %s", token)
+}
+
+// RecordOASTInteraction attributes a callback hit (reported by the user or an
+// external poller) to its payload. host may be either the raw token or the
+// full "<token>.<domain>" hostname the callback server observed.
+func (a *App) RecordOASTInteraction(host string, source string, detail string) (*oast.IssuedPayload, error) {
+	a.project.Lock()
+	token := oast.TokenFromHost(host, a.project.OASTDomain)
+	if token == "" {
+		token = host
+	}
+	var hit *oast.IssuedPayload
+	for _, p := range a.project.OASTPayloads {
+		if p.Token == token {
+			p.Interactions = append(p.Interactions, oast.Interaction{Time: time.Now(), Source: source, Detail: detail})
+			hit = p
+			break
+		}
+	}
+	a.project.Unlock()
+	if hit == nil {
+		return nil, fmt.Errorf("no issued payload matches %q", host)
+	}
+	a.project.AppendAudit("oast", "interaction", fmt.Sprintf("%s payload %s hit from %s", hit.Kind, hit.Token, source))
+	events.Emit("oast:interaction", hit)
+	return hit, nil
+}
+
+// GetOASTFindings returns every payload that received at least one callback,
+// with the bound transaction's dumps attached as evidence.
+func (a *App) GetOASTFindings() []OASTFinding {
+	a.project.RLock()
+	payloads := append([]*oast.IssuedPayload(nil), a.project.OASTPayloads...)
+	a.project.RUnlock()
+	var findings []OASTFinding
+	for _, p := range payloads {
+		if len(p.Interactions) == 0 {
+			continue
+		}
+		finding := OASTFinding{Payload: p}
+		if p.TransactionID != "" {
+			if tx, ok := a.store.Get(p.TransactionID); ok {
+				finding.RequestDump = tx.Request.Dump
+				if tx.Response != nil {
+					finding.ResponseDump = tx.Response.Dump
+				}
+			}
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
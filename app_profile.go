@@ -0,0 +1,61 @@
+package main
+
+// Flow profiling: answers "why does this flow take 90 seconds" by breaking
+// the last run down per step into network and processing time.
+
+// StepProfile is one step's share of a flow run.
+type StepProfile struct {
+	StepID       string `json:"stepId"`
+	StepName     string `json:"stepName"`
+	Disabled     bool   `json:"disabled"`
+	DurationMS   int64  `json:"durationMs"`
+	NetworkMS    int64  `json:"networkMs"`
+	ProcessingMS int64  `json:"processingMs"`
+	Retries      int    `json:"retries,omitempty"`
+	Error        string `json:"error,omitempty"`
+	// PercentOfTotal is the step's share of the flow's total wall time.
+	PercentOfTotal float64 `json:"percentOfTotal"`
+}
+
+// FlowProfile is the timing breakdown of a flow's last execution.
+type FlowProfile struct {
+	FlowID       string        `json:"flowId"`
+	FlowName     string        `json:"flowName"`
+	TotalMS      int64         `json:"totalMs"`
+	NetworkMS    int64         `json:"networkMs"`
+	ProcessingMS int64         `json:"processingMs"`
+	Steps        []StepProfile `json:"steps"`
+}
+
+// GetFlowProfile builds a timing profile from the flow's ExecutionResults,
+// in step order. Steps that have never run appear with zero timings.
+func (a *App) GetFlowProfile(flowID string) (*FlowProfile, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	a.project.RLock()
+	defer a.project.RUnlock()
+
+	profile := &FlowProfile{FlowID: flow.ID, FlowName: flow.Name}
+	for _, step := range flow.Steps {
+		sp := StepProfile{StepID: step.ID, StepName: step.Name, Disabled: step.Disabled}
+		if result := flow.ExecutionResults[step.ID]; result != nil {
+			sp.DurationMS = result.Duration.Milliseconds()
+			sp.NetworkMS = result.NetworkDuration.Milliseconds()
+			sp.ProcessingMS = (result.Duration - result.NetworkDuration).Milliseconds()
+			sp.Retries = result.Retries
+			sp.Error = result.Error
+			profile.TotalMS += sp.DurationMS
+			profile.NetworkMS += sp.NetworkMS
+			profile.ProcessingMS += sp.ProcessingMS
+		}
+		profile.Steps = append(profile.Steps, sp)
+	}
+	if profile.TotalMS > 0 {
+		for i := range profile.Steps {
+			profile.Steps[i].PercentOfTotal = 100 * float64(profile.Steps[i].DurationMS) / float64(profile.TotalMS)
+		}
+	}
+	return profile, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"gleip/backend/events"
+	"gleip/backend/gleipflow"
+	"gleip/backend/network"
+)
+
+// recordFlowResults writes each successful step result into history as a
+// transaction backlinked to its flow and step, and records the transaction
+// ID on the result so the two can be navigated in both directions.
+func (a *App) recordFlowResults(flow *gleipflow.GleipFlow, results []*gleipflow.ExecutionResult) {
+	for _, result := range results {
+		if result.RequestDump == "" {
+			continue
+		}
+		req, err := network.ParseRequestDump(result.RequestDump)
+		if err != nil {
+			continue
+		}
+		a.project.RLock()
+		host := req.Host
+		useTLS := false
+		if step, _ := flow.FindStep(result.StepID); step != nil && step.Request != nil {
+			if step.Request.Host != "" {
+				host = step.Request.Host
+			}
+			useTLS = step.Request.TLS
+		}
+		a.project.RUnlock()
+		scheme := "http"
+		if useTLS {
+			scheme = "https"
+		}
+		tx := &network.HTTPTransaction{
+			ID:         network.NewID(),
+			SeqNumber:  a.proxy.NextSeq(),
+			Timestamp:  result.ExecutedAt,
+			Duration:   result.Duration,
+			FlowID:     flow.ID,
+			FlowStepID: result.StepID,
+			Request: network.HTTPRequest{
+				Dump:   result.RequestDump,
+				Method: req.Method,
+				Host:   host,
+				Path:   req.URL.Path,
+				URL:    scheme + "://" + host + req.URL.RequestURI(),
+				TLS:    useTLS,
+			},
+			Tags: []string{"flow"},
+		}
+		if strings.TrimSpace(result.ResponseDump) != "" {
+			if resp, err := network.ParseResponseDump(result.ResponseDump); err == nil {
+				_, body := network.SplitDump(result.ResponseDump)
+				tx.Response = &network.HTTPResponse{
+					Dump:       result.ResponseDump,
+					Status:     resp.Status,
+					StatusCode: resp.StatusCode,
+					BodySize:   len(body),
+				}
+			}
+		}
+		a.store.Add(tx)
+		result.TransactionID = tx.ID
+		events.Emit("proxy:request", tx.Summary())
+	}
+}
+
+// FlowProvenance identifies the flow and step a transaction came from.
+type FlowProvenance struct {
+	FlowID   string `json:"flowId"`
+	FlowName string `json:"flowName"`
+	StepID   string `json:"stepId"`
+	StepName string `json:"stepName"`
+}
+
+// GetTransactionProvenance returns the flow and step that produced a
+// transaction, or an error for proxied traffic with no flow backlink.
+func (a *App) GetTransactionProvenance(txID string) (*FlowProvenance, error) {
+	tx, ok := a.store.Get(txID)
+	if !ok {
+		return nil, fmt.Errorf("no transaction with ID %s", txID)
+	}
+	if tx.FlowID == "" {
+		return nil, fmt.Errorf("transaction %s was not produced by a flow", txID)
+	}
+	prov := &FlowProvenance{FlowID: tx.FlowID, StepID: tx.FlowStepID}
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, flow := range a.project.GleipFlows {
+		if flow.ID != tx.FlowID {
+			continue
+		}
+		prov.FlowName = flow.Name
+		if step, _ := flow.FindStep(tx.FlowStepID); step != nil {
+			prov.StepName = step.Name
+		}
+	}
+	return prov, nil
+}
+
+// GetStepResultTransaction returns the history transaction recorded for a
+// step's most recent execution.
+func (a *App) GetStepResultTransaction(flowID, stepID string) (*network.HTTPTransaction, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	a.project.RLock()
+	result := flow.ExecutionResults[stepID]
+	a.project.RUnlock()
+	if result == nil || result.TransactionID == "" {
+		return nil, fmt.Errorf("step %s has no recorded transaction; run the flow first", stepID)
+	}
+	tx, ok := a.store.Get(result.TransactionID)
+	if !ok {
+		return nil, fmt.Errorf("transaction %s is no longer in history", result.TransactionID)
+	}
+	return tx, nil
+}
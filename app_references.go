@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reference is one usage of a searched value, with enough location context
+// for the UI to jump straight to it.
+type Reference struct {
+	Kind        string `json:"kind"`        // what the value was found in: "match-replace-rule", "intercept-macro", "transaction", ...
+	ContainerID string `json:"containerId"` // ID of the containing entity
+	Container   string `json:"container"`   // human-readable name of the containing entity
+	Location    string `json:"location"`    // field or position within the container
+	Snippet     string `json:"snippet"`     // surrounding text for preview
+}
+
+// FindReferences searches project configuration and history for usages of a
+// variable name, hostname, or endpoint. kind narrows how the value is
+// matched: "variable" searches for {{value}} template usage, "host" matches
+// transaction hosts and rule patterns, "endpoint" matches host+path; an
+// empty kind does a plain substring search everywhere.
+func (a *App) FindReferences(kind, value string) ([]Reference, error) {
+	if value == "" {
+		return nil, fmt.Errorf("search value is required")
+	}
+	needle := value
+	if kind == "variable" {
+		needle = "{{" + value + "}}"
+	}
+	var refs []Reference
+	refs = append(refs, a.findRuleReferences(needle)...)
+	refs = append(refs, a.findMacroReferences(needle)...)
+	refs = append(refs, a.findTransactionReferences(kind, value)...)
+	return refs, nil
+}
+
+func (a *App) findRuleReferences(needle string) []Reference {
+	var refs []Reference
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, rule := range a.project.MatchReplaceRules {
+		for field, text := range map[string]string{"match": rule.Match, "replace": rule.Replace} {
+			if strings.Contains(text, needle) {
+				refs = append(refs, Reference{
+					Kind:        "match-replace-rule",
+					ContainerID: rule.ID,
+					Container:   rule.Name,
+					Location:    field,
+					Snippet:     snippet(text, needle),
+				})
+			}
+		}
+	}
+	return refs
+}
+
+func (a *App) findMacroReferences(needle string) []Reference {
+	var refs []Reference
+	a.project.RLock()
+	defer a.project.RUnlock()
+	for _, macro := range a.project.InterceptMacros {
+		for i, op := range macro.Ops {
+			text := op.Name + " " + op.Value
+			if strings.Contains(text, needle) {
+				refs = append(refs, Reference{
+					Kind:        "intercept-macro",
+					ContainerID: macro.ID,
+					Container:   macro.Name,
+					Location:    fmt.Sprintf("op %d (%s)", i+1, op.Type),
+					Snippet:     snippet(text, needle),
+				})
+			}
+		}
+		if strings.Contains(macro.Host+macro.Path, needle) {
+			refs = append(refs, Reference{
+				Kind:        "intercept-macro",
+				ContainerID: macro.ID,
+				Container:   macro.Name,
+				Location:    "endpoint",
+				Snippet:     macro.Host + macro.Path,
+			})
+		}
+	}
+	return refs
+}
+
+func (a *App) findTransactionReferences(kind, value string) []Reference {
+	var refs []Reference
+	for _, tx := range a.store.All() {
+		match := false
+		switch kind {
+		case "host":
+			match = strings.EqualFold(hostOnly(tx.Request.Host), hostOnly(value))
+		case "endpoint":
+			match = strings.Contains(tx.Request.Host+tx.Request.Path, value)
+		default:
+			match = strings.Contains(tx.Request.Dump, value)
+		}
+		if match {
+			refs = append(refs, Reference{
+				Kind:        "transaction",
+				ContainerID: tx.ID,
+				Container:   fmt.Sprintf("#%d %s %s", tx.SeqNumber, tx.Request.Method, tx.Request.URL),
+				Location:    "request",
+				Snippet:     tx.Request.Method + " " + tx.Request.Host + tx.Request.Path,
+			})
+		}
+	}
+	return refs
+}
+
+// snippet returns the needle with a little surrounding context.
+func snippet(text, needle string) string {
+	idx := strings.Index(text, needle)
+	if idx < 0 {
+		return ""
+	}
+	start := idx - 30
+	if start < 0 {
+		start = 0
+	}
+	end := idx + len(needle) + 30
+	if end > len(text) {
+		end = len(text)
+	}
+	return text[start:end]
+}
+
+func hostOnly(host string) string {
+	if idx := strings.IndexByte(host, ':'); idx >= 0 {
+		return host[:idx]
+	}
+	return host
+}
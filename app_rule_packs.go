@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gleip/backend/network"
+	"gleip/backend/proxy"
+)
+
+// GetBuiltinRulePacks lists the shipped rule pack library.
+func (a *App) GetBuiltinRulePacks() []*proxy.RulePack {
+	return proxy.BuiltinRulePacks()
+}
+
+// ExportRulePack writes the project's match/replace and intercept rules to
+// path as a JSON rule pack that other machines can import.
+func (a *App) ExportRulePack(path, name, description string) error {
+	a.project.RLock()
+	pack := &proxy.RulePack{
+		Name:              name,
+		Description:       description,
+		MatchReplaceRules: append([]*proxy.MatchReplaceRule(nil), a.project.MatchReplaceRules...),
+		InterceptRules:    append([]*proxy.InterceptRule(nil), a.project.InterceptRules...),
+	}
+	a.project.RUnlock()
+	if err := pack.Validate(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(pack, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize rule pack: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write rule pack: %w", err)
+	}
+	return nil
+}
+
+// ImportRulePackFile loads a JSON rule pack from path and adds its rules to
+// the project.
+func (a *App) ImportRulePackFile(path string) (*proxy.RulePack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule pack: %w", err)
+	}
+	pack, err := proxy.ParseRulePack(data)
+	if err != nil {
+		return nil, err
+	}
+	a.applyRulePack(pack)
+	return pack, nil
+}
+
+// ImportBuiltinRulePack adds a pack from the shipped library to the project
+// by name.
+func (a *App) ImportBuiltinRulePack(name string) error {
+	for _, pack := range proxy.BuiltinRulePacks() {
+		if pack.Name == name {
+			a.applyRulePack(pack)
+			return nil
+		}
+	}
+	return fmt.Errorf("no built-in rule pack named %q", name)
+}
+
+// applyRulePack appends a pack's rules to the project with fresh IDs and
+// activates them in the proxy. Imported rules keep their enabled state from
+// the pack (built-ins ship disabled).
+func (a *App) applyRulePack(pack *proxy.RulePack) {
+	a.project.Lock()
+	for _, rule := range pack.MatchReplaceRules {
+		added := *rule
+		added.ID = network.NewID()
+		a.project.MatchReplaceRules = append(a.project.MatchReplaceRules, &added)
+	}
+	for _, rule := range pack.InterceptRules {
+		added := *rule
+		added.ID = network.NewID()
+		a.project.InterceptRules = append(a.project.InterceptRules, &added)
+	}
+	a.project.Unlock()
+	a.syncMatchReplaceRules()
+	a.syncInterceptRules()
+}
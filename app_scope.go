@@ -0,0 +1,45 @@
+package main
+
+import (
+	"gleip/backend/network"
+	"gleip/backend/scope"
+)
+
+// GetScope returns the project's target scope configuration.
+func (a *App) GetScope() scope.Config {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return a.project.Scope
+}
+
+// SetScope stores a new scope configuration on the project and applies it
+// to the running proxy.
+func (a *App) SetScope(cfg scope.Config) {
+	a.project.Lock()
+	a.project.Scope = cfg
+	a.project.Unlock()
+	a.proxy.Scope.Set(cfg)
+}
+
+// SetInterceptInScopeOnly restricts interception to in-scope requests.
+func (a *App) SetInterceptInScopeOnly(only bool) {
+	a.proxy.SetInterceptScopeOnly(only)
+}
+
+// IsInScope reports whether a host+path falls inside the configured scope,
+// for subsystems and UI affordances that offer "in scope only" views.
+func (a *App) IsInScope(host, path string) bool {
+	return a.proxy.Scope.InScope(host, path)
+}
+
+// GetProxyRequestsInScope returns history summaries restricted to the
+// project scope.
+func (a *App) GetProxyRequestsInScope() []network.HTTPTransactionSummary {
+	var out []network.HTTPTransactionSummary
+	for _, tx := range a.store.All() {
+		if a.proxy.Scope.InScope(tx.Request.Host, tx.Request.Path) {
+			out = append(out, tx.Summary())
+		}
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"gleip/backend/network"
+	"gleip/backend/telemetry"
+)
+
+// defaultPageLimit is used when a pagination call passes limit <= 0.
+const defaultPageLimit = 1000
+
+// HistoryPage is one window of history summaries, sized so the frontend can
+// virtualize huge lists without transferring everything per refresh.
+type HistoryPage struct {
+	Items []network.HTTPTransactionSummary `json:"items"`
+	// NextCursor is the sequence number to pass as cursor for the following
+	// page; 0 means the listing is exhausted.
+	NextCursor int64 `json:"nextCursor,omitempty"`
+	// Total counts all matching transactions, not just this page.
+	Total int `json:"total"`
+}
+
+// pageSummaries windows a summary list to entries after cursor, up to limit.
+func pageSummaries(summaries []network.HTTPTransactionSummary, cursor int64, limit int) HistoryPage {
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+	page := HistoryPage{Total: len(summaries)}
+	for _, s := range summaries {
+		if s.SeqNumber <= cursor {
+			continue
+		}
+		if len(page.Items) == limit {
+			page.NextCursor = page.Items[len(page.Items)-1].SeqNumber
+			break
+		}
+		page.Items = append(page.Items, s)
+	}
+	return page
+}
+
+// GetProxyRequestsPage returns up to limit history summaries with sequence
+// numbers greater than cursor (0 starts from the beginning), in sequence
+// order.
+func (a *App) GetProxyRequestsPage(cursor int64, limit int) HistoryPage {
+	return pageSummaries(a.GetProxyRequests(), cursor, limit)
+}
+
+// QueryTransactions runs a filter query string (e.g. `host:api.*
+// status:500..599 method:POST size:>10k has:set-cookie`) against history and
+// returns matching summaries.
+func (a *App) QueryTransactions(query string) ([]network.HTTPTransactionSummary, error) {
+	filters, err := network.ParseFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return a.SearchTransactions(filters)
+}
+
+// SearchTransactionsPage is SearchTransactions with cursor pagination.
+func (a *App) SearchTransactionsPage(filters network.RequestFilters, cursor int64, limit int) (*HistoryPage, error) {
+	matches, err := a.SearchTransactions(filters)
+	if err != nil {
+		return nil, err
+	}
+	page := pageSummaries(matches, cursor, limit)
+	return &page, nil
+}
+
+// SearchTransactions returns summaries of history transactions matching the
+// filters, searching full dumps (or the scoped part of them). When the
+// project excludes telemetry, tagged third-party noise is filtered out.
+func (a *App) SearchTransactions(filters network.RequestFilters) ([]network.HTTPTransactionSummary, error) {
+	match, err := filters.Matcher()
+	if err != nil {
+		return nil, err
+	}
+	excludeTelemetry := a.GetExcludeTelemetry()
+	var out []network.HTTPTransactionSummary
+	for _, tx := range a.store.All() {
+		if excludeTelemetry && tx.HasTag(telemetry.Tag) {
+			continue
+		}
+		if match(tx) {
+			out = append(out, tx.Summary())
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"gleip/backend/network"
+	"gleip/backend/settings"
+)
+
+// GetSettings returns the current application settings.
+func (a *App) GetSettings() settings.Settings {
+	return a.settings.Get()
+}
+
+// GetTLSFingerprints lists the selectable outbound TLS fingerprint names.
+func (a *App) GetTLSFingerprints() []string {
+	return network.TLSFingerprintNames()
+}
+
+// SetTLSFingerprint persists and applies the outbound TLS fingerprint for
+// both proxied traffic and flow execution.
+func (a *App) SetTLSFingerprint(name string) error {
+	if err := a.proxy.SetTLSFingerprint(name); err != nil {
+		return err
+	}
+	if err := a.executor.SetTLSFingerprint(name); err != nil {
+		return err
+	}
+	return a.settings.Update(func(s *settings.Settings) { s.TLSFingerprint = name })
+}
+
+// GetCipherSuiteNames lists the cipher suite names accepted by
+// SetOutboundTLSOptions.
+func (a *App) GetCipherSuiteNames() []string {
+	return network.CipherSuiteNames()
+}
+
+// SetOutboundTLSOptions persists and applies forced TLS versions, cipher
+// suites, and legacy renegotiation for all outbound connections.
+func (a *App) SetOutboundTLSOptions(opts network.OutboundTLSOptions) error {
+	if err := a.proxy.SetOutboundTLSOptions(opts); err != nil {
+		return err
+	}
+	if err := a.executor.SetOutboundTLSOptions(opts); err != nil {
+		return err
+	}
+	return a.settings.Update(func(s *settings.Settings) { s.OutboundTLS = opts })
+}
+
+// SetConnectionPoolOptions persists and applies outbound connection pool
+// limits and keep-alive behavior for both proxied traffic and flow
+// execution.
+func (a *App) SetConnectionPoolOptions(opts network.ConnectionPoolOptions) error {
+	a.proxy.SetConnectionPoolOptions(opts)
+	a.executor.SetConnectionPoolOptions(opts)
+	return a.settings.Update(func(s *settings.Settings) { s.ConnectionPool = opts })
+}
+
+// SetRetryPolicy persists and applies automatic retrying of forwarded
+// requests that fail with transient transport errors. A zero policy
+// disables retrying.
+func (a *App) SetRetryPolicy(policy network.RetryPolicy) error {
+	a.proxy.SetRetryPolicy(policy)
+	return a.settings.Update(func(s *settings.Settings) { s.Retry = policy })
+}
+
+// SetUpstreamProxy persists and applies upstream proxy chaining for proxied
+// traffic and flow execution. An empty Addr disables chaining.
+func (a *App) SetUpstreamProxy(cfg network.UpstreamProxyConfig) error {
+	if err := a.proxy.SetUpstreamProxy(cfg); err != nil {
+		return err
+	}
+	if err := a.executor.SetUpstreamProxy(cfg); err != nil {
+		return err
+	}
+	return a.settings.Update(func(s *settings.Settings) { s.UpstreamProxy = cfg })
+}
+
+// SetRemoteAccess persists and applies the allowlist and shared token that
+// gate non-loopback proxy clients, so the proxy can safely bind 0.0.0.0 for
+// phones and VMs on the LAN.
+func (a *App) SetRemoteAccess(clients []string, token string) error {
+	if err := a.proxy.SetRemoteAccess(clients, token); err != nil {
+		return err
+	}
+	return a.settings.Update(func(s *settings.Settings) {
+		s.RemoteAllowedClients = clients
+		s.RemoteAccessToken = token
+	})
+}
+
+// SetProxyListenerTLS persists and applies TLS on the proxy listener. The
+// proxy must be restarted for the change to take effect.
+func (a *App) SetProxyListenerTLS(enabled bool) error {
+	if err := a.settings.Update(func(s *settings.Settings) { s.ListenerTLS = enabled }); err != nil {
+		return err
+	}
+	a.proxy.SetListenerTLS(enabled)
+	return nil
+}
+
+// SetAcceptProxyProtocol persists and applies PROXY protocol v2 parsing on
+// the proxy listener. The proxy must be restarted for the change to take
+// effect.
+func (a *App) SetAcceptProxyProtocol(enabled bool) error {
+	if err := a.settings.Update(func(s *settings.Settings) { s.AcceptProxyProtocol = enabled }); err != nil {
+		return err
+	}
+	a.proxy.SetAcceptProxyProtocol(enabled)
+	return nil
+}
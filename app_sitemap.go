@@ -0,0 +1,118 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"gleip/backend/telemetry"
+)
+
+// SiteMapNode is one node of the host→path tree built from captured traffic:
+// a host at the root, one node per path segment below it. Counts and the
+// method/status sets aggregate everything at or under the node.
+type SiteMapNode struct {
+	// Name is the host (root nodes) or path segment.
+	Name string `json:"name"`
+	// Path is the full path from the host root ("" for host nodes).
+	Path        string         `json:"path,omitempty"`
+	Count       int            `json:"count"`
+	Methods     []string       `json:"methods,omitempty"`
+	StatusCodes []int          `json:"statusCodes,omitempty"`
+	Children    []*SiteMapNode `json:"children,omitempty"`
+}
+
+// siteMapBuilder accumulates a node's children and method/status sets before
+// they are flattened into sorted slices.
+type siteMapBuilder struct {
+	name     string
+	path     string
+	count    int
+	methods  map[string]bool
+	statuses map[int]bool
+	children map[string]*siteMapBuilder
+}
+
+func newSiteMapBuilder(name, path string) *siteMapBuilder {
+	return &siteMapBuilder{
+		name:     name,
+		path:     path,
+		methods:  make(map[string]bool),
+		statuses: make(map[int]bool),
+		children: make(map[string]*siteMapBuilder),
+	}
+}
+
+func (b *siteMapBuilder) record(method string, status int) {
+	b.count++
+	if method != "" {
+		b.methods[method] = true
+	}
+	if status != 0 {
+		b.statuses[status] = true
+	}
+}
+
+func (b *siteMapBuilder) child(segment, path string) *siteMapBuilder {
+	c, ok := b.children[segment]
+	if !ok {
+		c = newSiteMapBuilder(segment, path)
+		b.children[segment] = c
+	}
+	return c
+}
+
+func (b *siteMapBuilder) build() *SiteMapNode {
+	node := &SiteMapNode{Name: b.name, Path: b.path, Count: b.count}
+	for m := range b.methods {
+		node.Methods = append(node.Methods, m)
+	}
+	sort.Strings(node.Methods)
+	for s := range b.statuses {
+		node.StatusCodes = append(node.StatusCodes, s)
+	}
+	sort.Ints(node.StatusCodes)
+	for _, c := range b.children {
+		node.Children = append(node.Children, c.build())
+	}
+	sort.Slice(node.Children, func(i, j int) bool { return node.Children[i].Name < node.Children[j].Name })
+	return node
+}
+
+// GetSiteMap builds the target tree from history, one root per host, for a
+// Burp-style target tab and as input for scoping and content discovery. When
+// the project excludes telemetry, tagged third-party noise is left out.
+func (a *App) GetSiteMap() []*SiteMapNode {
+	hosts := make(map[string]*siteMapBuilder)
+	excludeTelemetry := a.GetExcludeTelemetry()
+	for _, s := range a.store.Summaries() {
+		if excludeTelemetry && summaryHasTag(s, telemetry.Tag) {
+			continue
+		}
+		host := hostOnly(s.Host)
+		if host == "" {
+			continue
+		}
+		root, ok := hosts[host]
+		if !ok {
+			root = newSiteMapBuilder(host, "")
+			hosts[host] = root
+		}
+		root.record(s.Method, s.StatusCode)
+		node := root
+		path := ""
+		for _, segment := range strings.Split(strings.Trim(s.Path, "/"), "/") {
+			if segment == "" {
+				continue
+			}
+			path += "/" + segment
+			node = node.child(segment, path)
+			node.record(s.Method, s.StatusCode)
+		}
+	}
+	out := make([]*SiteMapNode, 0, len(hosts))
+	for _, root := range hosts {
+		out = append(out, root.build())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
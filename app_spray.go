@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"gleip/backend/gleipflow"
+)
+
+// RunCredentialSpray runs a credential test against a login request step.
+// The run is recorded in the audit log and auto-pauses on lockout
+// indicators; see gleipflow.SprayConfig for pairing and safety options.
+func (a *App) RunCredentialSpray(flowID, stepID string, cfg gleipflow.SprayConfig) (*gleipflow.SprayResult, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil {
+		return nil, fmt.Errorf("no step with ID %s in flow %s", stepID, flowID)
+	}
+	a.project.AppendAudit("spray", "start",
+		fmt.Sprintf("credential test on %q: %d users, %d passwords", step.Name, len(cfg.Usernames), len(cfg.Passwords)))
+	result, err := a.executor.RunSpray(step, cfg)
+	if err != nil {
+		return nil, err
+	}
+	detail := fmt.Sprintf("%d attempts on %q", len(result.Attempts), step.Name)
+	if result.Paused {
+		detail += "; auto-paused: " + result.PauseReason
+	}
+	a.project.AppendAudit("spray", "finish", detail)
+	return result, nil
+}
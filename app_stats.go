@@ -0,0 +1,30 @@
+package main
+
+import (
+	"gleip/backend/network"
+	"gleip/backend/stats"
+)
+
+// observeHostStats folds each completed transaction into the per-host
+// aggregates. Registered as a proxy transaction observer.
+func (a *App) observeHostStats(tx *network.HTTPTransaction) {
+	a.hostStats.Record(tx)
+}
+
+// GetHostStats returns the per-host traffic aggregates (request count, error
+// count, total bytes, methods, and paths seen), busiest hosts first. The
+// aggregates are maintained incrementally as traffic arrives, so this is
+// cheap to poll from a dashboard.
+func (a *App) GetHostStats() []stats.HostStats {
+	return a.hostStats.Snapshot()
+}
+
+// RebuildHostStats recomputes the aggregates from stored history, for use
+// after opening a project or importing traffic.
+func (a *App) RebuildHostStats() {
+	a.hostStats.Reset()
+	a.store.ForEach(func(tx *network.HTTPTransaction) bool {
+		a.hostStats.Record(tx)
+		return true
+	})
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gleip/backend/events"
+	"gleip/backend/gleipflow"
+	"gleip/backend/network"
+)
+
+// PendingStepSync is a proposed dump update for a bound request step,
+// waiting for the user's confirmation.
+type PendingStepSync struct {
+	FlowID        string    `json:"flowId"`
+	StepID        string    `json:"stepId"`
+	StepName      string    `json:"stepName"`
+	TransactionID string    `json:"transactionId"`
+	Dump          string    `json:"dump"`
+	ObservedAt    time.Time `json:"observedAt"`
+}
+
+// stepSyncState tracks pending dump updates, keyed by step ID so only the
+// latest observation per step is kept.
+type stepSyncState struct {
+	mu      sync.Mutex
+	pending map[string]*PendingStepSync
+}
+
+// BindRequestStep binds a request step to an endpoint; when a matching
+// request passes the proxy, the step's dump update is offered for
+// confirmation.
+func (a *App) BindRequestStep(flowID, stepID, method, host, path string) error {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return err
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil || step.Request == nil {
+		return fmt.Errorf("no request step with ID %s in flow %s", stepID, flowID)
+	}
+	a.project.Lock()
+	step.Request.Binding = &gleipflow.StepBinding{Method: method, Host: host, Path: path}
+	a.project.Unlock()
+	return nil
+}
+
+// UnbindRequestStep removes a step's endpoint binding and discards any
+// pending update for it.
+func (a *App) UnbindRequestStep(flowID, stepID string) error {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return err
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil || step.Request == nil {
+		return fmt.Errorf("no request step with ID %s in flow %s", stepID, flowID)
+	}
+	a.project.Lock()
+	step.Request.Binding = nil
+	a.project.Unlock()
+	a.stepSync.mu.Lock()
+	delete(a.stepSync.pending, stepID)
+	a.stepSync.mu.Unlock()
+	return nil
+}
+
+// GetPendingStepSyncs lists dump updates awaiting confirmation.
+func (a *App) GetPendingStepSyncs() []*PendingStepSync {
+	a.stepSync.mu.Lock()
+	defer a.stepSync.mu.Unlock()
+	out := make([]*PendingStepSync, 0, len(a.stepSync.pending))
+	for _, p := range a.stepSync.pending {
+		out = append(out, p)
+	}
+	return out
+}
+
+// AcceptStepSync applies the pending dump update to the bound step.
+func (a *App) AcceptStepSync(stepID string) error {
+	a.stepSync.mu.Lock()
+	pending, ok := a.stepSync.pending[stepID]
+	delete(a.stepSync.pending, stepID)
+	a.stepSync.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no pending sync for step %s", stepID)
+	}
+	flow, err := a.GetGleipFlow(pending.FlowID)
+	if err != nil {
+		return err
+	}
+	step, _ := flow.FindStep(stepID)
+	if step == nil || step.Request == nil {
+		return fmt.Errorf("step %s no longer exists", stepID)
+	}
+	a.project.Lock()
+	step.Request.Dump = pending.Dump
+	flow.UpdatedAt = time.Now()
+	a.project.Unlock()
+	return nil
+}
+
+// RejectStepSync discards the pending dump update for a step.
+func (a *App) RejectStepSync(stepID string) {
+	a.stepSync.mu.Lock()
+	delete(a.stepSync.pending, stepID)
+	a.stepSync.mu.Unlock()
+}
+
+// observeStepBindings is registered as a proxy transaction observer; it
+// queues dump updates for bound steps matching the observed request.
+func (a *App) observeStepBindings(tx *network.HTTPTransaction) {
+	a.project.RLock()
+	type hit struct {
+		flowID, stepID, stepName string
+	}
+	var hits []hit
+	for _, flow := range a.project.GleipFlows {
+		for _, step := range flow.Steps {
+			if step.Request != nil && step.Request.Binding.Matches(tx.Request.Method, tx.Request.Host, tx.Request.Path) {
+				hits = append(hits, hit{flow.ID, step.ID, step.Name})
+			}
+		}
+	}
+	a.project.RUnlock()
+	if len(hits) == 0 {
+		return
+	}
+	a.stepSync.mu.Lock()
+	for _, h := range hits {
+		a.stepSync.pending[h.stepID] = &PendingStepSync{
+			FlowID:        h.flowID,
+			StepID:        h.stepID,
+			StepName:      h.stepName,
+			TransactionID: tx.ID,
+			Dump:          tx.Request.Dump,
+			ObservedAt:    time.Now(),
+		}
+	}
+	a.stepSync.mu.Unlock()
+	events.Emit("flow:step-sync-available", len(hits))
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gleip/backend/gleipflow"
+	"gleip/backend/network"
+)
+
+// VariantResult is one row of a variant comparison: the execution outcome
+// of a cloned step next to its label, so differing server behavior between
+// variants is easy to spot.
+type VariantResult struct {
+	StepID     string `json:"stepId"`
+	StepName   string `json:"stepName"`
+	StatusCode int    `json:"statusCode"`
+	BodySize   int    `json:"bodySize"`
+	Error      string `json:"error,omitempty"`
+}
+
+// CreateStepVariants clones the request step at stepIndex across the
+// cartesian product of the given matrix (e.g. 3 content types x 2 auth
+// states), inserting the labeled clones directly after the original.
+func (a *App) CreateStepVariants(flowID string, stepIndex int, matrix []gleipflow.VariantDimension) ([]*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	a.project.Lock()
+	defer a.project.Unlock()
+	if stepIndex < 0 || stepIndex >= len(flow.Steps) {
+		return nil, fmt.Errorf("step index %d out of range", stepIndex)
+	}
+	variants, err := gleipflow.BuildVariants(flow.Steps[stepIndex], matrix)
+	if err != nil {
+		return nil, err
+	}
+	tail := append([]*gleipflow.Step(nil), flow.Steps[stepIndex+1:]...)
+	flow.Steps = append(append(flow.Steps[:stepIndex+1], variants...), tail...)
+	flow.UpdatedAt = time.Now()
+	return variants, nil
+}
+
+// CreateParameterPollutionVariants clones the request step at stepIndex into
+// HTTP parameter pollution cases (duplicate keys, mixed query/body locations)
+// and inserts them after the original, like CreateStepVariants.
+func (a *App) CreateParameterPollutionVariants(flowID string, stepIndex int) ([]*gleipflow.Step, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	a.project.Lock()
+	defer a.project.Unlock()
+	if stepIndex < 0 || stepIndex >= len(flow.Steps) {
+		return nil, fmt.Errorf("step index %d out of range", stepIndex)
+	}
+	variants, err := gleipflow.BuildParameterPollutionVariants(flow.Steps[stepIndex])
+	if err != nil {
+		return nil, err
+	}
+	tail := append([]*gleipflow.Step(nil), flow.Steps[stepIndex+1:]...)
+	flow.Steps = append(append(flow.Steps[:stepIndex+1], variants...), tail...)
+	flow.UpdatedAt = time.Now()
+	return variants, nil
+}
+
+// CompareStepVariants returns the last execution outcome of the step at
+// stepIndex and every variant cloned from it (steps whose name extends the
+// original's), so parse-precedence differences show up as status or body
+// size deltas.
+func (a *App) CompareStepVariants(flowID string, stepIndex int) ([]VariantResult, error) {
+	flow, err := a.GetGleipFlow(flowID)
+	if err != nil {
+		return nil, err
+	}
+	a.project.RLock()
+	defer a.project.RUnlock()
+	if stepIndex < 0 || stepIndex >= len(flow.Steps) {
+		return nil, fmt.Errorf("step index %d out of range", stepIndex)
+	}
+	base := flow.Steps[stepIndex]
+	var results []VariantResult
+	for _, step := range flow.Steps {
+		if step != base && !strings.HasPrefix(step.Name, base.Name+" [") {
+			continue
+		}
+		row := VariantResult{StepID: step.ID, StepName: step.Name}
+		if res := flow.ExecutionResults[step.ID]; res != nil {
+			row.StatusCode = res.StatusCode
+			row.BodySize = len(bodyOf(res.ResponseDump))
+			row.Error = res.Error
+		}
+		results = append(results, row)
+	}
+	return results, nil
+}
+
+// bodyOf extracts the body portion of a response dump.
+func bodyOf(dump string) string {
+	_, body := network.SplitDump(dump)
+	return body
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+
+	"gleip/backend/network"
+	"gleip/backend/settings"
+)
+
+// SetTransactionStorePath moves history into a SQLite database at path and
+// routes subsequent captures there; existing transactions are migrated. An
+// empty path switches back to the in-memory store. The choice persists with
+// the project.
+func (a *App) SetTransactionStorePath(path string) error {
+	var fresh network.TransactionStore
+	if path == "" {
+		fresh = network.NewInMemoryTransactionStore()
+	} else {
+		db, err := network.NewSQLiteTransactionStore(path)
+		if err != nil {
+			return err
+		}
+		fresh = db
+	}
+	old := a.store
+	for _, tx := range old.All() {
+		fresh.Add(tx)
+	}
+	a.store = fresh
+	a.proxy.SetStore(fresh)
+	if closer, ok := old.(io.Closer); ok {
+		closer.Close()
+	}
+	a.project.Lock()
+	a.project.StorePath = path
+	a.project.Unlock()
+	return nil
+}
+
+// SetHistoryOffload persists and applies disk offloading of sealed history:
+// dumps aged out of the recent window are written to one file each under the
+// data directory and paged back on demand (via GetTransaction and
+// GetTransactionChunk), so only summaries stay in memory. It has no effect
+// while the project uses a SQLite store, which is already disk-backed.
+func (a *App) SetHistoryOffload(enabled bool) error {
+	if mem, ok := a.store.(*network.InMemoryTransactionStore); ok {
+		dir := ""
+		if enabled {
+			dir = filepath.Join(a.dataDir, "history")
+		}
+		if err := mem.SetOffloadDir(dir); err != nil {
+			return err
+		}
+	}
+	return a.settings.Update(func(s *settings.Settings) { s.OffloadHistory = enabled })
+}
+
+// applyProjectStore opens the project's SQLite history database (or reverts
+// to the in-memory store) after a project is opened. History from the
+// previous project is left behind, not migrated.
+func (a *App) applyProjectStore(path string) error {
+	var fresh network.TransactionStore
+	if path == "" {
+		fresh = network.NewInMemoryTransactionStore()
+	} else {
+		db, err := network.NewSQLiteTransactionStore(path)
+		if err != nil {
+			return err
+		}
+		fresh = db
+	}
+	old := a.store
+	a.store = fresh
+	a.proxy.SetStore(fresh)
+	if closer, ok := old.(io.Closer); ok {
+		closer.Close()
+	}
+	return nil
+}
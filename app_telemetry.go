@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"gleip/backend/network"
+	"gleip/backend/telemetry"
+)
+
+// observeTelemetry tags transactions going to known analytics/telemetry
+// domains. Registered as a proxy transaction observer.
+func (a *App) observeTelemetry(tx *network.HTTPTransaction) {
+	if a.telemetry.IsTelemetry(tx.Request.Host) {
+		tx.AddTag(telemetry.Tag)
+	}
+}
+
+// GetTelemetryLists returns the project's allow and deny overrides for the
+// telemetry classifier.
+func (a *App) GetTelemetryLists() (allow []string, deny []string) {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	allow = append([]string(nil), a.project.TelemetryAllow...)
+	deny = append([]string(nil), a.project.TelemetryDeny...)
+	return allow, deny
+}
+
+// SetTelemetryLists replaces the project's allow and deny overrides. Allowed
+// domains are never tagged as telemetry even if the offline list names them;
+// denied domains are always tagged.
+func (a *App) SetTelemetryLists(allow, deny []string) {
+	a.project.Lock()
+	a.project.TelemetryAllow = allow
+	a.project.TelemetryDeny = deny
+	a.project.Unlock()
+	a.syncTelemetry()
+}
+
+// SetExcludeTelemetry toggles hiding telemetry-tagged traffic from history
+// views and subset exports.
+func (a *App) SetExcludeTelemetry(exclude bool) {
+	a.project.Lock()
+	a.project.ExcludeTelemetry = exclude
+	a.project.Unlock()
+}
+
+// GetExcludeTelemetry reports whether telemetry traffic is hidden.
+func (a *App) GetExcludeTelemetry() bool {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	return a.project.ExcludeTelemetry
+}
+
+// LoadTelemetryDomainList replaces the offline telemetry domain list with
+// the contents of a file (one domain per line, # comments allowed), so the
+// list can be updated without a new build. An empty path restores the
+// built-in list.
+func (a *App) LoadTelemetryDomainList(path string) (int, error) {
+	if path == "" {
+		a.telemetry.SetDomains(nil)
+		return 0, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open domain list: %w", err)
+	}
+	defer f.Close()
+	var domains []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line != "" {
+			domains = append(domains, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read domain list: %w", err)
+	}
+	if len(domains) == 0 {
+		return 0, fmt.Errorf("domain list %s contains no domains", path)
+	}
+	a.telemetry.SetDomains(domains)
+	return len(domains), nil
+}
+
+// syncTelemetry pushes the project's allow/deny overrides into the
+// classifier.
+func (a *App) syncTelemetry() {
+	a.project.RLock()
+	allow := append([]string(nil), a.project.TelemetryAllow...)
+	deny := append([]string(nil), a.project.TelemetryDeny...)
+	a.project.RUnlock()
+	a.telemetry.SetProjectLists(allow, deny)
+}
@@ -0,0 +1,9 @@
+package main
+
+import "gleip/backend/project"
+
+// GetTimeTracking returns the engagement time report: total active hours,
+// a per-day breakdown, and the raw sessions.
+func (a *App) GetTimeTracking() project.TimeTrackingReport {
+	return a.project.TimeTracking()
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strconv"
+
+	"gleip/backend/gleipflow"
+	"gleip/backend/network"
+	"gleip/backend/settings"
+)
+
+// SetOTLPEndpoint persists and applies the OTLP/HTTP collector base URL for
+// internal span export ("" disables exporting).
+func (a *App) SetOTLPEndpoint(endpoint string) error {
+	a.tracer.SetEndpoint(endpoint)
+	return a.settings.Update(func(s *settings.Settings) { s.OTLPEndpoint = endpoint })
+}
+
+// observeTracing records one span per proxied transaction, carrying the
+// timing breakdown as attributes.
+func (a *App) observeTracing(tx *network.HTTPTransaction) {
+	if !a.tracer.Enabled() {
+		return
+	}
+	attrs := map[string]string{
+		"http.method": tx.Request.Method,
+		"http.host":   tx.Request.Host,
+		"http.path":   tx.Request.Path,
+	}
+	if tx.Response != nil {
+		attrs["http.status_code"] = strconv.Itoa(tx.Response.StatusCode)
+	}
+	if tx.Timings != nil {
+		attrs["gleip.dns_ms"] = strconv.FormatInt(tx.Timings.DNSMS, 10)
+		attrs["gleip.connect_ms"] = strconv.FormatInt(tx.Timings.ConnectMS, 10)
+		attrs["gleip.tls_ms"] = strconv.FormatInt(tx.Timings.TLSMS, 10)
+		attrs["gleip.ttfb_ms"] = strconv.FormatInt(tx.Timings.TTFBMS, 10)
+	}
+	a.tracer.Record("proxy.transaction", tx.Timestamp, tx.Timestamp.Add(tx.Duration), attrs)
+}
+
+// traceFlowResults records one span per executed flow step.
+func (a *App) traceFlowResults(flowName string, results []*gleipflow.ExecutionResult) {
+	if !a.tracer.Enabled() {
+		return
+	}
+	for _, result := range results {
+		attrs := map[string]string{
+			"gleip.flow": flowName,
+			"gleip.step": result.StepName,
+			"gleip.network_ms": strconv.FormatInt(
+				result.NetworkDuration.Milliseconds(), 10),
+		}
+		if result.Error != "" {
+			attrs["error"] = result.Error
+		}
+		a.tracer.Record("flow.step", result.ExecutedAt, result.ExecutedAt.Add(result.Duration), attrs)
+	}
+}
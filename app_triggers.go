@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gleip/backend/gleipflow"
+	"gleip/backend/network"
+	"gleip/backend/project"
+)
+
+// triggerState tracks last-fire times so cooldowns are honored.
+type triggerState struct {
+	mu        sync.Mutex
+	lastFired map[string]time.Time
+}
+
+// GetFlowTriggers lists the project's flow triggers.
+func (a *App) GetFlowTriggers() []*gleipflow.FlowTrigger {
+	a.project.RLock()
+	defer a.project.RUnlock()
+	out := make([]*gleipflow.FlowTrigger, len(a.project.FlowTriggers))
+	copy(out, a.project.FlowTriggers)
+	return out
+}
+
+// AddFlowTrigger validates and stores a new proxy-event trigger.
+func (a *App) AddFlowTrigger(trigger gleipflow.FlowTrigger) (*gleipflow.FlowTrigger, error) {
+	if err := trigger.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := a.GetGleipFlow(trigger.FlowID); err != nil {
+		return nil, err
+	}
+	trigger.ID = network.NewID()
+	a.project.Lock()
+	a.project.FlowTriggers = append(a.project.FlowTriggers, &trigger)
+	a.project.Unlock()
+	return &trigger, nil
+}
+
+// DeleteFlowTrigger removes a trigger by ID.
+func (a *App) DeleteFlowTrigger(id string) error {
+	a.project.Lock()
+	defer a.project.Unlock()
+	for i, t := range a.project.FlowTriggers {
+		if t.ID == id {
+			a.project.FlowTriggers = append(a.project.FlowTriggers[:i], a.project.FlowTriggers[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no flow trigger with ID %s", id)
+}
+
+// SetFlowTriggerEnabled toggles a trigger.
+func (a *App) SetFlowTriggerEnabled(id string, enabled bool) error {
+	a.project.Lock()
+	defer a.project.Unlock()
+	for _, t := range a.project.FlowTriggers {
+		if t.ID == id {
+			t.Enabled = enabled
+			return nil
+		}
+	}
+	return fmt.Errorf("no flow trigger with ID %s", id)
+}
+
+// GetAuditLog returns the project audit log.
+func (a *App) GetAuditLog() []project.AuditEntry {
+	return a.project.Audit()
+}
+
+// observeFlowTriggers is registered as a proxy transaction observer; it
+// fires matching triggers asynchronously, honoring their cooldowns.
+func (a *App) observeFlowTriggers(tx *network.HTTPTransaction) {
+	if tx.Response == nil {
+		return
+	}
+	a.project.RLock()
+	triggers := append([]*gleipflow.FlowTrigger(nil), a.project.FlowTriggers...)
+	a.project.RUnlock()
+	for _, trigger := range triggers {
+		if !trigger.Matches(tx.Request.Method, tx.Request.Host, tx.Response.StatusCode) {
+			continue
+		}
+		a.triggers.mu.Lock()
+		if last, ok := a.triggers.lastFired[trigger.ID]; ok && time.Since(last) < trigger.Cooldown() {
+			a.triggers.mu.Unlock()
+			continue
+		}
+		a.triggers.lastFired[trigger.ID] = time.Now()
+		a.triggers.mu.Unlock()
+
+		trigger := trigger
+		go func() {
+			a.project.AppendAudit("trigger:"+trigger.ID, "execute flow",
+				fmt.Sprintf("trigger %q fired for %s %s (%d)", trigger.Name, tx.Request.Method, tx.Request.Host, tx.Response.StatusCode))
+			if _, err := a.ExecuteGleipFlow(trigger.FlowID); err != nil {
+				log.Printf("trigger %q: flow execution failed: %v", trigger.Name, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import "gleip/backend/proxy"
+
+// GetTunnelRecords lists CONNECT tunnels that carried non-HTTP traffic
+// (thick clients, custom TCP protocols), oldest first.
+func (a *App) GetTunnelRecords() []*proxy.TunnelRecord {
+	return a.proxy.Tunnels()
+}
+
+// SetTunnelByteCapture toggles keeping the first bytes of non-HTTP tunnel
+// traffic alongside endpoints and byte counts.
+func (a *App) SetTunnelByteCapture(enabled bool) {
+	a.proxy.SetTunnelByteCapture(enabled)
+}
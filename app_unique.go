@@ -0,0 +1,45 @@
+package main
+
+import (
+	"time"
+
+	"gleip/backend/network"
+	"gleip/backend/telemetry"
+)
+
+// UniqueRequestGroup is one collapsed set of transactions with identical
+// method+URL+body (per network.RequestSignature): a representative entry
+// plus how often and when the request repeated.
+type UniqueRequestGroup struct {
+	Signature string `json:"signature"`
+	Count     int    `json:"count"`
+	// Representative is the most recent occurrence.
+	Representative network.HTTPTransactionSummary `json:"representative"`
+	FirstSeen      time.Time                      `json:"firstSeen"`
+	LastSeen       time.Time                      `json:"lastSeen"`
+}
+
+// GetUniqueRequests collapses history into one group per request signature,
+// in order of first occurrence, to cut through repetitive polling traffic.
+// When the project excludes telemetry, tagged third-party noise is left out.
+func (a *App) GetUniqueRequests() []*UniqueRequestGroup {
+	groups := make(map[string]*UniqueRequestGroup)
+	var order []*UniqueRequestGroup
+	excludeTelemetry := a.GetExcludeTelemetry()
+	for _, tx := range a.store.All() {
+		if excludeTelemetry && tx.HasTag(telemetry.Tag) {
+			continue
+		}
+		sig := network.RequestSignature(tx)
+		group, ok := groups[sig]
+		if !ok {
+			group = &UniqueRequestGroup{Signature: sig, FirstSeen: tx.Timestamp}
+			groups[sig] = group
+			order = append(order, group)
+		}
+		group.Count++
+		group.Representative = tx.Summary()
+		group.LastSeen = tx.Timestamp
+	}
+	return order
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"gleip/backend/wordlist"
+)
+
+// ProcessWordlists merges the given wordlist files into output, applying the
+// requested dedupe/filter/sample options in one streaming pass.
+func (a *App) ProcessWordlists(inputs []string, output string, opts wordlist.Options) (*wordlist.Stats, error) {
+	return wordlist.Process(inputs, output, opts)
+}
@@ -0,0 +1,140 @@
+// Package agent implements remote capture: a headless ProxyServer running on
+// a jump box or cloud VM that streams completed transactions back to the
+// desktop app over an authenticated channel.
+package agent
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gleip/backend/network"
+	"gleip/backend/proxy"
+)
+
+// Agent exposes a proxy's transaction feed over HTTP. Clients subscribe to
+// /stream and receive newline-delimited JSON transactions as they complete.
+type Agent struct {
+	mu       sync.Mutex
+	token    string
+	listener net.Listener
+
+	subMu       sync.Mutex
+	subscribers map[chan *network.HTTPTransaction]struct{}
+}
+
+// New wires an agent to a proxy. The token authenticates stream clients and
+// must not be empty: agents listen on routable interfaces by design.
+func New(p *proxy.ProxyServer, token string) (*Agent, error) {
+	if token == "" {
+		return nil, fmt.Errorf("agent requires an access token")
+	}
+	a := &Agent{token: token, subscribers: make(map[chan *network.HTTPTransaction]struct{})}
+	p.AddTransactionObserver(a.publish)
+	return a, nil
+}
+
+// Start begins serving the control API on addr.
+func (a *Agent) Start(addr string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.listener != nil {
+		return fmt.Errorf("agent already running")
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to bind agent listener on %s: %w", addr, err)
+	}
+	a.listener = listener
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", a.requireToken(a.handleHealth))
+	mux.HandleFunc("/stream", a.requireToken(a.handleStream))
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed && !strings.Contains(err.Error(), "use of closed network connection") {
+			log.Printf("agent: serve error: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop closes the control listener. Streaming clients are disconnected.
+func (a *Agent) Stop() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.listener == nil {
+		return nil
+	}
+	err := a.listener.Close()
+	a.listener = nil
+	return err
+}
+
+func (a *Agent) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		presented, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(a.token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (a *Agent) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// handleStream sends transactions as NDJSON for as long as the client stays
+// connected.
+func (a *Agent) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	ch := make(chan *network.HTTPTransaction, 256)
+	a.subMu.Lock()
+	a.subscribers[ch] = struct{}{}
+	a.subMu.Unlock()
+	defer func() {
+		a.subMu.Lock()
+		delete(a.subscribers, ch)
+		a.subMu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case tx := <-ch:
+			if err := encoder.Encode(tx); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// publish fans a completed transaction out to every subscriber. Slow clients
+// drop transactions rather than stalling the proxy.
+func (a *Agent) publish(tx *network.HTTPTransaction) {
+	a.subMu.Lock()
+	defer a.subMu.Unlock()
+	for ch := range a.subscribers {
+		select {
+		case ch <- tx:
+		default:
+		}
+	}
+}
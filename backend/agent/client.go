@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// Client consumes a remote agent's transaction stream.
+type Client struct {
+	cancel context.CancelFunc
+}
+
+// Connect opens the agent's stream and invokes handler for every received
+// transaction until Close is called or the connection drops. The returned
+// error covers connection setup only; stream errors end the handler loop
+// silently (the caller observes the feed going quiet).
+func Connect(baseURL, token string, handler func(*network.HTTPTransaction)) (*Client, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+"/stream", nil)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("invalid agent URL: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to reach agent: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("agent refused stream: %s", resp.Status)
+	}
+
+	go func() {
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 64<<20)
+		for scanner.Scan() {
+			tx := &network.HTTPTransaction{}
+			if err := json.Unmarshal(scanner.Bytes(), tx); err != nil {
+				continue
+			}
+			handler(tx)
+		}
+	}()
+	return &Client{cancel: cancel}, nil
+}
+
+// Close disconnects from the agent.
+func (c *Client) Close() {
+	c.cancel()
+}
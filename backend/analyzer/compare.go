@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// FieldComparison is one compared dimension across N transactions: the
+// status line, one response header, or a body property.
+type FieldComparison struct {
+	// Field names the dimension, e.g. "status", "header:Content-Type",
+	// "body-size", "body-hash".
+	Field string `json:"field"`
+	// Identical is true when every input carries the same value.
+	Identical bool `json:"identical"`
+	// Consensus is the most common value ("" when every input differs).
+	Consensus string `json:"consensus,omitempty"`
+	// Values maps transaction ID to its value, only populated for fields
+	// that are not identical. Missing entries mean the field is absent.
+	Values map[string]string `json:"values,omitempty"`
+	// Outliers lists the transaction IDs whose value deviates from the
+	// consensus.
+	Outliers []string `json:"outliers,omitempty"`
+}
+
+// NWayDiff is the consensus/outlier view over N responses: which fields are
+// identical everywhere, and which inputs deviate where.
+type NWayDiff struct {
+	TransactionIDs []string          `json:"transactionIds"`
+	Fields         []FieldComparison `json:"fields"`
+}
+
+// CompareTransactions diffs the responses of two or more transactions. It
+// answers "which of these similar responses actually differs, and in what"
+// without requiring pairwise comparison.
+func CompareTransactions(txs []*network.HTTPTransaction) (*NWayDiff, error) {
+	if len(txs) < 2 {
+		return nil, fmt.Errorf("need at least two transactions to compare, got %d", len(txs))
+	}
+	diff := &NWayDiff{}
+	values := make(map[string]map[string]string) // field -> tx ID -> value
+	record := func(field, txID, value string) {
+		if values[field] == nil {
+			values[field] = make(map[string]string)
+		}
+		values[field][txID] = value
+	}
+	for _, tx := range txs {
+		diff.TransactionIDs = append(diff.TransactionIDs, tx.ID)
+		if tx.Response == nil {
+			return nil, fmt.Errorf("transaction %s has no response", tx.ID)
+		}
+		record("status", tx.ID, strconv.Itoa(tx.Response.StatusCode))
+		record("body-size", tx.ID, strconv.Itoa(tx.Response.BodySize))
+		record("body-hash", tx.ID, tx.Response.BodyHash)
+		resp, err := network.ParseResponseDump(tx.Response.Dump)
+		if err != nil {
+			continue
+		}
+		for name, vals := range resp.Header {
+			record("header:"+name, tx.ID, strings.Join(vals, ", "))
+		}
+	}
+
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		diff.Fields = append(diff.Fields, compareField(field, values[field], diff.TransactionIDs))
+	}
+	return diff, nil
+}
+
+// compareField computes consensus and outliers for one field. Absent values
+// (header missing on some responses) count as their own value, so a header
+// unique to one response shows that response as the outlier.
+func compareField(field string, byTx map[string]string, ids []string) FieldComparison {
+	counts := make(map[string]int)
+	for _, id := range ids {
+		counts[byTx[id]]++
+	}
+	consensus := ""
+	best := 0
+	for value, n := range counts {
+		if n > best {
+			consensus = value
+			best = n
+		}
+	}
+	fc := FieldComparison{Field: field, Identical: len(counts) == 1}
+	if fc.Identical {
+		return fc
+	}
+	if best > 1 {
+		fc.Consensus = consensus
+	}
+	fc.Values = make(map[string]string)
+	for _, id := range ids {
+		if value, ok := byTx[id]; ok {
+			fc.Values[id] = value
+		}
+		if byTx[id] != consensus || best == 1 {
+			fc.Outliers = append(fc.Outliers, id)
+		}
+	}
+	return fc
+}
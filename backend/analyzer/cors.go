@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"strings"
+)
+
+// CORSProbeResult is one row of the CORS test table: the Origin sent and
+// what the server allowed in return.
+type CORSProbeResult struct {
+	Origin           string   `json:"origin"`
+	StatusCode       int      `json:"statusCode"`
+	AllowOrigin      string   `json:"allowOrigin"`
+	AllowCredentials bool     `json:"allowCredentials"`
+	Exploitable      bool     `json:"exploitable"`
+	Severity         Severity `json:"severity"`
+	Note             string   `json:"note,omitempty"`
+}
+
+// CORSTestOrigins builds the Origin matrix for a target host: an unrelated
+// attacker origin, the null origin, and the reflection tricks (prefix,
+// suffix, and subdomain confusion) that sloppy origin validation falls for.
+func CORSTestOrigins(host string) []string {
+	bare := host
+	if idx := strings.LastIndex(bare, ":"); idx > 0 && !strings.Contains(bare[idx:], "]") {
+		bare = bare[:idx]
+	}
+	return []string{
+		"https://attacker.example",
+		"null",
+		"https://" + bare + ".attacker.example",
+		"https://attacker" + bare,
+		"https://attacker-" + bare,
+	}
+}
+
+// EvaluateCORSProbe grades one probe result. sentOrigin is what we sent;
+// the allow values are what came back.
+func EvaluateCORSProbe(sentOrigin, allowOrigin string, allowCredentials bool) (exploitable bool, severity Severity, note string) {
+	switch {
+	case allowOrigin == "":
+		return false, SeverityInfo, "origin not allowed"
+	case allowOrigin == "*" && allowCredentials:
+		return true, SeverityHigh, "wildcard origin with credentials"
+	case allowOrigin == "*":
+		return false, SeverityInfo, "wildcard origin without credentials"
+	case allowOrigin == sentOrigin && allowCredentials:
+		return true, SeverityHigh, "arbitrary origin reflected with credentials"
+	case allowOrigin == sentOrigin:
+		return true, SeverityMedium, "arbitrary origin reflected"
+	default:
+		return false, SeverityInfo, "fixed allowlist origin returned"
+	}
+}
@@ -0,0 +1,248 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// DiffOp says which side of a pairwise comparison a hunk belongs to.
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffDelete DiffOp = "delete" // present only in A
+	DiffInsert DiffOp = "insert" // present only in B
+)
+
+// DiffHunk is one run of consecutive tokens sharing an op. Concatenating the
+// equal and delete hunks reproduces A; equal and insert reproduce B.
+type DiffHunk struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// HeaderChange records one header that differs between the two sides; an
+// empty A or B means the header is absent on that side.
+type HeaderChange struct {
+	Name string `json:"name"`
+	A    string `json:"a,omitempty"`
+	B    string `json:"b,omitempty"`
+}
+
+// ResponseDiff is the structured pairwise comparison of two HTTP messages:
+// header changes plus body hunks at the requested granularity.
+type ResponseDiff struct {
+	Granularity string         `json:"granularity"`
+	Headers     []HeaderChange `json:"headers,omitempty"`
+	Body        []DiffHunk     `json:"body"`
+	Identical   bool           `json:"identical"`
+}
+
+// maxDiffCells caps the LCS table size; beyond it the differing middle is
+// reported as one delete+insert pair instead of an exact alignment.
+const maxDiffCells = 1 << 22
+
+// DiffDumps compares two raw HTTP dumps pairwise, unlike the N-way
+// consensus view of CompareTransactions. granularity is "line", "word", or
+// "byte" ("" means word).
+func DiffDumps(aDump, bDump, granularity string) (*ResponseDiff, error) {
+	switch granularity {
+	case "":
+		granularity = "word"
+	case "line", "word", "byte":
+	default:
+		return nil, fmt.Errorf("unknown diff granularity %q", granularity)
+	}
+	aHeaders, aBody := network.SplitDump(aDump)
+	bHeaders, bBody := network.SplitDump(bDump)
+	diff := &ResponseDiff{Granularity: granularity}
+	diff.Headers = diffHeaders(aHeaders, bHeaders)
+	diff.Body = diffTokens(tokenize(aBody, granularity), tokenize(bBody, granularity))
+	diff.Identical = len(diff.Headers) == 0
+	for _, h := range diff.Body {
+		if h.Op != DiffEqual {
+			diff.Identical = false
+			break
+		}
+	}
+	return diff, nil
+}
+
+// diffHeaders compares two header blocks by name; the first line (request or
+// status line) is compared under the pseudo-name "(start-line)".
+func diffHeaders(aBlock, bBlock string) []HeaderChange {
+	aLine, aHeaders := parseHeaderBlock(aBlock)
+	bLine, bHeaders := parseHeaderBlock(bBlock)
+	var changes []HeaderChange
+	if aLine != bLine {
+		changes = append(changes, HeaderChange{Name: "(start-line)", A: aLine, B: bLine})
+	}
+	names := make(map[string]bool, len(aHeaders)+len(bHeaders))
+	for name := range aHeaders {
+		names[name] = true
+	}
+	for name := range bHeaders {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		if aHeaders[name] != bHeaders[name] {
+			changes = append(changes, HeaderChange{Name: name, A: aHeaders[name], B: bHeaders[name]})
+		}
+	}
+	return changes
+}
+
+// parseHeaderBlock splits a header block into its first line and a
+// lower-cased name→value map (repeated headers joined with ", ").
+func parseHeaderBlock(block string) (string, map[string]string) {
+	lines := strings.Split(strings.ReplaceAll(block, "\r\n", "\n"), "\n")
+	headers := make(map[string]string)
+	for _, line := range lines[1:] {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if existing, seen := headers[key]; seen {
+			value = existing + ", " + value
+		}
+		headers[key] = value
+	}
+	return lines[0], headers
+}
+
+// tokenize splits text into diff tokens. Separators stay attached to the
+// preceding token so concatenating tokens reproduces the input exactly.
+func tokenize(text, granularity string) []string {
+	switch granularity {
+	case "byte":
+		tokens := make([]string, len(text))
+		for i := 0; i < len(text); i++ {
+			tokens[i] = text[i : i+1]
+		}
+		return tokens
+	case "line":
+		return strings.SplitAfter(text, "\n")
+	default: // word
+		var tokens []string
+		start := 0
+		inSpace := false
+		for i := 0; i < len(text); i++ {
+			isSpace := text[i] == ' ' || text[i] == '\t' || text[i] == '\n' || text[i] == '\r'
+			if inSpace && !isSpace {
+				tokens = append(tokens, text[start:i])
+				start = i
+			}
+			inSpace = isSpace
+		}
+		if start < len(text) {
+			tokens = append(tokens, text[start:])
+		}
+		return tokens
+	}
+}
+
+// diffTokens aligns two token sequences into hunks: common prefix and suffix
+// are matched directly, the middle via LCS (or, when it would exceed the
+// table cap, as one coarse replace).
+func diffTokens(a, b []string) []DiffHunk {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	var hunks []DiffHunk
+	if prefix > 0 {
+		hunks = append(hunks, DiffHunk{Op: DiffEqual, Text: strings.Join(a[:prefix], "")})
+	}
+	if len(midA)*len(midB) > maxDiffCells {
+		if len(midA) > 0 {
+			hunks = append(hunks, DiffHunk{Op: DiffDelete, Text: strings.Join(midA, "")})
+		}
+		if len(midB) > 0 {
+			hunks = append(hunks, DiffHunk{Op: DiffInsert, Text: strings.Join(midB, "")})
+		}
+	} else {
+		hunks = append(hunks, lcsHunks(midA, midB)...)
+	}
+	if suffix > 0 {
+		hunks = append(hunks, DiffHunk{Op: DiffEqual, Text: strings.Join(a[len(a)-suffix:], "")})
+	}
+	return mergeHunks(hunks)
+}
+
+// lcsHunks produces per-token hunks from a longest-common-subsequence
+// alignment of the two sides.
+func lcsHunks(a, b []string) []DiffHunk {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else if dp[i-1][j] >= dp[i][j-1] {
+				dp[i][j] = dp[i-1][j]
+			} else {
+				dp[i][j] = dp[i][j-1]
+			}
+		}
+	}
+	var reversed []DiffHunk
+	i, j := n, m
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && a[i-1] == b[j-1]:
+			reversed = append(reversed, DiffHunk{Op: DiffEqual, Text: a[i-1]})
+			i--
+			j--
+		case j > 0 && (i == 0 || dp[i][j-1] >= dp[i-1][j]):
+			reversed = append(reversed, DiffHunk{Op: DiffInsert, Text: b[j-1]})
+			j--
+		default:
+			reversed = append(reversed, DiffHunk{Op: DiffDelete, Text: a[i-1]})
+			i--
+		}
+	}
+	hunks := make([]DiffHunk, len(reversed))
+	for k, h := range reversed {
+		hunks[len(reversed)-1-k] = h
+	}
+	return hunks
+}
+
+// mergeHunks joins adjacent hunks with the same op.
+func mergeHunks(hunks []DiffHunk) []DiffHunk {
+	var out []DiffHunk
+	for _, h := range hunks {
+		if h.Text == "" {
+			continue
+		}
+		if len(out) > 0 && out[len(out)-1].Op == h.Op {
+			out[len(out)-1].Text += h.Text
+			continue
+		}
+		out = append(out, h)
+	}
+	return out
+}
@@ -0,0 +1,170 @@
+// Package analyzer inspects captured responses for security-configuration
+// weaknesses. It is purely passive: findings are derived from traffic already
+// in history, never from new requests.
+package analyzer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// Severity grades a finding.
+type Severity string
+
+const (
+	SeverityInfo   Severity = "info"
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// HeaderFinding is one graded weakness with the offending header value as
+// evidence, ready to drop into a report.
+type HeaderFinding struct {
+	Host          string   `json:"host"`
+	TransactionID string   `json:"transactionId"`
+	Severity      Severity `json:"severity"`
+	Title         string   `json:"title"`
+	Explanation   string   `json:"explanation"`
+	// Evidence is the exact header value that triggered the finding, or ""
+	// when the finding is about an absent header.
+	Evidence string `json:"evidence,omitempty"`
+}
+
+// hstsMinAge is the max-age below which HSTS is considered too short
+// (6 months, the preload-list minimum ballpark).
+const hstsMinAge = 15768000
+
+// AnalyzeTransaction evaluates one transaction's response headers. HTML
+// responses get the full treatment; non-HTML ones only the checks that apply
+// regardless of content (cookies, CORS, HSTS).
+func AnalyzeTransaction(tx *network.HTTPTransaction) []HeaderFinding {
+	if tx.Response == nil {
+		return nil
+	}
+	resp, err := network.ParseResponseDump(tx.Response.Dump)
+	if err != nil {
+		return nil
+	}
+	var findings []HeaderFinding
+	report := func(severity Severity, title, explanation, evidence string) {
+		findings = append(findings, HeaderFinding{
+			Host:          tx.Request.Host,
+			TransactionID: tx.ID,
+			Severity:      severity,
+			Title:         title,
+			Explanation:   explanation,
+			Evidence:      evidence,
+		})
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	isHTML := strings.Contains(strings.ToLower(contentType), "text/html")
+
+	csp := resp.Header.Get("Content-Security-Policy")
+	if isHTML {
+		switch {
+		case csp == "":
+			report(SeverityLow, "Missing Content-Security-Policy",
+				"No CSP header; injected script executes without restriction.", "")
+		default:
+			lower := strings.ToLower(csp)
+			if strings.Contains(lower, "'unsafe-inline'") && !strings.Contains(lower, "nonce-") && !strings.Contains(lower, "sha256-") {
+				report(SeverityMedium, "CSP allows unsafe-inline",
+					"'unsafe-inline' without nonces or hashes makes the policy ineffective against XSS.", csp)
+			}
+			if strings.Contains(lower, "'unsafe-eval'") {
+				report(SeverityLow, "CSP allows unsafe-eval",
+					"'unsafe-eval' permits string-to-code primitives, widening XSS gadgets.", csp)
+			}
+			if cspSourceIsWildcard(lower, "default-src") || cspSourceIsWildcard(lower, "script-src") {
+				report(SeverityMedium, "CSP script sources wildcarded",
+					"A wildcard script or default source allows loading script from any origin.", csp)
+			}
+		}
+		if resp.Header.Get("X-Frame-Options") == "" && !strings.Contains(strings.ToLower(csp), "frame-ancestors") {
+			report(SeverityMedium, "Missing clickjacking protection",
+				"Neither X-Frame-Options nor a frame-ancestors CSP directive is set.", "")
+		}
+	}
+
+	if tx.Request.TLS {
+		hsts := resp.Header.Get("Strict-Transport-Security")
+		if hsts == "" {
+			report(SeverityLow, "Missing Strict-Transport-Security",
+				"Without HSTS a first request over plain HTTP can be intercepted.", "")
+		} else if age, ok := hstsMaxAge(hsts); ok && age < hstsMinAge {
+			report(SeverityLow, "Short HSTS max-age",
+				fmt.Sprintf("max-age %d is below the recommended %d seconds.", age, hstsMinAge), hsts)
+		}
+	}
+
+	for _, cookie := range resp.Header.Values("Set-Cookie") {
+		lower := strings.ToLower(cookie)
+		if tx.Request.TLS && !strings.Contains(lower, "secure") {
+			report(SeverityMedium, "Cookie without Secure flag",
+				"The cookie is sent over plain HTTP as well, exposing it to interception.", cookie)
+		}
+		if !strings.Contains(lower, "httponly") {
+			report(SeverityLow, "Cookie without HttpOnly flag",
+				"Script can read the cookie, making it stealable through XSS.", cookie)
+		}
+		if strings.Contains(lower, "samesite=none") && !strings.Contains(lower, "secure") {
+			report(SeverityMedium, "SameSite=None cookie without Secure",
+				"Browsers reject or downgrade SameSite=None cookies lacking Secure.", cookie)
+		}
+	}
+
+	origin := resp.Header.Get("Access-Control-Allow-Origin")
+	credentials := strings.EqualFold(resp.Header.Get("Access-Control-Allow-Credentials"), "true")
+	if origin == "*" {
+		if credentials {
+			report(SeverityHigh, "CORS wildcard origin with credentials",
+				"Allow-Origin * combined with Allow-Credentials true lets any site read authenticated responses.",
+				fmt.Sprintf("Access-Control-Allow-Origin: %s; Access-Control-Allow-Credentials: true", origin))
+		} else {
+			report(SeverityInfo, "CORS wildcard origin",
+				"Any origin can read this response; fine for public data, check it stays unauthenticated.",
+				"Access-Control-Allow-Origin: *")
+		}
+	} else if origin == "null" {
+		report(SeverityMedium, "CORS allows the null origin",
+			"Sandboxed iframes and some redirect tricks produce a null origin, bypassing the allowlist.",
+			"Access-Control-Allow-Origin: null")
+	}
+
+	return findings
+}
+
+// cspSourceIsWildcard reports whether the named directive includes a bare *.
+func cspSourceIsWildcard(policy, directive string) bool {
+	for _, part := range strings.Split(policy, ";") {
+		fields := strings.Fields(strings.TrimSpace(part))
+		if len(fields) == 0 || fields[0] != directive {
+			continue
+		}
+		for _, src := range fields[1:] {
+			if src == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hstsMaxAge extracts the max-age value from an HSTS header.
+func hstsMaxAge(header string) (int, bool) {
+	for _, part := range strings.Split(header, ";") {
+		part = strings.TrimSpace(strings.ToLower(part))
+		if value, found := strings.CutPrefix(part, "max-age="); found {
+			age, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err == nil {
+				return age, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// MinimizeCandidate is one removable element of a request: a header, a
+// single cookie pair, a query parameter, or a form-body parameter.
+type MinimizeCandidate struct {
+	Kind string `json:"kind"` // "header", "cookie", "query-param", "form-param"
+	Name string `json:"name"`
+}
+
+// requiredHeaders are never offered for removal: dropping them changes the
+// message framing or target rather than the application input.
+var requiredHeaders = map[string]bool{
+	"host":              true,
+	"content-length":    true,
+	"transfer-encoding": true,
+}
+
+// ListMinimizeCandidates enumerates the elements of a request dump that can
+// be removed one at a time during minimization.
+func ListMinimizeCandidates(dump string) []MinimizeCandidate {
+	headers, body := network.SplitDump(dump)
+	lines := strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")
+	var out []MinimizeCandidate
+	for _, line := range lines[1:] {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if requiredHeaders[strings.ToLower(name)] {
+			continue
+		}
+		if strings.EqualFold(name, "Cookie") {
+			for _, pair := range strings.Split(value, ";") {
+				if cookie, _, _ := strings.Cut(strings.TrimSpace(pair), "="); cookie != "" {
+					out = append(out, MinimizeCandidate{Kind: "cookie", Name: cookie})
+				}
+			}
+			continue
+		}
+		out = append(out, MinimizeCandidate{Kind: "header", Name: name})
+	}
+	if len(lines) > 0 {
+		if parts := strings.SplitN(lines[0], " ", 3); len(parts) == 3 {
+			if target, err := url.ParseRequestURI(parts[1]); err == nil {
+				for key := range target.Query() {
+					out = append(out, MinimizeCandidate{Kind: "query-param", Name: key})
+				}
+			}
+		}
+	}
+	if strings.Contains(strings.ToLower(headers), "application/x-www-form-urlencoded") {
+		if params, err := url.ParseQuery(strings.TrimSpace(body)); err == nil {
+			for key := range params {
+				out = append(out, MinimizeCandidate{Kind: "form-param", Name: key})
+			}
+		}
+	}
+	return out
+}
+
+// RemoveCandidate returns the dump with one candidate element removed,
+// keeping Content-Length consistent when the body changes.
+func RemoveCandidate(dump string, c MinimizeCandidate) (string, error) {
+	headers, body := network.SplitDump(dump)
+	lines := strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")
+	switch c.Kind {
+	case "header":
+		for i, line := range lines[1:] {
+			if name, _, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), c.Name) {
+				lines = append(lines[:i+1], lines[i+2:]...)
+				return strings.Join(lines, "\r\n") + "\r\n\r\n" + body, nil
+			}
+		}
+		return "", fmt.Errorf("no header %q in request", c.Name)
+	case "cookie":
+		for i, line := range lines[1:] {
+			name, value, ok := strings.Cut(line, ":")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "Cookie") {
+				continue
+			}
+			var kept []string
+			for _, pair := range strings.Split(value, ";") {
+				if cookie, _, _ := strings.Cut(strings.TrimSpace(pair), "="); cookie == c.Name {
+					continue
+				}
+				kept = append(kept, strings.TrimSpace(pair))
+			}
+			if len(kept) == 0 {
+				lines = append(lines[:i+1], lines[i+2:]...)
+			} else {
+				lines[i+1] = "Cookie: " + strings.Join(kept, "; ")
+			}
+			return strings.Join(lines, "\r\n") + "\r\n\r\n" + body, nil
+		}
+		return "", fmt.Errorf("no cookie %q in request", c.Name)
+	case "query-param":
+		parts := strings.SplitN(lines[0], " ", 3)
+		if len(parts) != 3 {
+			return "", fmt.Errorf("malformed request line %q", lines[0])
+		}
+		target, err := url.ParseRequestURI(parts[1])
+		if err != nil {
+			return "", fmt.Errorf("failed to parse request target: %w", err)
+		}
+		query := target.Query()
+		if _, ok := query[c.Name]; !ok {
+			return "", fmt.Errorf("no query parameter %q in request", c.Name)
+		}
+		query.Del(c.Name)
+		target.RawQuery = query.Encode()
+		lines[0] = parts[0] + " " + target.String() + " " + parts[2]
+		return strings.Join(lines, "\r\n") + "\r\n\r\n" + body, nil
+	case "form-param":
+		params, err := url.ParseQuery(strings.TrimSpace(body))
+		if err != nil {
+			return "", fmt.Errorf("failed to parse form body: %w", err)
+		}
+		if _, ok := params[c.Name]; !ok {
+			return "", fmt.Errorf("no form parameter %q in request", c.Name)
+		}
+		params.Del(c.Name)
+		return network.ReplaceDumpBody(strings.Join(lines, "\r\n")+"\r\n\r\n"+body, []byte(params.Encode())), nil
+	default:
+		return "", fmt.Errorf("unknown candidate kind %q", c.Kind)
+	}
+}
+
+// minimizeSizeTolerance is how far (as a fraction) a response body may drift
+// in size and still count as equivalent, absorbing timestamps, CSRF tokens,
+// and other dynamic content.
+const minimizeSizeTolerance = 0.05
+
+// ResponsesEquivalent reports whether two response dumps represent the same
+// application outcome for minimization purposes: same status code and a body
+// of roughly the same size (identical bodies always match).
+func ResponsesEquivalent(aDump, bDump string) bool {
+	a, errA := network.ParseResponseDump(aDump)
+	b, errB := network.ParseResponseDump(bDump)
+	if errA != nil || errB != nil {
+		return errA == nil && errB == nil
+	}
+	if a.StatusCode != b.StatusCode {
+		return false
+	}
+	_, bodyA := network.SplitDump(aDump)
+	_, bodyB := network.SplitDump(bDump)
+	if bodyA == bodyB {
+		return true
+	}
+	longer := len(bodyA)
+	if len(bodyB) > longer {
+		longer = len(bodyB)
+	}
+	if longer == 0 {
+		return true
+	}
+	diff := len(bodyA) - len(bodyB)
+	if diff < 0 {
+		diff = -diff
+	}
+	return float64(diff)/float64(longer) <= minimizeSizeTolerance
+}
@@ -0,0 +1,98 @@
+package automation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// APIServer is the local automation API. It only ever binds to loopback and
+// every route requires a token whose scope covers the route.
+type APIServer struct {
+	mu       sync.Mutex
+	addr     string
+	tokens   *TokenStore
+	mux      *http.ServeMux
+	listener net.Listener
+}
+
+// NewAPIServer creates an automation API bound to addr, authenticating
+// against tokens.
+func NewAPIServer(addr string, tokens *TokenStore) *APIServer {
+	return &APIServer{addr: addr, tokens: tokens, mux: http.NewServeMux()}
+}
+
+// Handle registers a route that requires the given scope. Handlers receive
+// requests that already passed token authentication.
+func (s *APIServer) Handle(pattern string, required TokenScope, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		secret := bearerToken(r)
+		if secret == "" {
+			writeJSONError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+		if _, err := s.tokens.Authenticate(secret, required); err != nil {
+			writeJSONError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// Start begins serving. It fails rather than bind to a non-loopback address.
+func (s *APIServer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener != nil {
+		return fmt.Errorf("automation API already running on %s", s.addr)
+	}
+	host, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return fmt.Errorf("invalid automation API address %q: %w", s.addr, err)
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("automation API must bind to loopback, got %q", host)
+	}
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to bind automation API on %s: %w", s.addr, err)
+	}
+	s.listener = listener
+	go http.Serve(listener, s.mux)
+	return nil
+}
+
+// Stop closes the listener if running.
+func (s *APIServer) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	s.listener = nil
+	return err
+}
+
+// WriteJSON writes v as a JSON response body.
+func WriteJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return ""
+}
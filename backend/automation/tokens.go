@@ -0,0 +1,176 @@
+// Package automation hosts the local REST API that lets scripts drive a
+// running Gleip instance, plus the scoped token auth protecting it.
+package automation
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// TokenScope limits what an API token may do. Scopes are deliberately
+// coarse: least-privilege without a policy language.
+type TokenScope string
+
+const (
+	// ScopeReadHistory allows read-only access to captured traffic.
+	ScopeReadHistory TokenScope = "read-history"
+	// ScopeExecuteFlows allows triggering flow execution but nothing else.
+	ScopeExecuteFlows TokenScope = "execute-flows"
+	// ScopeFull allows everything the automation API exposes.
+	ScopeFull TokenScope = "full"
+)
+
+// ValidScope reports whether s is a known scope.
+func ValidScope(s TokenScope) bool {
+	switch s {
+	case ScopeReadHistory, ScopeExecuteFlows, ScopeFull:
+		return true
+	}
+	return false
+}
+
+// Allows reports whether a token with scope s may perform an action that
+// requires the given scope.
+func (s TokenScope) Allows(required TokenScope) bool {
+	return s == ScopeFull || s == required
+}
+
+// APIToken is the persisted record of an issued token. The secret itself is
+// only returned once at creation; we store its SHA-256.
+type APIToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scope      TokenScope `json:"scope"`
+	SecretHash string     `json:"secretHash"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	Revoked    bool       `json:"revoked"`
+}
+
+// TokenStore manages API tokens, persisted as JSON in the data directory so
+// tokens survive restarts of the instance.
+type TokenStore struct {
+	mu     sync.Mutex
+	path   string
+	tokens []*APIToken
+}
+
+// NewTokenStore loads existing tokens from dataDir.
+func NewTokenStore(dataDir string) (*TokenStore, error) {
+	s := &TokenStore{path: filepath.Join(dataDir, "api-tokens.json")}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read token store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse token store: %w", err)
+	}
+	return s, nil
+}
+
+// Create issues a new token and returns its record plus the plaintext
+// secret. The secret cannot be recovered later.
+func (s *TokenStore) Create(name string, scope TokenScope) (*APIToken, string, error) {
+	if !ValidScope(scope) {
+		return nil, "", fmt.Errorf("unknown token scope %q", scope)
+	}
+	var raw [32]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return nil, "", fmt.Errorf("failed to generate token secret: %w", err)
+	}
+	secret := "gleip_" + hex.EncodeToString(raw[:])
+	token := &APIToken{
+		ID:         network.NewID(),
+		Name:       name,
+		Scope:      scope,
+		SecretHash: hashSecret(secret),
+		CreatedAt:  time.Now(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = append(s.tokens, token)
+	if err := s.persistLocked(); err != nil {
+		s.tokens = s.tokens[:len(s.tokens)-1]
+		return nil, "", err
+	}
+	return token, secret, nil
+}
+
+// Revoke permanently disables a token. The record is kept for auditability.
+func (s *TokenStore) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tokens {
+		if t.ID == id {
+			t.Revoked = true
+			return s.persistLocked()
+		}
+	}
+	return fmt.Errorf("no API token with ID %s", id)
+}
+
+// List returns all token records, including revoked ones.
+func (s *TokenStore) List() []*APIToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*APIToken, len(s.tokens))
+	copy(out, s.tokens)
+	return out
+}
+
+// Authenticate checks a presented secret against the store and the required
+// scope. On success it updates the token's last-used timestamp.
+func (s *TokenStore) Authenticate(secret string, required TokenScope) (*APIToken, error) {
+	hash := hashSecret(secret)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(t.SecretHash), []byte(hash)) != 1 {
+			continue
+		}
+		if t.Revoked {
+			return nil, fmt.Errorf("token %s is revoked", t.ID)
+		}
+		if !t.Scope.Allows(required) {
+			return nil, fmt.Errorf("token %s lacks required scope %q", t.ID, required)
+		}
+		now := time.Now()
+		t.LastUsedAt = &now
+		// Last-used is best effort; an unwritable disk should not fail auth.
+		_ = s.persistLocked()
+		return t, nil
+	}
+	return nil, fmt.Errorf("unknown API token")
+}
+
+func (s *TokenStore) persistLocked() error {
+	data, err := json.MarshalIndent(s.tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize tokens: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create data dir: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write token store: %w", err)
+	}
+	return nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,201 @@
+// Package cert owns the proxy's root CA and mints per-host leaf certificates
+// for TLS interception. The CA is generated on first run and persisted next
+// to the application data so the user only has to trust it once.
+package cert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	caCertFile = "gleip-ca.pem"
+	caKeyFile  = "gleip-ca-key.pem"
+)
+
+// CertificateManager holds the root CA and a cache of minted leaf
+// certificates keyed by host.
+type CertificateManager struct {
+	mu      sync.Mutex
+	dataDir string
+	caCert  *x509.Certificate
+	caKey   crypto.Signer
+	leaves  map[string]*tls.Certificate
+}
+
+// NewCertificateManager loads the CA from dataDir, generating and persisting
+// a fresh one if none exists yet.
+func NewCertificateManager(dataDir string) (*CertificateManager, error) {
+	m := &CertificateManager{dataDir: dataDir, leaves: make(map[string]*tls.Certificate)}
+	if err := m.loadCA(); err != nil {
+		if err := m.generateCA(); err != nil {
+			return nil, fmt.Errorf("failed to generate CA: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// CACertPEM returns the root certificate in PEM form for export/trust setup.
+func (m *CertificateManager) CACertPEM() []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.caCert.Raw})
+}
+
+// CACertDER returns the root certificate in DER form.
+func (m *CertificateManager) CACertDER() []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]byte(nil), m.caCert.Raw...)
+}
+
+// CertificateFor returns a leaf certificate valid for host, minting and
+// caching one on first use.
+func (m *CertificateManager) CertificateFor(host string) (*tls.Certificate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if leaf, ok := m.leaves[host]; ok {
+		return leaf, nil
+	}
+	leaf, err := m.mintLeaf(host)
+	if err != nil {
+		return nil, err
+	}
+	m.leaves[host] = leaf
+	return leaf, nil
+}
+
+func (m *CertificateManager) mintLeaf(host string) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate leaf key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial: %w", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, m.caCert, &key.PublicKey, m.caKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign leaf certificate: %w", err)
+	}
+	return &tls.Certificate{
+		Certificate: [][]byte{der, m.caCert.Raw},
+		PrivateKey:  key,
+	}, nil
+}
+
+func (m *CertificateManager) loadCA() error {
+	certPEM, err := os.ReadFile(filepath.Join(m.dataDir, caCertFile))
+	if err != nil {
+		return err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(m.dataDir, caKeyFile))
+	if err != nil {
+		return err
+	}
+	return m.installCA(certPEM, keyPEM)
+}
+
+func (m *CertificateManager) installCA(certPEM, keyPEM []byte) error {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return fmt.Errorf("no PEM certificate found")
+	}
+	caCert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return fmt.Errorf("no PEM key found")
+	}
+	caKey, err := parsePrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse CA key: %w", err)
+	}
+	if !publicKeysMatch(caCert.PublicKey, caKey.Public()) {
+		return fmt.Errorf("CA certificate and key do not match")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.caCert = caCert
+	m.caKey = caKey
+	m.leaves = make(map[string]*tls.Certificate)
+	return nil
+}
+
+func (m *CertificateManager) generateCA() error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return fmt.Errorf("failed to generate serial: %w", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "Gleip Proxy CA", Organization: []string{"Gleip"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            0,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return fmt.Errorf("failed to self-sign CA certificate: %w", err)
+	}
+	caCert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return fmt.Errorf("failed to parse generated CA: %w", err)
+	}
+	m.mu.Lock()
+	m.caCert = caCert
+	m.caKey = key
+	m.mu.Unlock()
+	return m.persistCA()
+}
+
+func (m *CertificateManager) persistCA() error {
+	if err := os.MkdirAll(m.dataDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create data dir: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.caCert.Raw})
+	keyDER, err := x509.MarshalPKCS8PrivateKey(m.caKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal CA key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(filepath.Join(m.dataDir, caCertFile), certPEM, 0o644); err != nil {
+		return fmt.Errorf("failed to write CA certificate: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(m.dataDir, caKeyFile), keyPEM, 0o600); err != nil {
+		return fmt.Errorf("failed to write CA key: %w", err)
+	}
+	return nil
+}
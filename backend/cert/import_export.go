@@ -0,0 +1,103 @@
+package cert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ImportCA replaces the active root CA with an external certificate/key pair
+// (e.g. an organization CA or one exported from Burp). The key may be EC or
+// RSA in PKCS#1, PKCS#8 or SEC1 PEM form. The imported CA is persisted and
+// the leaf cache is invalidated so new connections use it immediately.
+func (m *CertificateManager) ImportCA(certPEM, keyPEM []byte) error {
+	if err := m.installCA(certPEM, keyPEM); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	isCA := m.caCert.IsCA
+	m.mu.Unlock()
+	if !isCA {
+		return fmt.Errorf("imported certificate is not a CA certificate")
+	}
+	return m.persistCA()
+}
+
+// ImportCAFromFiles reads PEM files from disk and imports them.
+func (m *CertificateManager) ImportCAFromFiles(certPath, keyPath string) error {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return fmt.Errorf("failed to read CA certificate file: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read CA key file: %w", err)
+	}
+	return m.ImportCA(certPEM, keyPEM)
+}
+
+// ExportCA writes the current root certificate (and, when includeKey is set,
+// its private key) as PEM files into dir. It returns the written paths.
+func (m *CertificateManager) ExportCA(dir string, includeKey bool) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	certPath := filepath.Join(dir, caCertFile)
+	if err := os.WriteFile(certPath, m.CACertPEM(), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write CA certificate: %w", err)
+	}
+	paths := []string{certPath}
+	if includeKey {
+		m.mu.Lock()
+		keyDER, err := x509.MarshalPKCS8PrivateKey(m.caKey)
+		m.mu.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal CA key: %w", err)
+		}
+		keyPath := filepath.Join(dir, caKeyFile)
+		if err := os.WriteFile(keyPath, pemEncode("PRIVATE KEY", keyDER), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write CA key: %w", err)
+		}
+		paths = append(paths, keyPath)
+	}
+	return paths, nil
+}
+
+func pemEncode(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+// parsePrivateKey tries the common private key encodings in turn.
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported PKCS#8 key type %T", key)
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("key is not PKCS#8, SEC1 or PKCS#1 encoded")
+}
+
+func publicKeysMatch(certPub, keyPub crypto.PublicKey) bool {
+	switch pub := certPub.(type) {
+	case *ecdsa.PublicKey:
+		other, ok := keyPub.(*ecdsa.PublicKey)
+		return ok && pub.Equal(other)
+	case *rsa.PublicKey:
+		other, ok := keyPub.(*rsa.PublicKey)
+		return ok && pub.Equal(other)
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+// Package events decouples backend subsystems from the UI runtime. Backend
+// code emits named events through a process-wide sink; the application wires
+// the sink to the frontend bridge at startup. When no sink is installed
+// (tests, headless mode) events are dropped silently.
+package events
+
+import "sync"
+
+// Sink receives every event emitted by the backend.
+type Sink func(name string, payload interface{})
+
+var (
+	mu   sync.RWMutex
+	sink Sink
+)
+
+// SetSink installs the process-wide event sink. Passing nil disables emission.
+func SetSink(s Sink) {
+	mu.Lock()
+	defer mu.Unlock()
+	sink = s
+}
+
+// Emit delivers an event to the installed sink, if any.
+func Emit(name string, payload interface{}) {
+	mu.RLock()
+	s := sink
+	mu.RUnlock()
+	if s != nil {
+		s(name, payload)
+	}
+}
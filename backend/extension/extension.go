@@ -0,0 +1,227 @@
+// Package extension defines the in-process extension API. Registered
+// extensions observe traffic at fixed phases of the proxy pipeline and can
+// veto or modify it; per-extension budgets keep a slow hook from stalling
+// the proxy.
+package extension
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// Phase identifies where in the proxy pipeline a hook runs.
+type Phase string
+
+const (
+	// PhasePreForward runs after interception, just before the request goes
+	// upstream. Veto drops the request; Modify rewrites the forwarded dump.
+	PhasePreForward Phase = "pre-forward"
+	// PhasePostResponse runs once the response dump is built. Veto drops the
+	// exchange; Modify rewrites the response seen by the client and history.
+	PhasePostResponse Phase = "post-response"
+	// PhasePreStore runs before the transaction is recorded. Veto forwards
+	// the request without recording it; Modify rewrites only the stored dump
+	// (e.g. to scrub secrets), not what is sent upstream.
+	PhasePreStore Phase = "pre-store"
+)
+
+// Verdict is a hook's decision about the traffic it was shown.
+type Verdict int
+
+const (
+	// VerdictContinue leaves the traffic unchanged.
+	VerdictContinue Verdict = iota
+	// VerdictVeto stops the phase; the effect depends on the Phase.
+	VerdictVeto
+	// VerdictModify replaces the dump with Result.Dump.
+	VerdictModify
+)
+
+// Result is what a hook returns.
+type Result struct {
+	Verdict Verdict
+	Dump    string
+}
+
+// Continue returns a pass-through result.
+func Continue() Result { return Result{Verdict: VerdictContinue} }
+
+// Veto returns a vetoing result.
+func Veto() Result { return Result{Verdict: VerdictVeto} }
+
+// Modify returns a result replacing the dump.
+func Modify(dump string) Result { return Result{Verdict: VerdictModify, Dump: dump} }
+
+// HookFunc inspects a transaction and the dump relevant to the phase (the
+// request dump for pre-forward/pre-store, the response dump for
+// post-response). Hooks run on the proxy goroutine under a time budget.
+type HookFunc func(tx *network.HTTPTransaction, dump string) Result
+
+// DefaultBudgetMS is the per-call budget applied to extensions that don't
+// set their own.
+const DefaultBudgetMS = 50
+
+// Extension is one registered extension and its runtime toggles.
+type Extension struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// BudgetMS caps each hook call; a hook that overruns is skipped for that
+	// call and the traffic continues unmodified.
+	BudgetMS int `json:"budgetMs"`
+}
+
+type registeredHook struct {
+	extensionID string
+	fn          HookFunc
+}
+
+// Registry holds extensions and their hooks. All methods are safe for
+// concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	extensions map[string]*Extension
+	hooks      map[Phase][]registeredHook
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		extensions: make(map[string]*Extension),
+		hooks:      make(map[Phase][]registeredHook),
+	}
+}
+
+// Register adds an extension, enabled and with the default budget. A second
+// registration under the same ID is an error.
+func (r *Registry) Register(id, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.extensions[id]; exists {
+		return fmt.Errorf("extension %q is already registered", id)
+	}
+	r.extensions[id] = &Extension{ID: id, Name: name, Enabled: true, BudgetMS: DefaultBudgetMS}
+	return nil
+}
+
+// Unregister removes an extension and all of its hooks.
+func (r *Registry) Unregister(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.extensions, id)
+	for phase, hooks := range r.hooks {
+		kept := hooks[:0]
+		for _, h := range hooks {
+			if h.extensionID != id {
+				kept = append(kept, h)
+			}
+		}
+		r.hooks[phase] = kept
+	}
+}
+
+// AddHook attaches a hook to a phase on behalf of a registered extension.
+// Hooks run in registration order.
+func (r *Registry) AddHook(extensionID string, phase Phase, fn HookFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.extensions[extensionID]; !ok {
+		return fmt.Errorf("unknown extension %q", extensionID)
+	}
+	r.hooks[phase] = append(r.hooks[phase], registeredHook{extensionID: extensionID, fn: fn})
+	return nil
+}
+
+// SetEnabled toggles an extension; disabled extensions' hooks are skipped.
+func (r *Registry) SetEnabled(id string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ext, ok := r.extensions[id]
+	if !ok {
+		return fmt.Errorf("unknown extension %q", id)
+	}
+	ext.Enabled = enabled
+	return nil
+}
+
+// SetBudget changes an extension's per-call budget in milliseconds; zero or
+// negative restores the default.
+func (r *Registry) SetBudget(id string, ms int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ext, ok := r.extensions[id]
+	if !ok {
+		return fmt.Errorf("unknown extension %q", id)
+	}
+	if ms <= 0 {
+		ms = DefaultBudgetMS
+	}
+	ext.BudgetMS = ms
+	return nil
+}
+
+// List returns the registered extensions, sorted by name.
+func (r *Registry) List() []Extension {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Extension, 0, len(r.extensions))
+	for _, ext := range r.extensions {
+		out = append(out, *ext)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Run invokes every enabled hook for phase in order. It returns the
+// (possibly modified) dump and whether any hook vetoed. A hook that exceeds
+// its extension's budget is skipped for this call with a log line.
+func (r *Registry) Run(phase Phase, tx *network.HTTPTransaction, dump string) (string, bool) {
+	r.mu.RLock()
+	hooks := append([]registeredHook(nil), r.hooks[phase]...)
+	budgets := make(map[string]time.Duration, len(r.extensions))
+	for id, ext := range r.extensions {
+		if ext.Enabled {
+			budgets[id] = time.Duration(ext.BudgetMS) * time.Millisecond
+		}
+	}
+	r.mu.RUnlock()
+
+	for _, h := range hooks {
+		budget, enabled := budgets[h.extensionID]
+		if !enabled {
+			continue
+		}
+		result, ok := callWithBudget(h.fn, tx, dump, budget)
+		if !ok {
+			log.Printf("extension: %s exceeded its %v budget at %s, skipping", h.extensionID, budget, phase)
+			continue
+		}
+		switch result.Verdict {
+		case VerdictVeto:
+			return dump, true
+		case VerdictModify:
+			dump = result.Dump
+		}
+	}
+	return dump, false
+}
+
+// callWithBudget runs fn and gives up after budget elapses. The overrunning
+// call keeps running on its goroutine but its result is discarded.
+func callWithBudget(fn HookFunc, tx *network.HTTPTransaction, dump string, budget time.Duration) (Result, bool) {
+	done := make(chan Result, 1)
+	go func() { done <- fn(tx, dump) }()
+	timer := time.NewTimer(budget)
+	defer timer.Stop()
+	select {
+	case result := <-done:
+		return result, true
+	case <-timer.C:
+		return Result{}, false
+	}
+}
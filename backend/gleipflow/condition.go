@@ -0,0 +1,113 @@
+package gleipflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// ConditionStep branches a flow: when If holds, the Then steps run;
+// otherwise the Else steps do. Branch steps are full steps and may nest.
+type ConditionStep struct {
+	If   Condition `json:"if"`
+	Then []*Step   `json:"then,omitempty"`
+	Else []*Step   `json:"else,omitempty"`
+}
+
+// Condition is one boolean test, evaluated against the flow's variables or
+// the most recent successful request step's response.
+type Condition struct {
+	// Source selects what is inspected: "variable", "status", "header", or
+	// "body". All but "variable" read the previous response.
+	Source string `json:"source"`
+	// Name is the variable or header to read (unused for status and body).
+	Name string `json:"name,omitempty"`
+	// Operator compares the inspected value to Value: "equals",
+	// "not-equals", "contains", or "matches" (regex).
+	Operator string `json:"operator"`
+	// Value is the comparison operand; {{variable}} templates are
+	// substituted before comparing.
+	Value string `json:"value"`
+}
+
+// Validate checks the condition is well-formed before it is saved, so flows
+// fail at edit time rather than mid-run.
+func (c Condition) Validate() error {
+	switch c.Source {
+	case "variable", "header":
+		if c.Name == "" {
+			return fmt.Errorf("condition source %q requires a name", c.Source)
+		}
+	case "status", "body":
+	default:
+		return fmt.Errorf("unknown condition source %q (use variable, status, header, or body)", c.Source)
+	}
+	switch c.Operator {
+	case "equals", "not-equals", "contains":
+	case "matches":
+		if _, err := network.CompileCached(c.Value); err != nil {
+			return fmt.Errorf("invalid condition regex %q: %w", c.Value, err)
+		}
+	default:
+		return fmt.Errorf("unknown condition operator %q (use equals, not-equals, contains, or matches)", c.Operator)
+	}
+	return nil
+}
+
+// Evaluate reports whether the condition holds. last is the most recent
+// successful request step result, or nil when none has run yet.
+func (c Condition) Evaluate(vars map[string]string, last *ExecutionResult) (bool, error) {
+	var subject string
+	switch c.Source {
+	case "variable":
+		subject = vars[c.Name]
+	case "status":
+		if last == nil {
+			return false, fmt.Errorf("no previous response to evaluate status against")
+		}
+		subject = strconv.Itoa(last.StatusCode)
+	case "header":
+		if last == nil {
+			return false, fmt.Errorf("no previous response to evaluate header against")
+		}
+		subject = responseHeaderValue(last.ResponseDump, c.Name)
+	case "body":
+		if last == nil {
+			return false, fmt.Errorf("no previous response to evaluate body against")
+		}
+		_, subject = network.SplitDump(last.ResponseDump)
+	default:
+		return false, fmt.Errorf("unknown condition source %q", c.Source)
+	}
+	value := SubstituteVariables(c.Value, vars)
+	switch c.Operator {
+	case "equals":
+		return subject == value, nil
+	case "not-equals":
+		return subject != value, nil
+	case "contains":
+		return strings.Contains(subject, value), nil
+	case "matches":
+		re, err := network.CompileCached(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid condition regex %q: %w", value, err)
+		}
+		return re.MatchString(subject), nil
+	default:
+		return false, fmt.Errorf("unknown condition operator %q", c.Operator)
+	}
+}
+
+// responseHeaderValue reads one header's value from a response dump ("" when
+// absent).
+func responseHeaderValue(dump, name string) string {
+	headers, _ := network.SplitDump(dump)
+	for _, line := range strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")[1:] {
+		if key, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
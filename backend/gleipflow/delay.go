@@ -0,0 +1,92 @@
+package gleipflow
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// delayMaxMS caps every pause at five minutes so a typo cannot hang a flow.
+const delayMaxMS = 300000
+
+// delayPollInterval is how often an until-variable delay re-checks the
+// variable.
+const delayPollInterval = 100 * time.Millisecond
+
+// DelayStep pauses a flow between requests, for targets with async
+// server-side processing. No dummy request is sent.
+type DelayStep struct {
+	// Mode selects the pause form: "fixed", "random", or "until-variable".
+	Mode string `json:"mode"`
+	// DurationMS is the pause length for "fixed" mode.
+	DurationMS int `json:"durationMs,omitempty"`
+	// MinMS and MaxMS bound the pause for "random" mode.
+	MinMS int `json:"minMs,omitempty"`
+	MaxMS int `json:"maxMs,omitempty"`
+	// Variable names the flow variable to wait for in "until-variable" mode;
+	// the step completes once it is non-empty (set mid-run from the UI or by
+	// an earlier step).
+	Variable string `json:"variable,omitempty"`
+	// TimeoutMS caps the wait in "until-variable" mode (default 60000).
+	TimeoutMS int `json:"timeoutMs,omitempty"`
+}
+
+// Validate checks the delay is well-formed before it is saved.
+func (d DelayStep) Validate() error {
+	switch d.Mode {
+	case "fixed":
+		if d.DurationMS <= 0 || d.DurationMS > delayMaxMS {
+			return fmt.Errorf("delay duration must be between 1 and %d ms", delayMaxMS)
+		}
+	case "random":
+		if d.MinMS < 0 || d.MaxMS <= d.MinMS || d.MaxMS > delayMaxMS {
+			return fmt.Errorf("random delay range must satisfy 0 <= min < max <= %d ms", delayMaxMS)
+		}
+	case "until-variable":
+		if d.Variable == "" {
+			return fmt.Errorf("until-variable delay requires a variable name")
+		}
+		if d.TimeoutMS < 0 || d.TimeoutMS > delayMaxMS {
+			return fmt.Errorf("delay timeout must be between 0 and %d ms", delayMaxMS)
+		}
+	default:
+		return fmt.Errorf("unknown delay mode %q (use fixed, random, or until-variable)", d.Mode)
+	}
+	return nil
+}
+
+// executeDelayStep pauses the run. The result's Duration records how long
+// was actually waited.
+func (e *Executor) executeDelayStep(ds *DelayStep, run *flowRun, result *ExecutionResult) {
+	switch ds.Mode {
+	case "fixed":
+		time.Sleep(time.Duration(ds.DurationMS) * time.Millisecond)
+	case "random":
+		ms := ds.MinMS + rand.Intn(ds.MaxMS-ds.MinMS+1)
+		time.Sleep(time.Duration(ms) * time.Millisecond)
+	case "until-variable":
+		timeout := time.Duration(ds.TimeoutMS) * time.Millisecond
+		if timeout <= 0 {
+			timeout = time.Minute
+		}
+		deadline := time.Now().Add(timeout)
+		for {
+			if value := run.vars[ds.Variable]; value != "" {
+				return
+			}
+			if e.VariableLookup != nil {
+				if value, ok := e.VariableLookup(run.flow.ID, ds.Variable); ok && value != "" {
+					run.vars[ds.Variable] = value
+					return
+				}
+			}
+			if time.Now().After(deadline) {
+				result.Error = fmt.Sprintf("timed out waiting for variable %q", ds.Variable)
+				return
+			}
+			time.Sleep(delayPollInterval)
+		}
+	default:
+		result.Error = fmt.Sprintf("unknown delay mode %q", ds.Mode)
+	}
+}
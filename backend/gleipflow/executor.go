@@ -0,0 +1,288 @@
+package gleipflow
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"gleip/backend/network"
+)
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// SubstituteVariables replaces {{name}} placeholders with values from vars.
+// Unknown variables are left in place so validation can flag them.
+func SubstituteVariables(s string, vars map[string]string) string {
+	return variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := strings.TrimSpace(strings.Trim(match, "{}"))
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// Executor runs flows. One executor is shared by the whole app; it keeps its
+// own outbound transport, independent of the proxy's.
+type Executor struct {
+	transport *http.Transport
+	hosts     *network.HostsOverrides
+	// ProfileLookup resolves a client profile ID. Wired by the App so the
+	// executor doesn't need to know where profiles are stored.
+	ProfileLookup func(id string) *ClientProfile
+	// VariableLookup reads a flow variable's current value under the owner's
+	// locking, so until-variable delay steps see updates made mid-run. Wired
+	// by the App.
+	VariableLookup func(flowID, name string) (string, bool)
+}
+
+// NewExecutor returns an executor with default transport settings. The
+// hosts override table is shared with the proxy so flow requests resolve
+// the same way proxied traffic does.
+func NewExecutor(hosts *network.HostsOverrides) *Executor {
+	transport := &http.Transport{
+		TLSClientConfig:    &tls.Config{InsecureSkipVerify: true},
+		DisableCompression: true,
+	}
+	if hosts != nil {
+		transport.DialContext = hosts.DialContext
+	}
+	return &Executor{transport: transport, hosts: hosts}
+}
+
+// SetUpstreamProxy chains flow execution through an upstream proxy,
+// mirroring the proxy-side setting. An empty Addr restores direct dialing.
+func (e *Executor) SetUpstreamProxy(cfg network.UpstreamProxyConfig) error {
+	if cfg.Addr == "" {
+		if e.hosts != nil {
+			e.transport.DialContext = e.hosts.DialContext
+		} else {
+			e.transport.DialContext = nil
+		}
+	} else {
+		var inner func(context.Context, string, string) (net.Conn, error)
+		if e.hosts != nil {
+			inner = e.hosts.DialContext
+		}
+		e.transport.DialContext = network.NewUpstreamDialer(cfg, inner).DialContext
+	}
+	e.transport.CloseIdleConnections()
+	return nil
+}
+
+// Transport exposes the executor's outbound transport for one-off probes
+// (e.g. the CORS tester) so they share fingerprint and host-override config.
+func (e *Executor) Transport() http.RoundTripper {
+	return e.transport
+}
+
+// SetTLSFingerprint switches the executor's ClientHello to mimic the named
+// client, matching the proxy-side setting of the same name.
+func (e *Executor) SetTLSFingerprint(name string) error {
+	if err := network.ApplyTLSFingerprint(e.transport.TLSClientConfig, name); err != nil {
+		return err
+	}
+	e.transport.CloseIdleConnections()
+	return nil
+}
+
+// SetOutboundTLSOptions forces TLS versions, cipher suites, and legacy
+// renegotiation on flow execution, mirroring the proxy-side setting.
+func (e *Executor) SetOutboundTLSOptions(opts network.OutboundTLSOptions) error {
+	if err := opts.Apply(e.transport.TLSClientConfig); err != nil {
+		return err
+	}
+	e.transport.CloseIdleConnections()
+	return nil
+}
+
+// SetConnectionPoolOptions tunes connection reuse for flow execution,
+// mirroring the proxy-side setting.
+func (e *Executor) SetConnectionPoolOptions(opts network.ConnectionPoolOptions) {
+	opts.Apply(e.transport)
+}
+
+// flowRun carries the mutable state of one flow execution: the variable
+// context, the accumulated results, and the most recent successful request
+// result that conditions evaluate against.
+type flowRun struct {
+	flow    *GleipFlow
+	vars    map[string]string
+	results []*ExecutionResult
+	last    *ExecutionResult
+}
+
+// Execute runs every enabled step in order, updating the flow's
+// ExecutionResults and returning the results of this run.
+func (e *Executor) Execute(flow *GleipFlow) []*ExecutionResult {
+	if flow.ExecutionResults == nil {
+		flow.ExecutionResults = make(map[string]*ExecutionResult)
+	}
+	vars := make(map[string]string, len(flow.Variables))
+	for k, v := range flow.Variables {
+		vars[k] = v
+	}
+	run := &flowRun{flow: flow, vars: vars}
+	e.executeSteps(flow.Steps, run)
+	return run.results
+}
+
+// executeSteps runs one step list (the flow's top level or a condition
+// branch) within a run.
+func (e *Executor) executeSteps(steps []*Step, run *flowRun) {
+	for _, step := range steps {
+		if step.Disabled {
+			continue
+		}
+		result := e.executeStep(step, run)
+		run.flow.ExecutionResults[step.ID] = result
+		run.results = append(run.results, result)
+		if step.Type == StepRequest && result.Error == "" {
+			run.last = result
+		}
+	}
+}
+
+func (e *Executor) executeStep(step *Step, run *flowRun) *ExecutionResult {
+	result := &ExecutionResult{StepID: step.ID, StepName: step.Name, ExecutedAt: time.Now()}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	switch step.Type {
+	case StepRequest:
+		if step.Request == nil {
+			result.Error = "request step has no request payload"
+			return result
+		}
+		e.executeRequestStep(run.flow, step.Request, run.vars, result)
+	case StepCondition:
+		if step.Condition == nil {
+			result.Error = "condition step has no condition payload"
+			return result
+		}
+		e.executeConditionStep(step.Condition, run, result)
+	case StepLoop:
+		if step.Loop == nil {
+			result.Error = "loop step has no loop payload"
+			return result
+		}
+		e.executeLoopStep(step.Loop, run, result)
+	case StepParallel:
+		if step.Parallel == nil {
+			result.Error = "parallel step has no group payload"
+			return result
+		}
+		e.executeParallelStep(step.Parallel, run, result)
+	case StepDelay:
+		if step.Delay == nil {
+			result.Error = "delay step has no delay payload"
+			return result
+		}
+		e.executeDelayStep(step.Delay, run, result)
+	default:
+		result.Error = fmt.Sprintf("unknown step type %q", step.Type)
+	}
+	return result
+}
+
+// executeConditionStep evaluates the condition and runs the selected branch
+// within the same run, so branch steps share variables and record results
+// like top-level steps.
+func (e *Executor) executeConditionStep(cs *ConditionStep, run *flowRun, result *ExecutionResult) {
+	ok, err := cs.If.Evaluate(run.vars, run.last)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	branch := cs.Else
+	result.BranchTaken = "else"
+	if ok {
+		branch = cs.Then
+		result.BranchTaken = "then"
+	}
+	e.executeSteps(branch, run)
+}
+
+func (e *Executor) executeRequestStep(flow *GleipFlow, rs *RequestStep, vars map[string]string, result *ExecutionResult) {
+	if rs.Raw {
+		dump := ExpandTemplates(rs.Dump, vars)
+		result.RequestDump = dump
+		netStart := time.Now()
+		respDump, err := e.SendRawResolved(rs.Host, rs.ResolveTo, rs.TLS, dump)
+		result.NetworkDuration = time.Since(netStart)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		result.ResponseDump = respDump
+		if resp, err := network.ParseResponseDump(respDump); err == nil {
+			result.StatusCode = resp.StatusCode
+		}
+		return
+	}
+
+	dump := ExpandTemplates(rs.Dump, vars)
+	if profile := e.resolveProfile(flow, rs); profile != nil {
+		dump = profile.Apply(dump)
+	}
+	result.RequestDump = dump
+
+	req, err := network.ParseRequestDump(dump)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	host := rs.Host
+	if host == "" {
+		host = req.Host
+	}
+	req.URL.Host = host
+	if rs.ResolveTo != "" {
+		// Dial the override IP while the Host header keeps the original
+		// hostname.
+		req.Host = host
+		req.URL.Host = resolveTarget(host, rs.ResolveTo, rs.TLS)
+	}
+	if rs.TLS {
+		req.URL.Scheme = "https"
+	} else {
+		req.URL.Scheme = "http"
+	}
+	req.RequestURI = ""
+
+	netStart := time.Now()
+	resp, err := e.transport.RoundTrip(req)
+	result.NetworkDuration = time.Since(netStart)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+	respDump, err := network.DumpResponse(resp)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	result.ResponseDump = respDump
+	result.StatusCode = resp.StatusCode
+}
+
+// resolveProfile picks the step's profile override, falling back to the
+// flow-level profile.
+func (e *Executor) resolveProfile(flow *GleipFlow, rs *RequestStep) *ClientProfile {
+	if e.ProfileLookup == nil {
+		return nil
+	}
+	if rs.ClientProfileID != "" {
+		return e.ProfileLookup(rs.ClientProfileID)
+	}
+	if flow.ClientProfileID != "" {
+		return e.ProfileLookup(flow.ClientProfileID)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Package gleipflow implements GleipFlows: ordered automation flows whose
+// steps send requests, transform data, and pass variables between each
+// other.
+package gleipflow
+
+import "time"
+
+// StepType discriminates the Step union.
+type StepType string
+
+const (
+	// StepRequest sends an HTTP request built from a raw dump.
+	StepRequest StepType = "request"
+	// StepCondition evaluates an expression and executes one of two branches.
+	StepCondition StepType = "condition"
+	// StepLoop repeats a group of steps.
+	StepLoop StepType = "loop"
+	// StepParallel runs a group of request steps concurrently.
+	StepParallel StepType = "parallel"
+	// StepDelay pauses the flow.
+	StepDelay StepType = "delay"
+)
+
+// GleipFlow is one automation flow. Steps execute in order; Variables seeds
+// the substitution context available to every step as {{name}}.
+type GleipFlow struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Steps     []*Step           `json:"steps"`
+	Variables map[string]string `json:"variables,omitempty"`
+	// ClientProfileID selects the client fingerprint profile applied to all
+	// request steps that don't override it.
+	ClientProfileID string    `json:"clientProfileId,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+
+	// ExecutionResults holds the most recent result per step ID.
+	ExecutionResults map[string]*ExecutionResult `json:"executionResults,omitempty"`
+}
+
+// Step is one entry in a flow. Exactly one of the per-type payloads is set,
+// matching Type.
+type Step struct {
+	ID        string         `json:"id"`
+	Type      StepType       `json:"type"`
+	Name      string         `json:"name"`
+	Disabled  bool           `json:"disabled,omitempty"`
+	Request   *RequestStep   `json:"request,omitempty"`
+	Condition *ConditionStep `json:"condition,omitempty"`
+	Loop      *LoopStep      `json:"loop,omitempty"`
+	Parallel  *ParallelStep  `json:"parallel,omitempty"`
+	Delay     *DelayStep     `json:"delay,omitempty"`
+}
+
+// RequestStep sends the raw Dump (after variable substitution) to Host.
+type RequestStep struct {
+	Dump string `json:"dump"`
+	Host string `json:"host"`
+	TLS  bool   `json:"tls"`
+	// ClientProfileID overrides the flow-level fingerprint profile for this
+	// step only.
+	ClientProfileID string `json:"clientProfileId,omitempty"`
+	// Raw transmits the dump byte-for-byte over the socket, with no header
+	// normalization and no automatic Content-Length, so smuggling and
+	// parser-differential payloads survive intact.
+	Raw bool `json:"raw,omitempty"`
+	// ResolveTo sends the request to this IP (optionally ip:port) instead of
+	// resolving Host via DNS, so prod hostnames can be replayed against a
+	// staging box without global configuration changes.
+	ResolveTo string `json:"resolveTo,omitempty"`
+	// Binding, when set, keeps the step in sync with live traffic: matching
+	// requests observed by the proxy are offered as dump updates.
+	Binding *StepBinding `json:"binding,omitempty"`
+}
+
+// StepBinding describes the endpoint a request step is bound to.
+type StepBinding struct {
+	Method string `json:"method,omitempty"`
+	Host   string `json:"host"`
+	Path   string `json:"path"`
+}
+
+// Matches reports whether an observed request matches the binding.
+func (b *StepBinding) Matches(method, host, path string) bool {
+	if b == nil {
+		return false
+	}
+	if b.Method != "" && b.Method != method {
+		return false
+	}
+	return b.Host == host && b.Path == path
+}
+
+// ExecutionResult captures what one step did during the latest run.
+type ExecutionResult struct {
+	StepID       string        `json:"stepId"`
+	StepName     string        `json:"stepName"`
+	RequestDump  string        `json:"requestDump,omitempty"`
+	ResponseDump string        `json:"responseDump,omitempty"`
+	StatusCode   int           `json:"statusCode,omitempty"`
+	Duration     time.Duration `json:"duration"`
+	// NetworkDuration is the portion of Duration spent on the wire; the rest
+	// is processing (templating, parsing, profiles).
+	NetworkDuration time.Duration `json:"networkDuration"`
+	// Retries counts additional attempts beyond the first.
+	Retries int `json:"retries,omitempty"`
+	// TransactionID links to the history transaction this execution was
+	// recorded as, so the UI can jump from a step result to full history.
+	TransactionID string `json:"transactionId,omitempty"`
+	// BranchTaken records which branch a condition step selected ("then" or
+	// "else").
+	BranchTaken string `json:"branchTaken,omitempty"`
+	// Iterations counts how many times a loop step ran its body.
+	Iterations int       `json:"iterations,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	ExecutedAt time.Time `json:"executedAt"`
+}
+
+// FindStep returns the step with the given ID and its index.
+func (f *GleipFlow) FindStep(id string) (*Step, int) {
+	for i, s := range f.Steps {
+		if s.ID == id {
+			return s, i
+		}
+	}
+	return nil, -1
+}
@@ -0,0 +1,88 @@
+package gleipflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// LintWarning is one pre-flight finding on a request step. Code is stable
+// for programmatic handling; Message is shown to the user.
+type LintWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// LintRequestDump validates a request dump before it is sent, returning
+// structured warnings for problems that would otherwise surface as opaque
+// network errors: a malformed request line, a missing Host header,
+// Content-Length not matching the body, unresolved {{variables}}, and
+// non-ASCII bytes in headers.
+func LintRequestDump(dump string, vars map[string]string) []LintWarning {
+	var warnings []LintWarning
+	warn := func(code, format string, args ...any) {
+		warnings = append(warnings, LintWarning{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, match := range variablePattern.FindAllString(dump, -1) {
+		name := strings.TrimSpace(strings.Trim(match, "{}"))
+		if _, ok := vars[name]; !ok {
+			warn("unresolved-variable", "variable {{%s}} has no value", name)
+		}
+	}
+
+	header, body := network.SplitDump(dump)
+	lines := strings.Split(strings.ReplaceAll(header, "\r\n", "\n"), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		warn("malformed-request-line", "request is empty")
+		return warnings
+	}
+	parts := strings.Fields(lines[0])
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], "HTTP/") {
+		warn("malformed-request-line", "request line %q is not METHOD target HTTP/version", lines[0])
+	}
+
+	var hostSeen bool
+	var contentLengths []string
+	for _, line := range lines[1:] {
+		if line == "" {
+			continue
+		}
+		for i := 0; i < len(line); i++ {
+			if line[i] > 127 {
+				warn("non-ascii-header", "header line %q contains non-ASCII bytes", line)
+				break
+			}
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			warn("malformed-header", "header line %q has no colon", line)
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "host":
+			hostSeen = true
+		case "content-length":
+			contentLengths = append(contentLengths, strings.TrimSpace(value))
+		}
+	}
+	if !hostSeen {
+		warn("missing-host", "request has no Host header")
+	}
+	for i := 1; i < len(contentLengths); i++ {
+		if contentLengths[i] != contentLengths[0] {
+			warn("conflicting-content-length", "request has multiple Content-Length headers with different values")
+			break
+		}
+	}
+	if len(contentLengths) > 0 {
+		if declared, err := strconv.Atoi(contentLengths[0]); err != nil {
+			warn("conflicting-content-length", "Content-Length %q is not a number", contentLengths[0])
+		} else if declared != len(body) {
+			warn("conflicting-content-length", "Content-Length is %d but the body is %d bytes", declared, len(body))
+		}
+	}
+	return warnings
+}
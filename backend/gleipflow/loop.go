@@ -0,0 +1,128 @@
+package gleipflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// loopMaxIterations caps every loop so a while-condition that never turns
+// false cannot spin a flow forever.
+const loopMaxIterations = 1000
+
+// LoopStep repeats its Body steps: a fixed number of times, while a
+// condition holds, or once per item of a list variable. Pagination walks and
+// token brute-forcing are expressed this way without scripting.
+type LoopStep struct {
+	// Mode selects the loop form: "count", "while", or "for-each".
+	Mode string `json:"mode"`
+	// Count is the iteration count for "count" mode.
+	Count int `json:"count,omitempty"`
+	// While is evaluated before each iteration in "while" mode; the loop
+	// stops when it no longer holds.
+	While *Condition `json:"while,omitempty"`
+	// ItemsVariable names the flow variable holding the list for "for-each"
+	// mode, one item per line (commas also accepted on a single line).
+	ItemsVariable string `json:"itemsVariable,omitempty"`
+	// IterationVariable is set in the variable context each iteration: the
+	// current item in "for-each" mode, the zero-based index otherwise.
+	// Defaults to "item" and "i" respectively.
+	IterationVariable string  `json:"iterationVariable,omitempty"`
+	Body              []*Step `json:"body,omitempty"`
+}
+
+// Validate checks the loop is well-formed before it is saved.
+func (l LoopStep) Validate() error {
+	switch l.Mode {
+	case "count":
+		if l.Count <= 0 || l.Count > loopMaxIterations {
+			return fmt.Errorf("loop count must be between 1 and %d", loopMaxIterations)
+		}
+	case "while":
+		if l.While == nil {
+			return fmt.Errorf("while loop requires a condition")
+		}
+		if err := l.While.Validate(); err != nil {
+			return err
+		}
+	case "for-each":
+		if l.ItemsVariable == "" {
+			return fmt.Errorf("for-each loop requires an items variable")
+		}
+	default:
+		return fmt.Errorf("unknown loop mode %q (use count, while, or for-each)", l.Mode)
+	}
+	return nil
+}
+
+// iterationVariable resolves the loop's variable name with its per-mode
+// default.
+func (l LoopStep) iterationVariable() string {
+	if l.IterationVariable != "" {
+		return l.IterationVariable
+	}
+	if l.Mode == "for-each" {
+		return "item"
+	}
+	return "i"
+}
+
+// splitLoopItems parses a list variable: one item per line, or
+// comma-separated when there are no newlines. Blank items are dropped.
+func splitLoopItems(value string) []string {
+	sep := "\n"
+	if !strings.Contains(value, "\n") {
+		sep = ","
+	}
+	var items []string
+	for _, item := range strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// executeLoopStep runs the loop body within the run, updating the iteration
+// variable before each pass. Body results overwrite per step ID in
+// ExecutionResults, so the map keeps the last iteration's outcome.
+func (e *Executor) executeLoopStep(ls *LoopStep, run *flowRun, result *ExecutionResult) {
+	iterVar := ls.iterationVariable()
+	switch ls.Mode {
+	case "count":
+		count := ls.Count
+		if count > loopMaxIterations {
+			count = loopMaxIterations
+		}
+		for i := 0; i < count; i++ {
+			run.vars[iterVar] = strconv.Itoa(i)
+			e.executeSteps(ls.Body, run)
+			result.Iterations++
+		}
+	case "while":
+		for result.Iterations < loopMaxIterations {
+			ok, err := ls.While.Evaluate(run.vars, run.last)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			if !ok {
+				return
+			}
+			run.vars[iterVar] = strconv.Itoa(result.Iterations)
+			e.executeSteps(ls.Body, run)
+			result.Iterations++
+		}
+	case "for-each":
+		for _, item := range splitLoopItems(run.vars[ls.ItemsVariable]) {
+			if result.Iterations >= loopMaxIterations {
+				return
+			}
+			run.vars[iterVar] = item
+			e.executeSteps(ls.Body, run)
+			result.Iterations++
+		}
+	default:
+		result.Error = fmt.Sprintf("unknown loop mode %q", ls.Mode)
+	}
+}
@@ -0,0 +1,84 @@
+package gleipflow
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ParallelStep runs a group of request steps concurrently, for speeding up
+// independent requests and for simple race tests. Only request steps may be
+// grouped: concurrent branches or loops would make variable state ambiguous.
+type ParallelStep struct {
+	// Workers caps how many requests are in flight at once; 0 runs the whole
+	// group simultaneously.
+	Workers int     `json:"workers,omitempty"`
+	Steps   []*Step `json:"steps,omitempty"`
+}
+
+// Validate checks the group is well-formed before it is saved.
+func (p ParallelStep) Validate() error {
+	if p.Workers < 0 {
+		return fmt.Errorf("worker count cannot be negative")
+	}
+	for _, step := range p.Steps {
+		if step.Type != StepRequest {
+			return fmt.Errorf("parallel groups may contain only request steps; %q is a %s step", step.Name, step.Type)
+		}
+	}
+	return nil
+}
+
+// executeParallelStep fans the group's request steps out across a worker
+// pool, then merges the results into the run in declaration order so
+// ExecutionResults stay deterministic regardless of completion order.
+func (e *Executor) executeParallelStep(ps *ParallelStep, run *flowRun, result *ExecutionResult) {
+	var steps []*Step
+	for _, step := range ps.Steps {
+		if step.Disabled {
+			continue
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 {
+		return
+	}
+	workers := ps.Workers
+	if workers <= 0 || workers > len(steps) {
+		workers = len(steps)
+	}
+	results := make([]*ExecutionResult, len(steps))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, step := range steps {
+		wg.Add(1)
+		go func(i int, step *Step) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			r := &ExecutionResult{StepID: step.ID, StepName: step.Name, ExecutedAt: time.Now()}
+			start := time.Now()
+			if step.Type != StepRequest || step.Request == nil {
+				r.Error = "parallel groups may contain only request steps"
+			} else {
+				// Each worker gets its own variable snapshot: concurrent
+				// steps must not race on the shared context.
+				vars := make(map[string]string, len(run.vars))
+				for k, v := range run.vars {
+					vars[k] = v
+				}
+				e.executeRequestStep(run.flow, step.Request, vars, r)
+			}
+			r.Duration = time.Since(start)
+			results[i] = r
+		}(i, step)
+	}
+	wg.Wait()
+	for _, r := range results {
+		run.flow.ExecutionResults[r.StepID] = r
+		run.results = append(run.results, r)
+		if r.Error == "" {
+			run.last = r
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package gleipflow
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// pollutionMarker is appended to injected duplicate values so differing
+// parse precedence shows up in responses.
+const pollutionMarker = "gleip_hpp"
+
+// BuildParameterPollutionVariants clones a request step into HTTP parameter
+// pollution test cases: duplicated keys in the query string, duplicated keys
+// in a form body, and parameters moved between locations. Comparing the
+// variants' responses reveals which copy each component honors.
+func BuildParameterPollutionVariants(step *Step) ([]*Step, error) {
+	if step.Type != StepRequest || step.Request == nil {
+		return nil, fmt.Errorf("step %q is not a request step", step.Name)
+	}
+	headers, body := network.SplitDump(step.Request.Dump)
+	lines := strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")
+	parts := strings.SplitN(lines[0], " ", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed request line %q", lines[0])
+	}
+	target, err := url.ParseRequestURI(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request target: %w", err)
+	}
+	queryParams := target.Query()
+	isForm := strings.Contains(strings.ToLower(headers), "application/x-www-form-urlencoded")
+	var bodyParams url.Values
+	if isForm {
+		bodyParams, _ = url.ParseQuery(strings.TrimSpace(body))
+	}
+
+	var variants []*Step
+	add := func(label, rawQuery, newBody string) {
+		newLines := append([]string(nil), lines...)
+		newTarget := *target
+		newTarget.RawQuery = rawQuery
+		newLines[0] = parts[0] + " " + newTarget.String() + " " + parts[2]
+		dump := strings.Join(newLines, "\r\n") + "\r\n\r\n" + newBody
+		if newBody != body {
+			// Reframe Content-Length so the injected marker survives the
+			// re-parse at send time instead of being truncated away.
+			dump = network.ReplaceDumpBody(dump, []byte(newBody))
+		}
+		variants = append(variants, &Step{
+			ID:   network.NewID(),
+			Type: StepRequest,
+			Name: step.Name + " [hpp: " + label + "]",
+			Request: &RequestStep{
+				Dump:            dump,
+				Host:            step.Request.Host,
+				TLS:             step.Request.TLS,
+				ClientProfileID: step.Request.ClientProfileID,
+			},
+		})
+	}
+
+	for key := range queryParams {
+		// Duplicate the key in the query with a marker value appended.
+		dup := target.RawQuery + "&" + url.QueryEscape(key) + "=" + url.QueryEscape(pollutionMarker)
+		add("dup query "+key, dup, body)
+		if isForm {
+			// Same key supplied in both query and body.
+			newBody := strings.TrimSpace(body)
+			if newBody != "" {
+				newBody += "&"
+			}
+			newBody += url.QueryEscape(key) + "=" + url.QueryEscape(pollutionMarker)
+			add("query+body "+key, target.RawQuery, newBody)
+		}
+	}
+	for key := range bodyParams {
+		// Duplicate the key within the body.
+		newBody := strings.TrimSpace(body) + "&" + url.QueryEscape(key) + "=" + url.QueryEscape(pollutionMarker)
+		add("dup body "+key, target.RawQuery, newBody)
+		// And mirror the body key into the query.
+		dup := target.RawQuery
+		if dup != "" {
+			dup += "&"
+		}
+		dup += url.QueryEscape(key) + "=" + url.QueryEscape(pollutionMarker)
+		add("body+query "+key, dup, body)
+	}
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("request has no query or form parameters to pollute")
+	}
+	return variants, nil
+}
@@ -0,0 +1,101 @@
+package gleipflow
+
+import (
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// ProfileHeader is one header of a client profile. Order matters: browsers
+// send these in a characteristic order and we reproduce it.
+type ProfileHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ClientProfile is a named header set mimicking a real client (User-Agent,
+// Accept-*, sec-ch-*), applied to flow requests so replayed traffic blends
+// in with genuine browser requests.
+type ClientProfile struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Headers []ProfileHeader `json:"headers"`
+	// Builtin profiles ship with Gleip and cannot be edited.
+	Builtin bool `json:"builtin,omitempty"`
+}
+
+// Apply rewrites a request dump with the profile's headers. Profile headers
+// replace same-named headers in the dump; headers the dump doesn't have are
+// appended in profile order.
+func (p *ClientProfile) Apply(dump string) string {
+	if p == nil || len(p.Headers) == 0 {
+		return dump
+	}
+	headers, body := network.SplitDump(dump)
+	lines := strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")
+	out := lines[:1:1]
+	for _, line := range lines[1:] {
+		name, _, ok := strings.Cut(line, ":")
+		if ok && p.hasHeader(strings.TrimSpace(name)) {
+			continue
+		}
+		out = append(out, line)
+	}
+	for _, h := range p.Headers {
+		out = append(out, h.Name+": "+h.Value)
+	}
+	return strings.Join(out, "\r\n") + "\r\n\r\n" + body
+}
+
+func (p *ClientProfile) hasHeader(name string) bool {
+	for _, h := range p.Headers {
+		if strings.EqualFold(h.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuiltinProfiles returns the client profiles that ship with Gleip.
+func BuiltinProfiles() []*ClientProfile {
+	return []*ClientProfile{
+		{
+			ID:      "builtin-chrome",
+			Name:    "Chrome (Windows)",
+			Builtin: true,
+			Headers: []ProfileHeader{
+				{Name: "User-Agent", Value: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36"},
+				{Name: "Accept", Value: "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8"},
+				{Name: "Accept-Language", Value: "en-US,en;q=0.9"},
+				{Name: "Accept-Encoding", Value: "gzip, deflate"},
+				{Name: "sec-ch-ua", Value: `"Not/A)Brand";v="8", "Chromium";v="126", "Google Chrome";v="126"`},
+				{Name: "sec-ch-ua-mobile", Value: "?0"},
+				{Name: "sec-ch-ua-platform", Value: `"Windows"`},
+			},
+		},
+		{
+			ID:      "builtin-firefox",
+			Name:    "Firefox (Windows)",
+			Builtin: true,
+			Headers: []ProfileHeader{
+				{Name: "User-Agent", Value: "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:127.0) Gecko/20100101 Firefox/127.0"},
+				{Name: "Accept", Value: "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"},
+				{Name: "Accept-Language", Value: "en-US,en;q=0.5"},
+				{Name: "Accept-Encoding", Value: "gzip, deflate"},
+			},
+		},
+		{
+			ID:      "builtin-mobile",
+			Name:    "Chrome (Android)",
+			Builtin: true,
+			Headers: []ProfileHeader{
+				{Name: "User-Agent", Value: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36"},
+				{Name: "Accept", Value: "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8"},
+				{Name: "Accept-Language", Value: "en-US,en;q=0.9"},
+				{Name: "Accept-Encoding", Value: "gzip, deflate"},
+				{Name: "sec-ch-ua-mobile", Value: "?1"},
+				{Name: "sec-ch-ua-platform", Value: `"Android"`},
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package gleipflow
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// rawTimeout bounds a raw send end to end; raw sockets have no transport
+// machinery to time out for us.
+const rawTimeout = 30 * time.Second
+
+// SendRaw writes dump to host byte-for-byte and returns the response dump.
+// Nothing is normalized or added: what is in dump is what goes on the wire.
+// The response is parsed leniently; if it isn't valid HTTP, the raw bytes
+// read before close are returned instead.
+func (e *Executor) SendRaw(host string, useTLS bool, dump string) (string, error) {
+	return e.SendRawResolved(host, "", useTLS, dump)
+}
+
+// SendRawResolved is SendRaw with a per-request DNS override: when
+// resolveTo is set, the connection goes to that IP (optionally ip:port)
+// while TLS SNI and the dump's Host header keep the original hostname.
+func (e *Executor) SendRawResolved(host, resolveTo string, useTLS bool, dump string) (string, error) {
+	if host == "" {
+		return "", fmt.Errorf("raw send requires a target host")
+	}
+	addr := resolveTarget(host, resolveTo, useTLS)
+
+	ctx, cancel := context.WithTimeout(context.Background(), rawTimeout)
+	defer cancel()
+	var conn net.Conn
+	var err error
+	if e.hosts != nil {
+		conn, err = e.hosts.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(rawTimeout))
+
+	if useTLS {
+		cfg := e.transport.TLSClientConfig.Clone()
+		// SNI carries the original hostname even when dialing an override IP.
+		cfg.ServerName = host
+		if name, _, err := net.SplitHostPort(host); err == nil {
+			cfg.ServerName = name
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.Handshake(); err != nil {
+			return "", fmt.Errorf("TLS handshake with %s failed: %w", addr, err)
+		}
+		conn = tlsConn
+	}
+
+	if _, err := conn.Write([]byte(dump)); err != nil {
+		return "", fmt.Errorf("failed to write raw request: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		// Not parseable as HTTP; hand back whatever the server sent.
+		raw := readAvailable(reader)
+		if raw == "" {
+			return "", fmt.Errorf("failed to read response: %w", err)
+		}
+		return raw, nil
+	}
+	defer resp.Body.Close()
+	respDump, err := network.DumpResponse(resp)
+	if err != nil {
+		return "", err
+	}
+	return respDump, nil
+}
+
+// resolveTarget picks the dial address: resolveTo when set, host otherwise.
+// A missing port falls back to the port in host, then to the scheme
+// default.
+func resolveTarget(host, resolveTo string, useTLS bool) string {
+	port := "80"
+	if useTLS {
+		port = "443"
+	}
+	if _, p, err := net.SplitHostPort(host); err == nil {
+		port = p
+	}
+	addr := host
+	if resolveTo != "" {
+		addr = resolveTo
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, port)
+	}
+	return addr
+}
+
+// readAvailable drains what the server sent without insisting on framing.
+func readAvailable(reader *bufio.Reader) string {
+	buf := make([]byte, 64*1024)
+	var out []byte
+	for {
+		n, err := reader.Read(buf)
+		out = append(out, buf[:n]...)
+		if err != nil || n == 0 {
+			return string(out)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package gleipflow
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// SprayStrategy pairs usernames with passwords.
+type SprayStrategy string
+
+const (
+	// SprayPairwise tries username[i] with password[i].
+	SprayPairwise SprayStrategy = "pairwise"
+	// SpraySpray tries each password against every username before moving
+	// to the next password, the classic low-and-slow spraying order that
+	// maximizes per-account spacing.
+	SpraySpray SprayStrategy = "spray"
+)
+
+// SprayConfig drives a credential test against a login request step. The
+// step's dump must contain {{username}} and {{password}} placeholders.
+type SprayConfig struct {
+	Usernames []string      `json:"usernames"`
+	Passwords []string      `json:"passwords"`
+	Strategy  SprayStrategy `json:"strategy"`
+	// PerAccountDelaySeconds is the minimum spacing between attempts against
+	// the same account.
+	PerAccountDelaySeconds int `json:"perAccountDelaySeconds"`
+	// InvalidUserMarker and InvalidPasswordMarker are response substrings
+	// distinguishing the two failure modes (either may be empty).
+	InvalidUserMarker     string `json:"invalidUserMarker,omitempty"`
+	InvalidPasswordMarker string `json:"invalidPasswordMarker,omitempty"`
+	// LockoutMarker is an extra response substring treated as a lockout, on
+	// top of the built-in heuristics (423/429 status, "locked out" text).
+	LockoutMarker string `json:"lockoutMarker,omitempty"`
+}
+
+// SprayOutcome classifies one attempt.
+type SprayOutcome string
+
+const (
+	OutcomePossibleSuccess SprayOutcome = "possible-success"
+	OutcomeInvalidUser     SprayOutcome = "invalid-user"
+	OutcomeInvalidPassword SprayOutcome = "invalid-password"
+	OutcomeLockout         SprayOutcome = "lockout"
+	OutcomeError           SprayOutcome = "error"
+)
+
+// SprayAttempt is one credential pair's result.
+type SprayAttempt struct {
+	Username   string       `json:"username"`
+	Password   string       `json:"password"`
+	StatusCode int          `json:"statusCode"`
+	BodySize   int          `json:"bodySize"`
+	Outcome    SprayOutcome `json:"outcome"`
+	Note       string       `json:"note,omitempty"`
+	ExecutedAt time.Time    `json:"executedAt"`
+}
+
+// SprayResult is a full (possibly auto-paused) run.
+type SprayResult struct {
+	Attempts []SprayAttempt `json:"attempts"`
+	// Paused is set when lockout detection stopped the run early.
+	Paused      bool   `json:"paused"`
+	PauseReason string `json:"pauseReason,omitempty"`
+}
+
+// RunSpray executes the credential test. It stops immediately when a lockout
+// is detected, so one misconfigured run cannot lock out a whole directory.
+func (e *Executor) RunSpray(step *Step, cfg SprayConfig) (*SprayResult, error) {
+	if step.Type != StepRequest || step.Request == nil {
+		return nil, fmt.Errorf("step %q is not a request step", step.Name)
+	}
+	if !strings.Contains(step.Request.Dump, "{{") {
+		return nil, fmt.Errorf("step dump has no {{username}}/{{password}} placeholders")
+	}
+	if len(cfg.Usernames) == 0 || len(cfg.Passwords) == 0 {
+		return nil, fmt.Errorf("both username and password lists are required")
+	}
+
+	pairs, err := buildSprayPairs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	delay := time.Duration(cfg.PerAccountDelaySeconds) * time.Second
+	lastAttempt := make(map[string]time.Time)
+	result := &SprayResult{}
+	for _, pair := range pairs {
+		if wait := delay - time.Since(lastAttempt[pair[0]]); wait > 0 && !lastAttempt[pair[0]].IsZero() {
+			time.Sleep(wait)
+		}
+		attempt := e.sprayAttempt(step.Request, pair[0], pair[1], cfg)
+		lastAttempt[pair[0]] = attempt.ExecutedAt
+		result.Attempts = append(result.Attempts, attempt)
+		if attempt.Outcome == OutcomeLockout {
+			result.Paused = true
+			result.PauseReason = fmt.Sprintf("lockout indicators on account %q: %s", pair[0], attempt.Note)
+			break
+		}
+	}
+	return result, nil
+}
+
+func buildSprayPairs(cfg SprayConfig) ([][2]string, error) {
+	var pairs [][2]string
+	switch cfg.Strategy {
+	case SprayPairwise:
+		if len(cfg.Usernames) != len(cfg.Passwords) {
+			return nil, fmt.Errorf("pairwise strategy needs equal-length lists (%d users, %d passwords)", len(cfg.Usernames), len(cfg.Passwords))
+		}
+		for i := range cfg.Usernames {
+			pairs = append(pairs, [2]string{cfg.Usernames[i], cfg.Passwords[i]})
+		}
+	case SpraySpray, "":
+		for _, password := range cfg.Passwords {
+			for _, username := range cfg.Usernames {
+				pairs = append(pairs, [2]string{username, password})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown spray strategy %q", cfg.Strategy)
+	}
+	return pairs, nil
+}
+
+func (e *Executor) sprayAttempt(rs *RequestStep, username, password string, cfg SprayConfig) SprayAttempt {
+	attempt := SprayAttempt{Username: username, Password: password, ExecutedAt: time.Now()}
+	dump := SubstituteVariables(rs.Dump, map[string]string{"username": username, "password": password})
+
+	req, err := network.ParseRequestDump(dump)
+	if err != nil {
+		attempt.Outcome = OutcomeError
+		attempt.Note = err.Error()
+		return attempt
+	}
+	host := rs.Host
+	if host == "" {
+		host = req.Host
+	}
+	req.URL.Host = host
+	if rs.TLS {
+		req.URL.Scheme = "https"
+	} else {
+		req.URL.Scheme = "http"
+	}
+	req.RequestURI = ""
+
+	resp, err := e.transport.RoundTrip(req)
+	if err != nil {
+		attempt.Outcome = OutcomeError
+		attempt.Note = err.Error()
+		return attempt
+	}
+	respDump, err := network.DumpResponse(resp)
+	resp.Body.Close()
+	if err != nil {
+		attempt.Outcome = OutcomeError
+		attempt.Note = err.Error()
+		return attempt
+	}
+	_, body := network.SplitDump(respDump)
+	attempt.StatusCode = resp.StatusCode
+	attempt.BodySize = len(body)
+	attempt.Outcome, attempt.Note = classifySprayResponse(resp.StatusCode, body, cfg)
+	return attempt
+}
+
+func classifySprayResponse(status int, body string, cfg SprayConfig) (SprayOutcome, string) {
+	lower := strings.ToLower(body)
+	lockoutMarkers := []string{"locked out", "account locked", "too many attempts"}
+	if cfg.LockoutMarker != "" {
+		lockoutMarkers = append(lockoutMarkers, strings.ToLower(cfg.LockoutMarker))
+	}
+	if status == http.StatusLocked || status == http.StatusTooManyRequests {
+		return OutcomeLockout, fmt.Sprintf("status %d", status)
+	}
+	for _, marker := range lockoutMarkers {
+		if strings.Contains(lower, marker) {
+			return OutcomeLockout, fmt.Sprintf("body contains %q", marker)
+		}
+	}
+	if cfg.InvalidUserMarker != "" && strings.Contains(lower, strings.ToLower(cfg.InvalidUserMarker)) {
+		return OutcomeInvalidUser, ""
+	}
+	if cfg.InvalidPasswordMarker != "" && strings.Contains(lower, strings.ToLower(cfg.InvalidPasswordMarker)) {
+		return OutcomeInvalidPassword, ""
+	}
+	if status >= 200 && status < 400 {
+		return OutcomePossibleSuccess, fmt.Sprintf("status %d without failure markers", status)
+	}
+	return OutcomeInvalidPassword, fmt.Sprintf("status %d", status)
+}
@@ -0,0 +1,53 @@
+package gleipflow
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// totpPattern matches the {{totp "seedVar"}} template function; the argument
+// names a flow variable holding the base32 seed.
+var totpPattern = regexp.MustCompile(`\{\{\s*totp\s+"([A-Za-z0-9_.-]+)"\s*\}\}`)
+
+// TOTPCode computes the 6-digit RFC 6238 code for a base32 seed at time t,
+// with the standard 30-second step.
+func TOTPCode(secret string, t time.Time) (string, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(normalized, "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid base32 TOTP seed: %w", err)
+	}
+	counter := uint64(t.Unix() / 30)
+	mac := hmac.New(sha1.New, key)
+	binary.Write(mac, binary.BigEndian, counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0F
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7FFFFFFF
+	return fmt.Sprintf("%06d", code%1000000), nil
+}
+
+// ExpandTemplates substitutes {{variable}} placeholders and then evaluates
+// template functions like {{totp "seedVar"}}, so MFA-protected flows can run
+// unattended. Functions whose seed variable is missing or invalid are left
+// in place, matching how unknown variables behave.
+func ExpandTemplates(s string, vars map[string]string) string {
+	s = SubstituteVariables(s, vars)
+	return totpPattern.ReplaceAllStringFunc(s, func(match string) string {
+		seedVar := totpPattern.FindStringSubmatch(match)[1]
+		seed, ok := vars[seedVar]
+		if !ok {
+			return match
+		}
+		code, err := TOTPCode(seed, time.Now())
+		if err != nil {
+			return match
+		}
+		return code
+	})
+}
@@ -0,0 +1,69 @@
+package gleipflow
+
+import (
+	"fmt"
+	"time"
+
+	"gleip/backend/scope"
+)
+
+// FlowTrigger runs a flow automatically when matching traffic is observed
+// by the proxy — e.g. re-run the auth flow whenever a 401 comes back from
+// the target host.
+type FlowTrigger struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	FlowID  string `json:"flowId"`
+
+	// Match conditions; empty fields match anything.
+	HostPattern string `json:"hostPattern,omitempty"`
+	Method      string `json:"method,omitempty"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+
+	// CooldownSeconds suppresses re-firing for this long after each run, so
+	// a trigger can't loop on its own traffic.
+	CooldownSeconds int `json:"cooldownSeconds"`
+}
+
+// Validate checks the trigger before it is stored.
+func (t *FlowTrigger) Validate() error {
+	if t.FlowID == "" {
+		return fmt.Errorf("trigger must reference a flow")
+	}
+	if t.CooldownSeconds < 0 {
+		return fmt.Errorf("cooldown must not be negative")
+	}
+	return nil
+}
+
+// Matches reports whether an observed transaction should fire the trigger.
+func (t *FlowTrigger) Matches(method, host string, statusCode int) bool {
+	if !t.Enabled {
+		return false
+	}
+	if t.Method != "" && t.Method != method {
+		return false
+	}
+	if t.StatusCode != 0 && t.StatusCode != statusCode {
+		return false
+	}
+	if t.HostPattern != "" && !scope.MatchGlob(t.HostPattern, stripPort(host)) {
+		return false
+	}
+	return true
+}
+
+// Cooldown returns the trigger's cooldown as a duration.
+func (t *FlowTrigger) Cooldown() time.Duration {
+	return time.Duration(t.CooldownSeconds) * time.Second
+}
+
+func stripPort(host string) string {
+	for i := 0; i < len(host); i++ {
+		if host[i] == ':' {
+			return host[:i]
+		}
+	}
+	return host
+}
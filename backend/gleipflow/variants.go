@@ -0,0 +1,114 @@
+package gleipflow
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gleip/backend/network"
+)
+
+// VariantOption is one choice within a dimension: a label plus the header
+// and query-parameter overrides it applies to the cloned step.
+type VariantOption struct {
+	Label   string            `json:"label"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Params  map[string]string `json:"params,omitempty"`
+}
+
+// VariantDimension is one axis of the variant matrix (e.g. "content type"
+// with three options, "auth" with two).
+type VariantDimension struct {
+	Name    string          `json:"name"`
+	Options []VariantOption `json:"options"`
+}
+
+// BuildVariants clones a request step across the cartesian product of the
+// matrix dimensions, returning one labeled step per combination. The
+// original step is not modified.
+func BuildVariants(step *Step, matrix []VariantDimension) ([]*Step, error) {
+	if step.Type != StepRequest || step.Request == nil {
+		return nil, fmt.Errorf("step %q is not a request step", step.Name)
+	}
+	for _, dim := range matrix {
+		if len(dim.Options) == 0 {
+			return nil, fmt.Errorf("variant dimension %q has no options", dim.Name)
+		}
+	}
+	combos := [][]VariantOption{nil}
+	for _, dim := range matrix {
+		var next [][]VariantOption
+		for _, combo := range combos {
+			for _, opt := range dim.Options {
+				extended := append(append([]VariantOption(nil), combo...), opt)
+				next = append(next, extended)
+			}
+		}
+		combos = next
+	}
+
+	var steps []*Step
+	for _, combo := range combos {
+		dump := step.Request.Dump
+		var labels []string
+		for _, opt := range combo {
+			labels = append(labels, opt.Label)
+			var err error
+			dump, err = applyVariant(dump, opt)
+			if err != nil {
+				return nil, err
+			}
+		}
+		clone := &Step{
+			ID:   network.NewID(),
+			Type: StepRequest,
+			Name: step.Name + " [" + strings.Join(labels, ", ") + "]",
+			Request: &RequestStep{
+				Dump:            dump,
+				Host:            step.Request.Host,
+				TLS:             step.Request.TLS,
+				ClientProfileID: step.Request.ClientProfileID,
+			},
+		}
+		steps = append(steps, clone)
+	}
+	return steps, nil
+}
+
+func applyVariant(dump string, opt VariantOption) (string, error) {
+	headers, body := network.SplitDump(dump)
+	lines := strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")
+
+	for name, value := range opt.Headers {
+		replaced := false
+		for i, line := range lines[1:] {
+			if strings.HasPrefix(strings.ToLower(line), strings.ToLower(name)+":") {
+				lines[i+1] = name + ": " + value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			lines = append(lines, name+": "+value)
+		}
+	}
+
+	if len(opt.Params) > 0 {
+		parts := strings.SplitN(lines[0], " ", 3)
+		if len(parts) != 3 {
+			return "", fmt.Errorf("malformed request line %q", lines[0])
+		}
+		target, err := url.ParseRequestURI(parts[1])
+		if err != nil {
+			return "", fmt.Errorf("failed to parse request target: %w", err)
+		}
+		query := target.Query()
+		for key, value := range opt.Params {
+			query.Set(key, value)
+		}
+		target.RawQuery = query.Encode()
+		lines[0] = parts[0] + " " + target.String() + " " + parts[2]
+	}
+
+	return strings.Join(lines, "\r\n") + "\r\n\r\n" + body, nil
+}
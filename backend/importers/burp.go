@@ -0,0 +1,126 @@
+package importers
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// burpTimeLayout matches the timestamps in Burp's item export
+// ("Wed Mar 15 10:20:30 CET 2023").
+const burpTimeLayout = "Mon Jan 02 15:04:05 MST 2006"
+
+// burpItems mirrors the root of Burp Suite's "Save items" XML export.
+type burpItems struct {
+	XMLName xml.Name   `xml:"items"`
+	Items   []burpItem `xml:"item"`
+}
+
+type burpItem struct {
+	Time     string      `xml:"time"`
+	URL      string      `xml:"url"`
+	Host     string      `xml:"host"`
+	Protocol string      `xml:"protocol"`
+	Method   string      `xml:"method"`
+	Request  burpMessage `xml:"request"`
+	Response burpMessage `xml:"response"`
+}
+
+// burpMessage is a request or response payload, base64-encoded when the
+// element's base64 attribute is "true".
+type burpMessage struct {
+	Base64 string `xml:"base64,attr"`
+	Data   string `xml:",chardata"`
+}
+
+func (m burpMessage) decode() (string, error) {
+	if m.Base64 == "true" {
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(m.Data))
+		if err != nil {
+			return "", fmt.Errorf("failed to decode base64 payload: %w", err)
+		}
+		return string(raw), nil
+	}
+	return m.Data, nil
+}
+
+// ImportBurpItems parses a Burp Suite XML item export into transactions so
+// existing engagements can be migrated. Items whose request fails to parse
+// are skipped and reported; .burp project files are a proprietary binary
+// format and are rejected with a pointer to the XML export.
+func ImportBurpItems(path string) (txs []*network.HTTPTransaction, skipped []string, err error) {
+	if strings.EqualFold(filepath.Ext(path), ".burp") {
+		return nil, nil, fmt.Errorf(".burp project files are a proprietary binary format; use Burp's \"Save items\" XML export instead")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read Burp export: %w", err)
+	}
+	var doc burpItems
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Burp export: %w", err)
+	}
+
+	for i, item := range doc.Items {
+		label := fmt.Sprintf("item %d", i+1)
+		if item.URL != "" {
+			label += " (" + item.URL + ")"
+		}
+		reqDump, err := item.Request.decode()
+		if err != nil || strings.TrimSpace(reqDump) == "" {
+			skipped = append(skipped, label)
+			continue
+		}
+		req, err := network.ParseRequestDump(reqDump)
+		if err != nil {
+			skipped = append(skipped, label)
+			continue
+		}
+		host := req.Host
+		if host == "" {
+			host = item.Host
+		}
+		fullURL := item.URL
+		if fullURL == "" {
+			fullURL = req.URL.String()
+		}
+		timestamp := time.Now()
+		if t, err := time.Parse(burpTimeLayout, item.Time); err == nil {
+			timestamp = t
+		}
+		tx := &network.HTTPTransaction{
+			ID:        network.NewID(),
+			Timestamp: timestamp,
+			Request: network.HTTPRequest{
+				Dump:   reqDump,
+				Method: req.Method,
+				Host:   host,
+				Path:   req.URL.Path,
+				URL:    fullURL,
+				TLS:    strings.EqualFold(item.Protocol, "https"),
+			},
+			Tags: []string{"imported", "burp"},
+		}
+		if respDump, err := item.Response.decode(); err == nil && strings.TrimSpace(respDump) != "" {
+			if resp, err := network.ParseResponseDump(respDump); err == nil {
+				_, body := network.SplitDump(respDump)
+				tx.Response = &network.HTTPResponse{
+					Dump:       respDump,
+					Status:     resp.Status,
+					StatusCode: resp.StatusCode,
+					BodySize:   len(body),
+				}
+			} else {
+				skipped = append(skipped, label+" response")
+			}
+		}
+		txs = append(txs, tx)
+	}
+	return txs, skipped, nil
+}
@@ -0,0 +1,240 @@
+package importers
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// ImportMitmproxyFlows parses a mitmproxy dump file (.mitm/.flow) into
+// transactions. Dumps are a sequence of tnetstring-encoded flow dicts;
+// non-HTTP flows (TCP, DNS) and flows that fail to reconstruct are skipped
+// and reported.
+func ImportMitmproxyFlows(path string) (txs []*network.HTTPTransaction, skipped []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read mitmproxy dump: %w", err)
+	}
+
+	pos := 0
+	index := 0
+	for pos < len(data) {
+		val, next, err := decodeTnetstring(data, pos)
+		if err != nil {
+			if index == 0 {
+				return nil, nil, fmt.Errorf("failed to parse mitmproxy dump: %w", err)
+			}
+			skipped = append(skipped, fmt.Sprintf("flow %d (truncated)", index+1))
+			break
+		}
+		pos = next
+		index++
+		flow, ok := val.(map[string]interface{})
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("flow %d", index))
+			continue
+		}
+		if typ, _ := flow["type"].(string); typ != "" && typ != "http" {
+			skipped = append(skipped, fmt.Sprintf("flow %d (%s)", index, typ))
+			continue
+		}
+		tx, ok := transactionFromMitmFlow(flow)
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("flow %d", index))
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	return txs, skipped, nil
+}
+
+// transactionFromMitmFlow rebuilds raw dumps from a decoded flow dict and
+// converts them to a transaction.
+func transactionFromMitmFlow(flow map[string]interface{}) (*network.HTTPTransaction, bool) {
+	reqDict, ok := flow["request"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	method := mitmString(reqDict, "method")
+	reqPath := mitmString(reqDict, "path")
+	version := mitmString(reqDict, "http_version")
+	if method == "" || reqPath == "" {
+		return nil, false
+	}
+	if version == "" {
+		version = "HTTP/1.1"
+	}
+	var b strings.Builder
+	b.WriteString(method + " " + reqPath + " " + version + "\r\n")
+	hasHost := false
+	for _, h := range mitmHeaders(reqDict) {
+		if strings.EqualFold(h[0], "Host") {
+			hasHost = true
+		}
+		b.WriteString(h[0] + ": " + h[1] + "\r\n")
+	}
+	if !hasHost {
+		if host := mitmString(reqDict, "host"); host != "" {
+			b.WriteString("Host: " + host + "\r\n")
+		}
+	}
+	b.WriteString("\r\n")
+	b.WriteString(mitmString(reqDict, "content"))
+	reqDump := b.String()
+
+	req, err := network.ParseRequestDump(reqDump)
+	if err != nil {
+		return nil, false
+	}
+	timestamp := time.Now()
+	if ts, ok := reqDict["timestamp_start"].(float64); ok && ts > 0 {
+		timestamp = time.Unix(int64(ts), int64((ts-float64(int64(ts)))*float64(time.Second)))
+	}
+	tx := &network.HTTPTransaction{
+		ID:        network.NewID(),
+		Timestamp: timestamp,
+		Request: network.HTTPRequest{
+			Dump:   reqDump,
+			Method: req.Method,
+			Host:   req.Host,
+			Path:   req.URL.Path,
+			URL:    req.URL.String(),
+			TLS:    mitmString(reqDict, "scheme") == "https",
+		},
+		Tags: []string{"imported", "mitmproxy"},
+	}
+
+	if respDict, ok := flow["response"].(map[string]interface{}); ok {
+		status := mitmInt(respDict, "status_code")
+		respVersion := mitmString(respDict, "http_version")
+		if respVersion == "" {
+			respVersion = version
+		}
+		var rb strings.Builder
+		rb.WriteString(fmt.Sprintf("%s %d %s\r\n", respVersion, status, mitmString(respDict, "reason")))
+		for _, h := range mitmHeaders(respDict) {
+			rb.WriteString(h[0] + ": " + h[1] + "\r\n")
+		}
+		rb.WriteString("\r\n")
+		rb.WriteString(mitmString(respDict, "content"))
+		respDump := rb.String()
+		if resp, err := network.ParseResponseDump(respDump); err == nil {
+			_, body := network.SplitDump(respDump)
+			tx.Response = &network.HTTPResponse{
+				Dump:       respDump,
+				Status:     resp.Status,
+				StatusCode: resp.StatusCode,
+				BodySize:   len(body),
+			}
+		}
+	}
+	return tx, true
+}
+
+func mitmString(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+func mitmInt(m map[string]interface{}, key string) int {
+	n, _ := m[key].(int64)
+	return int(n)
+}
+
+// mitmHeaders extracts a flow message's header list ([[name, value], ...]).
+func mitmHeaders(m map[string]interface{}) [][2]string {
+	list, _ := m["headers"].([]interface{})
+	out := make([][2]string, 0, len(list))
+	for _, entry := range list {
+		pair, ok := entry.([]interface{})
+		if !ok || len(pair) != 2 {
+			continue
+		}
+		name, _ := pair[0].(string)
+		value, _ := pair[1].(string)
+		if name == "" {
+			continue
+		}
+		out = append(out, [2]string{name, value})
+	}
+	return out
+}
+
+// decodeTnetstring decodes one tnetstring value ("LEN:PAYLOAD T") starting at
+// pos and returns the value and the offset of the next one. Strings decode to
+// string, integers to int64, floats to float64, dicts to
+// map[string]interface{}, lists to []interface{}.
+func decodeTnetstring(data []byte, pos int) (interface{}, int, error) {
+	colon := bytes.IndexByte(data[pos:], ':')
+	if colon < 0 || colon > 10 {
+		return nil, 0, fmt.Errorf("missing length prefix at offset %d", pos)
+	}
+	length, err := strconv.Atoi(string(data[pos : pos+colon]))
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid length prefix at offset %d: %w", pos, err)
+	}
+	start := pos + colon + 1
+	end := start + length
+	if end >= len(data) {
+		return nil, 0, fmt.Errorf("truncated value at offset %d", pos)
+	}
+	payload := data[start:end]
+	next := end + 1
+	switch data[end] {
+	case ',':
+		return string(payload), next, nil
+	case '#':
+		n, err := strconv.ParseInt(string(payload), 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid integer at offset %d: %w", start, err)
+		}
+		return n, next, nil
+	case '^':
+		f, err := strconv.ParseFloat(string(payload), 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid float at offset %d: %w", start, err)
+		}
+		return f, next, nil
+	case '!':
+		return string(payload) == "true", next, nil
+	case '~':
+		return nil, next, nil
+	case ']':
+		var list []interface{}
+		for p := 0; p < len(payload); {
+			val, np, err := decodeTnetstring(payload, p)
+			if err != nil {
+				return nil, 0, err
+			}
+			list = append(list, val)
+			p = np
+		}
+		return list, next, nil
+	case '}':
+		dict := make(map[string]interface{})
+		for p := 0; p < len(payload); {
+			keyVal, np, err := decodeTnetstring(payload, p)
+			if err != nil {
+				return nil, 0, err
+			}
+			key, ok := keyVal.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("non-string dict key at offset %d", start+p)
+			}
+			val, np2, err := decodeTnetstring(payload, np)
+			if err != nil {
+				return nil, 0, err
+			}
+			dict[key] = val
+			p = np2
+		}
+		return dict, next, nil
+	default:
+		return nil, 0, fmt.Errorf("unknown tnetstring type %q at offset %d", data[end], end)
+	}
+}
@@ -0,0 +1,221 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// APIEndpoint is one operation from an imported API description, rendered as
+// an editable request dump.
+type APIEndpoint struct {
+	ID          string `json:"id"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Dump        string `json:"dump"`
+	// Overridden marks endpoints whose dump the user edited; refreshes keep
+	// the edited dump instead of the regenerated one.
+	Overridden bool `json:"overridden,omitempty"`
+}
+
+// Key identifies the endpoint across refreshes.
+func (e *APIEndpoint) Key() string {
+	return e.Method + " " + e.Path
+}
+
+// APICollection is an imported API description: its endpoints plus the
+// source needed to refresh it.
+type APICollection struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// SourceURL and SourceHeaders (e.g. auth) are kept so the collection can
+	// be re-fetched.
+	SourceHeaders map[string]string `json:"sourceHeaders,omitempty"`
+	SourceURL     string            `json:"sourceUrl,omitempty"`
+	BaseURL       string            `json:"baseUrl,omitempty"`
+	// SecurityValues holds user-entered secrets (API keys, tokens) applied
+	// when endpoints are sent; they survive refreshes.
+	SecurityValues map[string]string `json:"securityValues,omitempty"`
+	Endpoints      []*APIEndpoint    `json:"endpoints,omitempty"`
+	FetchedAt      time.Time         `json:"fetchedAt"`
+}
+
+// FetchOpenAPI retrieves an API description from a URL, sending the given
+// headers (typically authentication) with the request.
+func FetchOpenAPI(sourceURL string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI URL: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenAPI document: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenAPI URL returned %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+	return data, nil
+}
+
+// openAPIDoc mirrors the subset of OpenAPI 3 (and Swagger 2) needed to
+// enumerate operations. Only JSON documents are supported.
+type openAPIDoc struct {
+	Info struct {
+		Title string `json:"title"`
+	} `json:"info"`
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Host       string                                `json:"host"`     // Swagger 2
+	BasePath   string                                `json:"basePath"` // Swagger 2
+	Schemes    []string                              `json:"schemes"`  // Swagger 2
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]json.RawMessage `json:"schemas"`
+	} `json:"components"`
+	Definitions map[string]json.RawMessage `json:"definitions"` // Swagger 2
+}
+
+type openAPIContent struct {
+	Schema  json.RawMessage `json:"schema"`
+	Example json.RawMessage `json:"example"`
+}
+
+type openAPIOperation struct {
+	OperationID string `json:"operationId"`
+	Summary     string `json:"summary"`
+	RequestBody *struct {
+		Content map[string]openAPIContent `json:"content"`
+	} `json:"requestBody"`
+}
+
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// ParseOpenAPI parses a JSON OpenAPI/Swagger document into a collection
+// skeleton (no ID; the caller assigns one).
+func ParseOpenAPI(data []byte) (*APICollection, error) {
+	var doc openAPIDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document (only JSON is supported): %w", err)
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("OpenAPI document defines no paths")
+	}
+	col := &APICollection{Name: doc.Info.Title, FetchedAt: time.Now()}
+	if col.Name == "" {
+		col.Name = "Imported API"
+	}
+	col.BaseURL = openAPIBaseURL(doc)
+
+	host, basePath := "example.com", ""
+	if u, err := url.Parse(col.BaseURL); err == nil && u.Host != "" {
+		host = u.Host
+		basePath = strings.TrimSuffix(u.Path, "/")
+	}
+
+	resolver := &schemaResolver{schemas: doc.Components.Schemas}
+	if len(resolver.schemas) == 0 {
+		resolver.schemas = doc.Definitions
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		for _, method := range openAPIMethods {
+			raw, ok := doc.Paths[p][method]
+			if !ok {
+				continue
+			}
+			var op openAPIOperation
+			json.Unmarshal(raw, &op)
+			endpoint := &APIEndpoint{
+				Method:      strings.ToUpper(method),
+				Path:        basePath + p,
+				OperationID: op.OperationID,
+				Summary:     op.Summary,
+			}
+			endpoint.Dump = buildEndpointDump(endpoint, host, &op, resolver)
+			col.Endpoints = append(col.Endpoints, endpoint)
+		}
+	}
+	return col, nil
+}
+
+func openAPIBaseURL(doc openAPIDoc) string {
+	if len(doc.Servers) > 0 {
+		return doc.Servers[0].URL
+	}
+	if doc.Host != "" {
+		scheme := "https"
+		if len(doc.Schemes) > 0 {
+			scheme = doc.Schemes[0]
+		}
+		return scheme + "://" + doc.Host + doc.BasePath
+	}
+	return ""
+}
+
+// buildEndpointDump renders a skeleton request for an operation. Path
+// parameters stay in {braces} so the user (or variable substitution) fills
+// them in. JSON bodies use the spec's example when present and are
+// generated from the schema otherwise.
+func buildEndpointDump(e *APIEndpoint, host string, op *openAPIOperation, resolver *schemaResolver) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s HTTP/1.1\r\n", e.Method, e.Path)
+	fmt.Fprintf(&b, "Host: %s\r\n", host)
+	body := ""
+	if op.RequestBody != nil {
+		contentType := "application/json"
+		var content openAPIContent
+		for ct, c := range op.RequestBody.Content {
+			if strings.Contains(ct, "json") {
+				contentType, content = ct, c
+				break
+			}
+			contentType, content = ct, c
+		}
+		fmt.Fprintf(&b, "Content-Type: %s\r\n", contentType)
+		if strings.Contains(contentType, "json") {
+			body = exampleBody(content, resolver)
+		}
+	}
+	fmt.Fprintf(&b, "Content-Length: %d\r\n", len(body))
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return b.String()
+}
+
+// exampleBody renders the request body example: the explicit example when
+// the spec has one, a schema-generated one otherwise.
+func exampleBody(content openAPIContent, resolver *schemaResolver) string {
+	if len(content.Example) > 0 {
+		return string(content.Example)
+	}
+	value := generateExample(content.Schema, resolver, 0)
+	if value == nil {
+		return "{}"
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
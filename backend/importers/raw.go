@@ -0,0 +1,101 @@
+// Package importers ingests traffic captured outside Gleip (raw dumps,
+// other tools' exports) into the transaction model.
+package importers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// rawExtensions are the file types scanned by ImportRawTransactions.
+// .req/.txt files are parsed as requests; a .res file with the same base
+// name supplies the matching response.
+var rawExtensions = map[string]bool{".txt": true, ".req": true, ".res": true}
+
+// ImportRawTransactions scans a directory for raw HTTP message files and
+// converts them into transactions. Timestamps are synthetic, taken from file
+// modification times so imported evidence sorts roughly where it belongs.
+// Files that fail to parse are skipped and reported in skipped.
+func ImportRawTransactions(dir string) (txs []*network.HTTPTransaction, skipped []string, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read import directory: %w", err)
+	}
+
+	responses := make(map[string]string) // base name -> response dump
+	type rawRequest struct {
+		base    string
+		dump    string
+		modTime time.Time
+	}
+	var requests []rawRequest
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !rawExtensions[ext] {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			skipped = append(skipped, entry.Name())
+			continue
+		}
+		base := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if ext == ".res" {
+			responses[base] = string(data)
+			continue
+		}
+		info, err := entry.Info()
+		modTime := time.Now()
+		if err == nil {
+			modTime = info.ModTime()
+		}
+		requests = append(requests, rawRequest{base: base, dump: string(data), modTime: modTime})
+	}
+
+	sort.Slice(requests, func(i, j int) bool { return requests[i].modTime.Before(requests[j].modTime) })
+
+	for _, raw := range requests {
+		req, err := network.ParseRequestDump(raw.dump)
+		if err != nil {
+			skipped = append(skipped, raw.base)
+			continue
+		}
+		tx := &network.HTTPTransaction{
+			ID:        network.NewID(),
+			Timestamp: raw.modTime,
+			Request: network.HTTPRequest{
+				Dump:   raw.dump,
+				Method: req.Method,
+				Host:   req.Host,
+				Path:   req.URL.Path,
+				URL:    req.URL.String(),
+			},
+			Tags: []string{"imported"},
+		}
+		if respDump, ok := responses[raw.base]; ok {
+			if resp, err := network.ParseResponseDump(respDump); err == nil {
+				_, body := network.SplitDump(respDump)
+				tx.Response = &network.HTTPResponse{
+					Dump:       respDump,
+					Status:     resp.Status,
+					StatusCode: resp.StatusCode,
+					BodySize:   len(body),
+				}
+			} else {
+				skipped = append(skipped, raw.base+".res")
+			}
+		}
+		txs = append(txs, tx)
+	}
+	return txs, skipped, nil
+}
@@ -0,0 +1,155 @@
+package importers
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Example generation from JSON Schema: most real specs carry no explicit
+// examples, so imported requests would have empty bodies. The generator
+// walks the schema, filling type-appropriate placeholders, first enum
+// values, and nested objects/arrays.
+
+// maxExampleDepth caps recursion so self-referential schemas terminate.
+const maxExampleDepth = 6
+
+// schemaResolver resolves local $ref pointers against the document's
+// component/definition schemas.
+type schemaResolver struct {
+	schemas map[string]json.RawMessage
+}
+
+// resolve returns the schema a local $ref points to, or nil.
+func (r *schemaResolver) resolve(ref string) json.RawMessage {
+	if r == nil {
+		return nil
+	}
+	name := ref
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return r.schemas[name]
+}
+
+// jsonSchema mirrors the subset of JSON Schema used for example generation.
+type jsonSchema struct {
+	Ref        string                     `json:"$ref"`
+	Type       string                     `json:"type"`
+	Format     string                     `json:"format"`
+	Enum       []json.RawMessage          `json:"enum"`
+	Example    json.RawMessage            `json:"example"`
+	Default    json.RawMessage            `json:"default"`
+	Properties map[string]json.RawMessage `json:"properties"`
+	Required   []string                   `json:"required"`
+	Items      json.RawMessage            `json:"items"`
+	AllOf      []json.RawMessage          `json:"allOf"`
+	OneOf      []json.RawMessage          `json:"oneOf"`
+	AnyOf      []json.RawMessage          `json:"anyOf"`
+}
+
+// generateExample produces an example value for a schema. Explicit examples
+// and defaults win; otherwise the value is synthesized from the type.
+func generateExample(raw json.RawMessage, resolver *schemaResolver, depth int) any {
+	if len(raw) == 0 || depth > maxExampleDepth {
+		return nil
+	}
+	var schema jsonSchema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		return generateExample(resolver.resolve(schema.Ref), resolver, depth+1)
+	}
+	if len(schema.Example) > 0 {
+		return rawValue(schema.Example)
+	}
+	if len(schema.Default) > 0 {
+		return rawValue(schema.Default)
+	}
+	if len(schema.Enum) > 0 {
+		return rawValue(schema.Enum[0])
+	}
+	if len(schema.AllOf) > 0 {
+		// Merge object members of allOf; good enough for composed models.
+		merged := make(map[string]any)
+		for _, sub := range schema.AllOf {
+			if m, ok := generateExample(sub, resolver, depth+1).(map[string]any); ok {
+				for k, v := range m {
+					merged[k] = v
+				}
+			}
+		}
+		if len(merged) > 0 {
+			return merged
+		}
+	}
+	if len(schema.OneOf) > 0 {
+		return generateExample(schema.OneOf[0], resolver, depth+1)
+	}
+	if len(schema.AnyOf) > 0 {
+		return generateExample(schema.AnyOf[0], resolver, depth+1)
+	}
+
+	switch schema.Type {
+	case "object", "":
+		if len(schema.Properties) == 0 {
+			if schema.Type == "object" {
+				return map[string]any{}
+			}
+			return nil
+		}
+		out := make(map[string]any, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			out[name] = generateExample(prop, resolver, depth+1)
+		}
+		return out
+	case "array":
+		item := generateExample(schema.Items, resolver, depth+1)
+		if item == nil {
+			return []any{}
+		}
+		return []any{item}
+	case "string":
+		return stringPlaceholder(schema.Format)
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return true
+	case "null":
+		return nil
+	}
+	return nil
+}
+
+// stringPlaceholder picks a format-appropriate string value.
+func stringPlaceholder(format string) string {
+	switch format {
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "date":
+		return "2024-01-01"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "uri", "url":
+		return "https://example.com/"
+	case "ipv4":
+		return "127.0.0.1"
+	case "byte":
+		return "ZXhhbXBsZQ=="
+	case "password":
+		return "password"
+	}
+	return "string"
+}
+
+func rawValue(raw json.RawMessage) any {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	return v
+}
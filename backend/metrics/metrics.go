@@ -0,0 +1,118 @@
+// Package metrics collects counters and gauges from the running subsystems
+// and renders them in the Prometheus text exposition format, so long-running
+// sessions can be watched with standard tooling.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Collector aggregates metric samples. All methods are safe for concurrent
+// use; gauge callbacks are evaluated at scrape time.
+type Collector struct {
+	mu       sync.Mutex
+	counters map[string]float64
+	gauges   map[string]func() float64
+	help     map[string]string
+}
+
+// NewCollector returns an empty collector.
+func NewCollector() *Collector {
+	return &Collector{
+		counters: make(map[string]float64),
+		gauges:   make(map[string]func() float64),
+		help:     make(map[string]string),
+	}
+}
+
+// Help registers the HELP text emitted for a metric name.
+func (c *Collector) Help(name, text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.help[name] = text
+}
+
+// Add increments the counter identified by name and labels by v.
+func (c *Collector) Add(name string, labels map[string]string, v float64) {
+	key := sampleKey(name, labels)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counters[key] += v
+}
+
+// Inc increments a counter by one.
+func (c *Collector) Inc(name string, labels map[string]string) {
+	c.Add(name, labels, 1)
+}
+
+// Observe records one observation of a duration-style metric, maintaining
+// Prometheus summary conventions: name_sum accumulates v and name_count the
+// number of observations.
+func (c *Collector) Observe(name string, labels map[string]string, v float64) {
+	c.Add(name+"_sum", labels, v)
+	c.Add(name+"_count", labels, 1)
+}
+
+// Gauge registers a callback sampled at scrape time for the given name and
+// labels.
+func (c *Collector) Gauge(name string, labels map[string]string, fn func() float64) {
+	key := sampleKey(name, labels)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gauges[key] = fn
+}
+
+// Render writes every sample in the text exposition format, sorted by
+// name so scrapes are stable.
+func (c *Collector) Render(w io.Writer) {
+	c.mu.Lock()
+	lines := make([]string, 0, len(c.counters)+len(c.gauges))
+	for key, v := range c.counters {
+		lines = append(lines, fmt.Sprintf("%s %g", key, v))
+	}
+	for key, fn := range c.gauges {
+		lines = append(lines, fmt.Sprintf("%s %g", key, fn()))
+	}
+	helps := make([]string, 0, len(c.help))
+	for name, text := range c.help {
+		helps = append(helps, fmt.Sprintf("# HELP %s %s", name, text))
+	}
+	c.mu.Unlock()
+
+	sort.Strings(helps)
+	sort.Strings(lines)
+	for _, line := range helps {
+		fmt.Fprintln(w, line)
+	}
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
+
+// sampleKey renders name plus a sorted label set, e.g.
+// gleip_requests_total{host="example.com"}.
+func sampleKey(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%q", k, labels[k])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// Server exposes a collector on /metrics. Like the automation API it only
+// ever binds to loopback.
+type Server struct {
+	mu        sync.Mutex
+	addr      string
+	collector *Collector
+	listener  net.Listener
+}
+
+// NewServer creates a metrics server for collector bound to addr.
+func NewServer(addr string, collector *Collector) *Server {
+	return &Server{addr: addr, collector: collector}
+}
+
+// Start begins serving /metrics. It fails rather than bind to a
+// non-loopback address.
+func (s *Server) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener != nil {
+		return fmt.Errorf("metrics server already running on %s", s.addr)
+	}
+	host, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return fmt.Errorf("invalid metrics address %q: %w", s.addr, err)
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("metrics server must bind to loopback, got %q", host)
+	}
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to bind metrics server on %s: %w", s.addr, err)
+	}
+	s.listener = listener
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		s.collector.Render(w)
+	})
+	go http.Serve(listener, mux)
+	return nil
+}
+
+// Stop closes the listener if running.
+func (s *Server) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	s.listener = nil
+	return err
+}
@@ -0,0 +1,158 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// codeRequest is a request dump broken down into the parts code templates
+// need: skip-listed headers are already removed and the target URL resolved.
+type codeRequest struct {
+	Method  string
+	URL     string
+	Headers [][2]string
+	Body    string
+}
+
+// GenerateCodeSnippet renders a raw request dump as reproduction code in the
+// given language: "python" (requests), "go" (net/http), or "javascript"
+// (fetch). Host resolution follows CurlCommand: the dump's Host header wins
+// over the host argument.
+func GenerateCodeSnippet(dump, host string, useTLS bool, language string) (string, error) {
+	req, err := parseCodeRequest(dump, host, useTLS)
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(language) {
+	case "python":
+		return pythonSnippet(req), nil
+	case "go":
+		return goSnippet(req), nil
+	case "javascript", "js":
+		return javascriptSnippet(req), nil
+	default:
+		return "", fmt.Errorf("unsupported language %q (use python, go, or javascript)", language)
+	}
+}
+
+// parseCodeRequest extracts method, URL, headers, and body from a dump.
+func parseCodeRequest(dump, host string, useTLS bool) (*codeRequest, error) {
+	headers, body := SplitDump(dump)
+	lines := strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")
+	fields := strings.Fields(lines[0])
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("malformed request line %q", lines[0])
+	}
+	req := &codeRequest{Method: fields[0], Body: body}
+	target := fields[1]
+	for _, line := range lines[1:] {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(name) {
+		case "host":
+			if value != "" {
+				host = value
+			}
+			continue
+		case "content-length":
+			// Every client library computes this from the body.
+			continue
+		}
+		req.Headers = append(req.Headers, [2]string{name, value})
+	}
+	req.URL = target
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		if host == "" {
+			return nil, fmt.Errorf("request has no host")
+		}
+		scheme := "http"
+		if useTLS {
+			scheme = "https"
+		}
+		req.URL = scheme + "://" + host + target
+	}
+	return req, nil
+}
+
+func pythonSnippet(req *codeRequest) string {
+	var b strings.Builder
+	b.WriteString("import requests\n\n")
+	b.WriteString("url = " + pyQuote(req.URL) + "\n")
+	b.WriteString("headers = {\n")
+	for _, h := range req.Headers {
+		b.WriteString("    " + pyQuote(h[0]) + ": " + pyQuote(h[1]) + ",\n")
+	}
+	b.WriteString("}\n")
+	args := "url, headers=headers"
+	if req.Body != "" {
+		b.WriteString("data = " + pyQuote(req.Body) + "\n")
+		args += ", data=data"
+	}
+	b.WriteString("\nresponse = requests.request(" + pyQuote(req.Method) + ", " + args + ")\n")
+	b.WriteString("print(response.status_code)\n")
+	b.WriteString("print(response.text)\n")
+	return b.String()
+}
+
+func goSnippet(req *codeRequest) string {
+	var b strings.Builder
+	b.WriteString("package main\n\n")
+	b.WriteString("import (\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n")
+	if req.Body != "" {
+		b.WriteString("\t\"strings\"\n")
+	}
+	b.WriteString(")\n\n")
+	b.WriteString("func main() {\n")
+	bodyArg := "nil"
+	if req.Body != "" {
+		b.WriteString("\tbody := strings.NewReader(" + strconv.Quote(req.Body) + ")\n")
+		bodyArg = "body"
+	}
+	b.WriteString("\treq, err := http.NewRequest(" + strconv.Quote(req.Method) + ", " + strconv.Quote(req.URL) + ", " + bodyArg + ")\n")
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	for _, h := range req.Headers {
+		b.WriteString("\treq.Header.Set(" + strconv.Quote(h[0]) + ", " + strconv.Quote(h[1]) + ")\n")
+	}
+	b.WriteString("\n\tresp, err := http.DefaultClient.Do(req)\n")
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	b.WriteString("\tdefer resp.Body.Close()\n")
+	b.WriteString("\tdata, _ := io.ReadAll(resp.Body)\n")
+	b.WriteString("\tfmt.Println(resp.Status)\n")
+	b.WriteString("\tfmt.Println(string(data))\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func javascriptSnippet(req *codeRequest) string {
+	var b strings.Builder
+	b.WriteString("const response = await fetch(" + jsQuote(req.URL) + ", {\n")
+	b.WriteString("  method: " + jsQuote(req.Method) + ",\n")
+	b.WriteString("  headers: {\n")
+	for _, h := range req.Headers {
+		b.WriteString("    " + jsQuote(h[0]) + ": " + jsQuote(h[1]) + ",\n")
+	}
+	b.WriteString("  },\n")
+	if req.Body != "" {
+		b.WriteString("  body: " + jsQuote(req.Body) + ",\n")
+	}
+	b.WriteString("});\n")
+	b.WriteString("console.log(response.status);\n")
+	b.WriteString("console.log(await response.text());\n")
+	return b.String()
+}
+
+// pyQuote renders s as a Python string literal. Go's double-quoted escaping
+// is valid Python for the characters that matter here.
+func pyQuote(s string) string {
+	return strconv.Quote(s)
+}
+
+// jsQuote renders s as a JavaScript string literal.
+func jsQuote(s string) string {
+	return strconv.Quote(s)
+}
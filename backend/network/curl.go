@@ -0,0 +1,69 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CurlCommand renders a raw request dump as an equivalent curl invocation
+// against host (host[:port]). The dump's Host header wins over the host
+// argument when both are present. HTTPS targets get --insecure so repros
+// work against lab targets with self-signed certificates.
+func CurlCommand(dump, host string, useTLS bool) (string, error) {
+	headers, body := SplitDump(dump)
+	lines := strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")
+	fields := strings.Fields(lines[0])
+	if len(fields) < 2 {
+		return "", fmt.Errorf("malformed request line %q", lines[0])
+	}
+	method, target := fields[0], fields[1]
+
+	args := []string{"curl"}
+	if method != "GET" {
+		args = append(args, "-X", method)
+	}
+	for _, line := range lines[1:] {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(name) {
+		case "host":
+			if value != "" {
+				host = value
+			}
+			continue
+		case "content-length":
+			// curl computes this from the body.
+			continue
+		}
+		args = append(args, "-H", shellQuote(name+": "+value))
+	}
+	if body != "" {
+		args = append(args, "--data-raw", shellQuote(body))
+	}
+	if useTLS {
+		args = append(args, "--insecure")
+	}
+
+	targetURL := target
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		if host == "" {
+			return "", fmt.Errorf("request has no host")
+		}
+		scheme := "http"
+		if useTLS {
+			scheme = "https"
+		}
+		targetURL = scheme + "://" + host + target
+	}
+	args = append(args, shellQuote(targetURL))
+	return strings.Join(args, " "), nil
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
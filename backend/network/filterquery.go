@@ -0,0 +1,114 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseFilterQuery compiles a compact query string like
+//
+//	host:api.* status:500..599 method:POST size:>10k has:set-cookie login
+//
+// into RequestFilters, for power users who outgrow the form-based filters.
+// Prefixed terms become structured criteria; unprefixed words become
+// substring conditions. All terms must match.
+func ParseFilterQuery(query string) (RequestFilters, error) {
+	var filters RequestFilters
+	for _, token := range strings.Fields(query) {
+		key, value, ok := strings.Cut(token, ":")
+		if !ok {
+			filters.Terms = append(filters.Terms, token)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "host":
+			filters.HostPattern = value
+		case "path":
+			filters.PathPattern = value
+		case "method":
+			for _, m := range strings.Split(value, ",") {
+				if m != "" {
+					filters.Methods = append(filters.Methods, strings.ToUpper(m))
+				}
+			}
+		case "status":
+			min, max, err := parseFilterRange(value)
+			if err != nil {
+				return filters, fmt.Errorf("invalid status filter %q: %w", token, err)
+			}
+			filters.StatusMin, filters.StatusMax = min, max
+		case "size":
+			min, max, err := parseFilterRange(value)
+			if err != nil {
+				return filters, fmt.Errorf("invalid size filter %q: %w", token, err)
+			}
+			filters.SizeMin, filters.SizeMax = min, max
+		case "has":
+			filters.HasHeaders = append(filters.HasHeaders, value)
+		case "is":
+			if !strings.EqualFold(value, "starred") {
+				return filters, fmt.Errorf("unknown filter %q (did you mean is:starred?)", token)
+			}
+			filters.StarredOnly = true
+		default:
+			// Unknown prefixes (e.g. a literal "foo:bar" search) fall back
+			// to substring terms.
+			filters.Terms = append(filters.Terms, token)
+		}
+	}
+	return filters, nil
+}
+
+// parseFilterRange parses "N", "N..M", ">N", or "<N", with optional k/m
+// size suffixes, into inclusive min/max bounds (0 = unbounded).
+func parseFilterRange(value string) (min, max int, err error) {
+	switch {
+	case strings.Contains(value, ".."):
+		low, high, _ := strings.Cut(value, "..")
+		if min, err = parseFilterNumber(low); err != nil {
+			return 0, 0, err
+		}
+		if max, err = parseFilterNumber(high); err != nil {
+			return 0, 0, err
+		}
+		return min, max, nil
+	case strings.HasPrefix(value, ">"):
+		n, err := parseFilterNumber(value[1:])
+		if err != nil {
+			return 0, 0, err
+		}
+		return n + 1, 0, nil
+	case strings.HasPrefix(value, "<"):
+		n, err := parseFilterNumber(value[1:])
+		if err != nil {
+			return 0, 0, err
+		}
+		return 0, n - 1, nil
+	default:
+		n, err := parseFilterNumber(value)
+		if err != nil {
+			return 0, 0, err
+		}
+		return n, n, nil
+	}
+}
+
+// parseFilterNumber parses an integer with an optional k/m (1024-based)
+// suffix.
+func parseFilterNumber(value string) (int, error) {
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(strings.ToLower(value), "k"):
+		multiplier = 1024
+		value = value[:len(value)-1]
+	case strings.HasSuffix(strings.ToLower(value), "m"):
+		multiplier = 1024 * 1024
+		value = value[:len(value)-1]
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("not a number: %q", value)
+	}
+	return n * multiplier, nil
+}
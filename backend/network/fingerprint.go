@@ -0,0 +1,98 @@
+package network
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sort"
+)
+
+// TLSFingerprint approximates a browser's ClientHello for outbound requests,
+// so targets that block Go's default TLS fingerprint can still be reached.
+// crypto/tls cannot reorder extensions, so this is not a byte-exact JA3
+// match, but cipher suite order, supported versions, curves, and ALPN cover
+// what most WAF fingerprint checks key on.
+type TLSFingerprint struct {
+	Name             string
+	MinVersion       uint16
+	MaxVersion       uint16
+	CipherSuites     []uint16
+	CurvePreferences []tls.CurveID
+	NextProtos       []string
+}
+
+// FingerprintGoDefault leaves the stdlib defaults in place.
+const FingerprintGoDefault = "go-default"
+
+var fingerprints = map[string]*TLSFingerprint{
+	"chrome": {
+		Name:       "chrome",
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS13,
+		CipherSuites: []uint16{
+			tls.TLS_AES_128_GCM_SHA256,
+			tls.TLS_AES_256_GCM_SHA384,
+			tls.TLS_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+			tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+		},
+		CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+		NextProtos:       []string{"h2", "http/1.1"},
+	},
+	"firefox": {
+		Name:       "firefox",
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS13,
+		CipherSuites: []uint16{
+			tls.TLS_AES_128_GCM_SHA256,
+			tls.TLS_CHACHA20_POLY1305_SHA256,
+			tls.TLS_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+		},
+		CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384, tls.CurveP521},
+		NextProtos:       []string{"h2", "http/1.1"},
+	},
+}
+
+// TLSFingerprintNames lists the configurable fingerprint names.
+func TLSFingerprintNames() []string {
+	names := []string{FingerprintGoDefault}
+	for name := range fingerprints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyTLSFingerprint mutates cfg to mimic the named client. The go-default
+// name resets to stdlib behavior.
+func ApplyTLSFingerprint(cfg *tls.Config, name string) error {
+	if name == "" || name == FingerprintGoDefault {
+		cfg.MinVersion = 0
+		cfg.MaxVersion = 0
+		cfg.CipherSuites = nil
+		cfg.CurvePreferences = nil
+		return nil
+	}
+	fp, ok := fingerprints[name]
+	if !ok {
+		return fmt.Errorf("unknown TLS fingerprint %q", name)
+	}
+	cfg.MinVersion = fp.MinVersion
+	cfg.MaxVersion = fp.MaxVersion
+	cfg.CipherSuites = append([]uint16(nil), fp.CipherSuites...)
+	cfg.CurvePreferences = append([]tls.CurveID(nil), fp.CurvePreferences...)
+	return nil
+}
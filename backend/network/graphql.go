@@ -0,0 +1,158 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GraphQLOperation is one parsed GraphQL operation: a single POST body may
+// carry one operation or a batch.
+type GraphQLOperation struct {
+	// OperationName comes from the request body, falling back to the name in
+	// the query text.
+	OperationName string `json:"operationName,omitempty"`
+	// Type is "query", "mutation", or "subscription".
+	Type  string `json:"type"`
+	Query string `json:"query"`
+	// Variables holds the operation's variables as sent.
+	Variables map[string]any `json:"variables,omitempty"`
+	// TopFields lists the top-level selection fields, so extraction rules
+	// can target e.g. the "login" field without parsing the query text.
+	TopFields []string `json:"topFields,omitempty"`
+}
+
+// graphQLBody mirrors the standard GraphQL-over-HTTP POST body.
+type graphQLBody struct {
+	OperationName string         `json:"operationName"`
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// IsGraphQLRequest reports whether a request body looks like GraphQL: a JSON
+// object (or batch array) with a "query" key.
+func IsGraphQLRequest(contentType, body string) bool {
+	if !strings.Contains(strings.ToLower(contentType), "json") {
+		return false
+	}
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+	return strings.Contains(trimmed, `"query"`)
+}
+
+// ParseGraphQL parses a GraphQL POST body into structured operations.
+// Both single operations and batched arrays are handled.
+func ParseGraphQL(body string) ([]GraphQLOperation, error) {
+	trimmed := strings.TrimSpace(body)
+	var raw []graphQLBody
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse GraphQL batch: %w", err)
+		}
+	} else {
+		var single graphQLBody
+		if err := json.Unmarshal([]byte(trimmed), &single); err != nil {
+			return nil, fmt.Errorf("failed to parse GraphQL body: %w", err)
+		}
+		raw = []graphQLBody{single}
+	}
+	out := make([]GraphQLOperation, 0, len(raw))
+	for _, b := range raw {
+		if b.Query == "" {
+			return nil, fmt.Errorf("body has no query field")
+		}
+		op := GraphQLOperation{
+			OperationName: b.OperationName,
+			Query:         b.Query,
+			Variables:     b.Variables,
+		}
+		op.Type, op.TopFields = parseQueryShape(b.Query)
+		if op.OperationName == "" {
+			op.OperationName = parseOperationName(b.Query)
+		}
+		out = append(out, op)
+	}
+	return out, nil
+}
+
+// parseQueryShape extracts the operation type and top-level selection
+// fields without a full GraphQL parser: enough for display and extraction
+// targeting.
+func parseQueryShape(query string) (opType string, topFields []string) {
+	trimmed := strings.TrimSpace(query)
+	opType = "query"
+	for _, t := range []string{"mutation", "subscription", "query"} {
+		if strings.HasPrefix(trimmed, t) {
+			opType = t
+			break
+		}
+	}
+	open := strings.IndexByte(trimmed, '{')
+	if open < 0 {
+		return opType, nil
+	}
+	body := trimmed[open+1:]
+	depth := 0
+	var field strings.Builder
+	flush := func() {
+		name := field.String()
+		field.Reset()
+		// Aliases appear as "alias:" (dropped) or "alias:field" (field kept).
+		if strings.HasSuffix(name, ":") {
+			return
+		}
+		if i := strings.LastIndexByte(name, ':'); i >= 0 {
+			name = name[i+1:]
+		}
+		if name == "" || strings.HasPrefix(name, "...") {
+			return
+		}
+		topFields = append(topFields, name)
+	}
+	for _, r := range body {
+		switch r {
+		case '{', '(':
+			if depth == 0 {
+				flush()
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				flush()
+				return opType, topFields
+			}
+			depth--
+		case ')':
+			depth--
+		default:
+			if depth > 0 {
+				continue
+			}
+			if r == '_' || r == ':' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				field.WriteRune(r)
+			} else {
+				flush()
+			}
+		}
+	}
+	flush()
+	return opType, topFields
+}
+
+// parseOperationName pulls the name out of "query Name(...) {" style text.
+func parseOperationName(query string) string {
+	trimmed := strings.TrimSpace(query)
+	for _, t := range []string{"mutation", "subscription", "query"} {
+		if strings.HasPrefix(trimmed, t) {
+			rest := strings.TrimSpace(trimmed[len(t):])
+			end := strings.IndexAny(rest, " ({@")
+			if end > 0 {
+				return rest[:end]
+			}
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package network
+
+import (
+	"fmt"
+)
+
+// HighlightRule auto-tags incoming transactions whose content matches a
+// regex, so interesting traffic (error responses, authorized requests)
+// stands out in history without manual triage. Color is carried for the UI;
+// the tag is what lands on the transaction.
+type HighlightRule struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Pattern is a case-insensitive regex matched against the scoped part of
+	// the transaction.
+	Pattern string `json:"pattern"`
+	// Scope limits matching: "request", "response", "headers", or "" for
+	// whole transactions.
+	Scope string `json:"scope,omitempty"`
+	// Tag is added to matching transactions ("" uses the rule name).
+	Tag   string `json:"tag,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// Validate checks the rule before it is stored.
+func (r *HighlightRule) Validate() error {
+	if r.Pattern == "" {
+		return fmt.Errorf("highlight pattern must not be empty")
+	}
+	if _, err := CompileCached("(?i)" + r.Pattern); err != nil {
+		return fmt.Errorf("invalid highlight regex %q: %w", r.Pattern, err)
+	}
+	switch r.Scope {
+	case "", "request", "response", "headers":
+	default:
+		return fmt.Errorf("unknown highlight scope %q", r.Scope)
+	}
+	return nil
+}
+
+// AppliedTag returns the tag the rule adds to matching transactions.
+func (r *HighlightRule) AppliedTag() string {
+	if r.Tag != "" {
+		return r.Tag
+	}
+	return r.Name
+}
+
+// Matches reports whether the transaction's scoped content matches the
+// rule's pattern.
+func (r *HighlightRule) Matches(tx *HTTPTransaction) bool {
+	re, err := CompileCached("(?i)" + r.Pattern)
+	if err != nil {
+		return false
+	}
+	for _, hay := range searchTargets(tx, r.Scope) {
+		if re.MatchString(hay) {
+			return true
+		}
+	}
+	return false
+}
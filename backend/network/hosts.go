@@ -0,0 +1,66 @@
+package network
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostsOverrides is a hosts-file-style hostname→IP table consulted before
+// DNS for outbound connections, so staging servers and virtual hosts can be
+// tested without touching /etc/hosts. One instance is shared by the proxy
+// and the flow executor.
+type HostsOverrides struct {
+	mu    sync.RWMutex
+	table map[string]string
+}
+
+// NewHostsOverrides returns an empty table.
+func NewHostsOverrides() *HostsOverrides {
+	return &HostsOverrides{table: make(map[string]string)}
+}
+
+// Set replaces the whole table.
+func (h *HostsOverrides) Set(table map[string]string) {
+	normalized := make(map[string]string, len(table))
+	for host, ip := range table {
+		normalized[strings.ToLower(host)] = ip
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.table = normalized
+}
+
+// Table returns a copy of the current table.
+func (h *HostsOverrides) Table() map[string]string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make(map[string]string, len(h.table))
+	for host, ip := range h.table {
+		out[host] = ip
+	}
+	return out
+}
+
+// Resolve returns the override for a hostname, if any.
+func (h *HostsOverrides) Resolve(host string) (string, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	ip, ok := h.table[strings.ToLower(host)]
+	return ip, ok
+}
+
+// DialContext is a net.Dialer-compatible dial function that applies the
+// override table to the target address before connecting.
+func (h *HostsOverrides) DialContext(ctx context.Context, netw, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err == nil {
+		if ip, ok := h.Resolve(host); ok {
+			addr = net.JoinHostPort(ip, port)
+		}
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	return dialer.DialContext(ctx, netw, addr)
+}
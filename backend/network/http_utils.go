@@ -0,0 +1,216 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ParseRequestDump parses a raw request dump into an *http.Request. The dump
+// is expected to use CRLF or bare LF line endings; bare LF is tolerated
+// because dumps frequently pass through editors that normalize them.
+func ParseRequestDump(dump string) (*http.Request, error) {
+	req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(normalizeDump(dump))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request dump: %w", err)
+	}
+	return req, nil
+}
+
+// ParseResponseDump parses a raw response dump into an *http.Response. The
+// returned response's Body is fully read and replaced with a re-readable
+// buffer.
+func ParseResponseDump(dump string) (*http.Response, error) {
+	resp, err := http.ReadResponse(bufio.NewReader(strings.NewReader(normalizeDump(dump))), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response dump: %w", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// DumpRequest renders a request back into wire format, including the body.
+func DumpRequest(req *http.Request) (string, error) {
+	var buf bytes.Buffer
+	if err := req.Write(&buf); err != nil {
+		return "", fmt.Errorf("failed to serialize request: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// DumpResponse renders a response back into wire format, including the body.
+func DumpResponse(resp *http.Response) (string, error) {
+	var buf bytes.Buffer
+	if err := resp.Write(&buf); err != nil {
+		return "", fmt.Errorf("failed to serialize response: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// SplitDump separates a raw HTTP message into its header block and body.
+func SplitDump(dump string) (headers string, body string) {
+	if idx := strings.Index(dump, "\r\n\r\n"); idx >= 0 {
+		return dump[:idx], dump[idx+4:]
+	}
+	if idx := strings.Index(dump, "\n\n"); idx >= 0 {
+		return dump[:idx], dump[idx+2:]
+	}
+	return dump, ""
+}
+
+// ReplaceDumpBody swaps a dump's body for new bytes, updating (or adding)
+// Content-Length so the framing stays consistent. It is binary-safe: the
+// body bytes are spliced in untouched.
+func ReplaceDumpBody(dump string, body []byte) string {
+	headers, _ := SplitDump(normalizeDump(dump))
+	lines := strings.Split(headers, "\r\n")
+	replaced := false
+	for i, line := range lines {
+		if name, _, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			lines[i] = fmt.Sprintf("Content-Length: %d", len(body))
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		lines = append(lines, fmt.Sprintf("Content-Length: %d", len(body)))
+	}
+	return strings.Join(lines, "\r\n") + "\r\n\r\n" + string(body)
+}
+
+// GetPrintableResponseWithDecompression returns the response dump with the
+// body decompressed for display when the Content-Encoding is one we can
+// decode. Unknown encodings are returned untouched.
+func GetPrintableResponseWithDecompression(dump string) string {
+	resp, err := ParseResponseDump(dump)
+	if err != nil {
+		return dump
+	}
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "" {
+		return dump
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return dump
+	}
+	decoded, err := DecodeContentEncoding(body, encoding)
+	if err != nil {
+		return dump
+	}
+	headers, _ := SplitDump(dump)
+	return headers + "\r\n\r\n" + string(decoded)
+}
+
+// contentDecoders maps Content-Encoding tokens to decoder functions.
+// RegisterContentDecoder lets callers plug in further encodings; the proxy
+// strips tokens from Accept-Encoding when no decoder is registered so
+// servers never send encodings history can't display.
+var contentDecoders = map[string]func([]byte) ([]byte, error){
+	"gzip":    decodeGzip,
+	"x-gzip":  decodeGzip,
+	"deflate": decodeDeflate,
+	"br":      decodeBrotli,
+	"zstd":    decodeZstd,
+	"identity": func(body []byte) ([]byte, error) {
+		return body, nil
+	},
+}
+
+// RegisterContentDecoder installs a decoder for a Content-Encoding token not
+// covered by the built-ins. Call before any traffic is captured.
+func RegisterContentDecoder(encoding string, decode func([]byte) ([]byte, error)) {
+	contentDecoders[strings.ToLower(encoding)] = decode
+}
+
+// SupportedContentEncodings returns the tokens DecodeContentEncoding can
+// handle, in Accept-Encoding header form.
+func SupportedContentEncodings() []string {
+	encodings := make([]string, 0, len(contentDecoders))
+	for encoding := range contentDecoders {
+		if encoding == "identity" || encoding == "x-gzip" {
+			continue
+		}
+		encodings = append(encodings, encoding)
+	}
+	sort.Strings(encodings)
+	return encodings
+}
+
+// DecodeContentEncoding decompresses a body according to a Content-Encoding
+// token. It returns an error for encodings we cannot decode so callers can
+// fall back to the raw bytes.
+func DecodeContentEncoding(body []byte, encoding string) ([]byte, error) {
+	if decode, ok := contentDecoders[encoding]; ok {
+		return decode(body)
+	}
+	return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+}
+
+func decodeGzip(body []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func decodeDeflate(body []byte) ([]byte, error) {
+	// Servers disagree on whether "deflate" means zlib-wrapped or raw; try
+	// zlib first, then raw flate.
+	if r, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+	r := flate.NewReader(bytes.NewReader(body))
+	defer r.Close()
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inflate body: %w", err)
+	}
+	return decoded, nil
+}
+
+func decodeBrotli(body []byte) ([]byte, error) {
+	decoded, err := io.ReadAll(brotli.NewReader(bytes.NewReader(body)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode brotli body: %w", err)
+	}
+	return decoded, nil
+}
+
+func decodeZstd(body []byte) ([]byte, error) {
+	r, err := zstd.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+	}
+	defer r.Close()
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode zstd body: %w", err)
+	}
+	return decoded, nil
+}
+
+func normalizeDump(dump string) string {
+	if strings.Contains(dump, "\r\n") {
+		return dump
+	}
+	return strings.ReplaceAll(dump, "\n", "\r\n")
+}
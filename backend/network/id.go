@@ -0,0 +1,16 @@
+package network
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewID returns a random 128-bit identifier in hex, used for transactions
+// and other backend entities that need stable unique IDs across saves.
+func NewID() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic("failed to read random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(buf[:])
+}
@@ -0,0 +1,60 @@
+package network
+
+// Minimal MD4 (RFC 1320), needed for NTLM password hashing. MD4 left the
+// standard library long ago and is used here strictly for protocol
+// compatibility, never for integrity.
+
+import "encoding/binary"
+
+func md4Sum(data []byte) [16]byte {
+	a, b, c, d := uint32(0x67452301), uint32(0xefcdab89), uint32(0x98badcfe), uint32(0x10325476)
+
+	// Pad to 64-byte blocks: 0x80, zeros, then the bit length.
+	msgLen := len(data)
+	padded := append(append([]byte(nil), data...), 0x80)
+	for len(padded)%64 != 56 {
+		padded = append(padded, 0)
+	}
+	padded = binary.LittleEndian.AppendUint64(padded, uint64(msgLen)*8)
+
+	var x [16]uint32
+	for block := 0; block < len(padded); block += 64 {
+		for i := range x {
+			x[i] = binary.LittleEndian.Uint32(padded[block+i*4:])
+		}
+		aa, bb, cc, dd := a, b, c, d
+
+		f := func(x, y, z uint32) uint32 { return (x & y) | (^x & z) }
+		g := func(x, y, z uint32) uint32 { return (x & y) | (x & z) | (y & z) }
+		h := func(x, y, z uint32) uint32 { return x ^ y ^ z }
+		rot := func(v uint32, s uint) uint32 { return v<<s | v>>(32-s) }
+
+		for _, i := range []int{0, 4, 8, 12} {
+			a = rot(a+f(b, c, d)+x[i], 3)
+			d = rot(d+f(a, b, c)+x[i+1], 7)
+			c = rot(c+f(d, a, b)+x[i+2], 11)
+			b = rot(b+f(c, d, a)+x[i+3], 19)
+		}
+		for _, i := range []int{0, 1, 2, 3} {
+			a = rot(a+g(b, c, d)+x[i]+0x5a827999, 3)
+			d = rot(d+g(a, b, c)+x[i+4]+0x5a827999, 5)
+			c = rot(c+g(d, a, b)+x[i+8]+0x5a827999, 9)
+			b = rot(b+g(c, d, a)+x[i+12]+0x5a827999, 13)
+		}
+		for _, i := range []int{0, 2, 1, 3} {
+			a = rot(a+h(b, c, d)+x[i]+0x6ed9eba1, 3)
+			d = rot(d+h(a, b, c)+x[i+8]+0x6ed9eba1, 9)
+			c = rot(c+h(d, a, b)+x[i+4]+0x6ed9eba1, 11)
+			b = rot(b+h(c, d, a)+x[i+12]+0x6ed9eba1, 15)
+		}
+
+		a, b, c, d = a+aa, b+bb, c+cc, d+dd
+	}
+
+	var sum [16]byte
+	binary.LittleEndian.PutUint32(sum[0:], a)
+	binary.LittleEndian.PutUint32(sum[4:], b)
+	binary.LittleEndian.PutUint32(sum[8:], c)
+	binary.LittleEndian.PutUint32(sum[12:], d)
+	return sum
+}
@@ -0,0 +1,94 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+)
+
+// MultipartPart is one part of a multipart/form-data body, decoded so file
+// uploads can be inspected and edited per part instead of hand-editing
+// boundaries.
+type MultipartPart struct {
+	Name        string `json:"name"`
+	Filename    string `json:"filename,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Content     string `json:"content"`
+}
+
+// IsMultipartRequest reports whether a Content-Type header describes a
+// multipart body.
+func IsMultipartRequest(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "multipart/")
+}
+
+// ParseMultipart splits a multipart body into its parts using the boundary
+// from the Content-Type header.
+func ParseMultipart(contentType, body string) ([]MultipartPart, error) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Content-Type: %w", err)
+	}
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, fmt.Errorf("multipart Content-Type has no boundary")
+	}
+	reader := multipart.NewReader(strings.NewReader(body), boundary)
+	var parts []MultipartPart
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse multipart body: %w", err)
+		}
+		content, err := io.ReadAll(part)
+		part.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart part: %w", err)
+		}
+		parts = append(parts, MultipartPart{
+			Name:        part.FormName(),
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Content:     string(content),
+		})
+	}
+	return parts, nil
+}
+
+// BuildMultipart reassembles parts into a body, returning it together with
+// the boundary to place in the Content-Type header.
+func BuildMultipart(parts []MultipartPart) (body, boundary string, err error) {
+	var b strings.Builder
+	w := multipart.NewWriter(&b)
+	for _, part := range parts {
+		header := make(map[string][]string)
+		disposition := fmt.Sprintf("form-data; name=%q", part.Name)
+		if part.Filename != "" {
+			disposition += fmt.Sprintf("; filename=%q", part.Filename)
+		}
+		header["Content-Disposition"] = []string{disposition}
+		if part.ContentType != "" {
+			header["Content-Type"] = []string{part.ContentType}
+		}
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to build multipart part: %w", err)
+		}
+		if _, err := pw.Write([]byte(part.Content)); err != nil {
+			return "", "", fmt.Errorf("failed to build multipart part: %w", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", "", fmt.Errorf("failed to build multipart body: %w", err)
+	}
+	return b.String(), w.Boundary(), nil
+}
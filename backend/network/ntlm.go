@@ -0,0 +1,134 @@
+package network
+
+// NTLMv2 message construction for upstream proxy authentication. Only the
+// client side of the handshake is implemented: a Type 1 negotiate message,
+// parsing the server's Type 2 challenge, and a Type 3 authenticate message
+// carrying an NTLMv2 response.
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+// ntlmNegotiateFlags: Unicode, Request Target, NTLM, Always Sign, Extended
+// Session Security.
+const ntlmNegotiateFlags = 0x00000001 | 0x00000004 | 0x00000200 | 0x00008000 | 0x00080000
+
+// NTLMType1 builds the initial negotiate message.
+func NTLMType1() []byte {
+	msg := make([]byte, 32)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 1)
+	binary.LittleEndian.PutUint32(msg[12:], ntlmNegotiateFlags)
+	// Empty domain and workstation security buffers (offsets past the end).
+	binary.LittleEndian.PutUint32(msg[16:], 0)
+	binary.LittleEndian.PutUint16(msg[20:], 32)
+	binary.LittleEndian.PutUint32(msg[24:], 0)
+	binary.LittleEndian.PutUint16(msg[28:], 32)
+	return msg
+}
+
+// ntlmChallenge is what we need from the server's Type 2 message.
+type ntlmChallenge struct {
+	serverChallenge [8]byte
+	targetInfo      []byte
+}
+
+func parseNTLMType2(msg []byte) (*ntlmChallenge, error) {
+	if len(msg) < 48 || !strings.HasPrefix(string(msg), string(ntlmSignature)) {
+		return nil, fmt.Errorf("malformed NTLM challenge")
+	}
+	if typ := binary.LittleEndian.Uint32(msg[8:]); typ != 2 {
+		return nil, fmt.Errorf("expected NTLM type 2 message, got type %d", typ)
+	}
+	ch := &ntlmChallenge{}
+	copy(ch.serverChallenge[:], msg[24:32])
+	infoLen := binary.LittleEndian.Uint16(msg[40:])
+	infoOffset := binary.LittleEndian.Uint32(msg[44:])
+	if int(infoOffset)+int(infoLen) <= len(msg) {
+		ch.targetInfo = msg[infoOffset : infoOffset+uint32(infoLen)]
+	}
+	return ch, nil
+}
+
+// NTLMType3 answers challenge with an NTLMv2 response for the credentials.
+func NTLMType3(challenge []byte, domain, username, password, workstation string) ([]byte, error) {
+	ch, err := parseNTLMType2(challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	ntlmV2Hash := ntlmv2Hash(domain, username, password)
+	clientNonce := make([]byte, 8)
+	if _, err := rand.Read(clientNonce); err != nil {
+		return nil, fmt.Errorf("failed to generate client nonce: %w", err)
+	}
+
+	// Blob: version, timestamp (Windows FILETIME), client nonce, target info.
+	blob := []byte{0x01, 0x01, 0, 0, 0, 0, 0, 0}
+	filetime := uint64(time.Now().UnixNano()/100) + 116444736000000000
+	blob = binary.LittleEndian.AppendUint64(blob, filetime)
+	blob = append(blob, clientNonce...)
+	blob = append(blob, 0, 0, 0, 0)
+	blob = append(blob, ch.targetInfo...)
+	blob = append(blob, 0, 0, 0, 0)
+
+	mac := hmac.New(md5.New, ntlmV2Hash)
+	mac.Write(ch.serverChallenge[:])
+	mac.Write(blob)
+	ntResponse := append(mac.Sum(nil), blob...)
+	lmResponse := make([]byte, 24)
+
+	domainUTF16 := utf16le(domain)
+	userUTF16 := utf16le(username)
+	workstationUTF16 := utf16le(workstation)
+
+	const headerLen = 64
+	msg := make([]byte, headerLen)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 3)
+	payload := func(offset int, data []byte) ([]byte, int) {
+		binary.LittleEndian.PutUint16(msg[offset:], uint16(len(data)))
+		binary.LittleEndian.PutUint16(msg[offset+2:], uint16(len(data)))
+		return data, offset
+	}
+	var body []byte
+	appendBuf := func(offset int, data []byte) {
+		data, offset = payload(offset, data)
+		binary.LittleEndian.PutUint32(msg[offset+4:], uint32(headerLen+len(body)))
+		body = append(body, data...)
+	}
+	appendBuf(12, lmResponse)
+	appendBuf(20, ntResponse)
+	appendBuf(28, domainUTF16)
+	appendBuf(36, userUTF16)
+	appendBuf(44, workstationUTF16)
+	appendBuf(52, nil) // session key
+	binary.LittleEndian.PutUint32(msg[60:], ntlmNegotiateFlags)
+	return append(msg, body...), nil
+}
+
+// ntlmv2Hash is HMAC-MD5 over the uppercased user + domain, keyed with the
+// MD4 of the UTF-16LE password.
+func ntlmv2Hash(domain, username, password string) []byte {
+	ntHash := md4Sum(utf16le(password))
+	mac := hmac.New(md5.New, ntHash[:])
+	mac.Write(utf16le(strings.ToUpper(username) + domain))
+	return mac.Sum(nil)
+}
+
+func utf16le(s string) []byte {
+	var out []byte
+	for _, r := range utf16.Encode([]rune(s)) {
+		out = binary.LittleEndian.AppendUint16(out, r)
+	}
+	return out
+}
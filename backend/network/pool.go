@@ -0,0 +1,45 @@
+package network
+
+import (
+	"net/http"
+	"time"
+)
+
+// ConnectionPoolOptions tune outbound connection reuse, for debugging
+// reuse-dependent target behavior and for keeping concurrency to fragile
+// servers in check. Zero values keep the transport's defaults.
+type ConnectionPoolOptions struct {
+	// MaxIdleConns caps idle connections across all hosts (0 keeps the
+	// default, negative means unlimited).
+	MaxIdleConns int `json:"maxIdleConns,omitempty"`
+	// MaxConnsPerHost caps total connections per host, including in-flight
+	// ones (0 means unlimited).
+	MaxConnsPerHost int `json:"maxConnsPerHost,omitempty"`
+	// IdleConnTimeoutSeconds closes idle connections after this many seconds
+	// (0 keeps the default).
+	IdleConnTimeoutSeconds int `json:"idleConnTimeoutSeconds,omitempty"`
+	// DisableKeepAlives opens a fresh connection for every request.
+	DisableKeepAlives bool `json:"disableKeepAlives"`
+}
+
+// IsZero reports whether no option deviates from the defaults.
+func (o ConnectionPoolOptions) IsZero() bool {
+	return o == ConnectionPoolOptions{}
+}
+
+// Apply mutates t according to the options and drops existing idle
+// connections so the new limits take effect immediately.
+func (o ConnectionPoolOptions) Apply(t *http.Transport) {
+	switch {
+	case o.MaxIdleConns < 0:
+		t.MaxIdleConns = 0
+	case o.MaxIdleConns > 0:
+		t.MaxIdleConns = o.MaxIdleConns
+	}
+	t.MaxConnsPerHost = o.MaxConnsPerHost
+	if o.IdleConnTimeoutSeconds > 0 {
+		t.IdleConnTimeout = time.Duration(o.IdleConnTimeoutSeconds) * time.Second
+	}
+	t.DisableKeepAlives = o.DisableKeepAlives
+	t.CloseIdleConnections()
+}
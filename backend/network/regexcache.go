@@ -0,0 +1,50 @@
+package network
+
+import (
+	"regexp"
+	"sync"
+)
+
+// Compiled patterns are cached process-wide: match/replace, intercept rules,
+// and glob matching would otherwise recompile the same handful of patterns
+// for every transaction, which shows up under heavy capture with many rules
+// active. Only successful compiles are cached; failures are rare and caught
+// by rule validation before rules are stored.
+
+// regexpCacheLimit bounds the cache. When it fills (e.g. generated patterns)
+// the whole cache is dropped rather than tracking recency per entry.
+const regexpCacheLimit = 1024
+
+var (
+	regexpCacheMu sync.RWMutex
+	regexpCache   = make(map[string]*regexp.Regexp)
+)
+
+// CompileCached is regexp.Compile backed by the shared cache.
+func CompileCached(pattern string) (*regexp.Regexp, error) {
+	regexpCacheMu.RLock()
+	re, ok := regexpCache[pattern]
+	regexpCacheMu.RUnlock()
+	if ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexpCacheMu.Lock()
+	if len(regexpCache) >= regexpCacheLimit {
+		regexpCache = make(map[string]*regexp.Regexp, regexpCacheLimit)
+	}
+	regexpCache[pattern] = re
+	regexpCacheMu.Unlock()
+	return re, nil
+}
+
+// FlushRegexpCache empties the cache. Rule engines call this when their rule
+// set changes so patterns from deleted rules don't linger.
+func FlushRegexpCache() {
+	regexpCacheMu.Lock()
+	defer regexpCacheMu.Unlock()
+	regexpCache = make(map[string]*regexp.Regexp)
+}
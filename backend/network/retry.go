@@ -0,0 +1,63 @@
+package network
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of outbound requests that fail
+// with transient transport errors (resets, timeouts). Zero values disable
+// retrying.
+type RetryPolicy struct {
+	// MaxRetries is how many times a failed request is re-sent after the
+	// initial attempt.
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// BackoffMS is the delay before the first retry; each further retry
+	// doubles it.
+	BackoffMS int `json:"backoffMs,omitempty"`
+}
+
+// IsZero reports whether retrying is disabled.
+func (p RetryPolicy) IsZero() bool {
+	return p == RetryPolicy{}
+}
+
+// Backoff returns the delay before the given retry (1-based), doubling per
+// attempt.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	d := time.Duration(p.BackoffMS) * time.Millisecond
+	for i := 1; i < retry; i++ {
+		d *= 2
+	}
+	return d
+}
+
+// RetryAttempt records one failed attempt of a retried request.
+type RetryAttempt struct {
+	Error string    `json:"error"`
+	At    time.Time `json:"at"`
+}
+
+// IsTransientErr reports whether an outbound error is worth retrying:
+// connection resets, timeouts, and truncated exchanges. Anything else
+// (refusals, TLS failures, DNS errors) fails the same way on retry.
+func IsTransientErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	return strings.Contains(err.Error(), "connection reset")
+}
@@ -0,0 +1,236 @@
+package network
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RequestFilters selects transactions for history search. Zero values mean
+// "no restriction" for that dimension.
+type RequestFilters struct {
+	Query string `json:"query,omitempty"`
+	// Regex treats Query as a regular expression instead of a substring, so
+	// searches like `Set-Cookie:.*; *Secure` work.
+	Regex bool `json:"regex,omitempty"`
+	// CaseSensitive disables the default case-insensitive matching.
+	CaseSensitive bool `json:"caseSensitive,omitempty"`
+	// Scope limits where Query is matched: "request", "response", "headers"
+	// (both header blocks), or "" for whole transactions.
+	Scope string `json:"scope,omitempty"`
+
+	// The structured criteria below are typically produced by
+	// ParseFilterQuery; zero values mean no restriction.
+	HostPattern string   `json:"hostPattern,omitempty"` // '*' glob on the host
+	PathPattern string   `json:"pathPattern,omitempty"` // '*' glob on the path
+	Methods     []string `json:"methods,omitempty"`
+	StatusMin   int      `json:"statusMin,omitempty"`
+	StatusMax   int      `json:"statusMax,omitempty"`
+	SizeMin     int      `json:"sizeMin,omitempty"` // response body bytes
+	SizeMax     int      `json:"sizeMax,omitempty"`
+	// HasHeaders requires each named header to be present in the request or
+	// response header block.
+	HasHeaders []string `json:"hasHeaders,omitempty"`
+	// StarredOnly keeps only starred transactions.
+	StarredOnly bool `json:"starredOnly,omitempty"`
+	// Terms are additional substring conditions, all required, matched in
+	// the same scope as Query.
+	Terms []string `json:"terms,omitempty"`
+}
+
+// Matcher compiles the filters into a reusable predicate, so patterns are
+// validated and compiled once per search rather than per transaction. All
+// populated criteria must match.
+func (f RequestFilters) Matcher() (func(*HTTPTransaction) bool, error) {
+	switch f.Scope {
+	case "", "request", "response", "headers":
+	default:
+		return nil, fmt.Errorf("unknown search scope %q", f.Scope)
+	}
+	var preds []func(*HTTPTransaction) bool
+
+	if f.Query != "" {
+		match, err := f.textMatcher(f.Query, f.Regex)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, f.scopedPredicate(match))
+	}
+	for _, term := range f.Terms {
+		match, err := f.textMatcher(term, false)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, f.scopedPredicate(match))
+	}
+	if f.HostPattern != "" {
+		pattern := f.HostPattern
+		preds = append(preds, func(tx *HTTPTransaction) bool {
+			return matchFilterGlob(pattern, hostWithoutPort(tx.Request.Host))
+		})
+	}
+	if f.PathPattern != "" {
+		pattern := f.PathPattern
+		preds = append(preds, func(tx *HTTPTransaction) bool {
+			return matchFilterGlob(pattern, tx.Request.Path)
+		})
+	}
+	if len(f.Methods) > 0 {
+		methods := f.Methods
+		preds = append(preds, func(tx *HTTPTransaction) bool {
+			for _, m := range methods {
+				if strings.EqualFold(m, tx.Request.Method) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if f.StatusMin > 0 || f.StatusMax > 0 {
+		min, max := f.StatusMin, f.StatusMax
+		preds = append(preds, func(tx *HTTPTransaction) bool {
+			if tx.Response == nil {
+				return false
+			}
+			code := tx.Response.StatusCode
+			return (min == 0 || code >= min) && (max == 0 || code <= max)
+		})
+	}
+	if f.SizeMin > 0 || f.SizeMax > 0 {
+		min, max := f.SizeMin, f.SizeMax
+		preds = append(preds, func(tx *HTTPTransaction) bool {
+			if tx.Response == nil {
+				return false
+			}
+			size := tx.Response.BodySize
+			return (min == 0 || size >= min) && (max == 0 || size <= max)
+		})
+	}
+	if f.StarredOnly {
+		preds = append(preds, func(tx *HTTPTransaction) bool { return tx.Starred })
+	}
+	for _, name := range f.HasHeaders {
+		header := name
+		preds = append(preds, func(tx *HTTPTransaction) bool {
+			return hasHeaderLine(tx, header)
+		})
+	}
+
+	return func(tx *HTTPTransaction) bool {
+		for _, pred := range preds {
+			if !pred(tx) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// textMatcher builds a string predicate for one query term.
+func (f RequestFilters) textMatcher(query string, regex bool) (func(string) bool, error) {
+	switch {
+	case regex:
+		pattern := query
+		if !f.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := CompileCached(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search regex %q: %w", query, err)
+		}
+		return re.MatchString, nil
+	case f.CaseSensitive:
+		return func(s string) bool { return strings.Contains(s, query) }, nil
+	default:
+		needle := strings.ToLower(query)
+		return func(s string) bool { return strings.Contains(strings.ToLower(s), needle) }, nil
+	}
+}
+
+// scopedPredicate lifts a string predicate to transactions via the filter's
+// search scope.
+func (f RequestFilters) scopedPredicate(match func(string) bool) func(*HTTPTransaction) bool {
+	scope := f.Scope
+	return func(tx *HTTPTransaction) bool {
+		for _, hay := range searchTargets(tx, scope) {
+			if match(hay) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matchFilterGlob matches value against a '*' glob, case-insensitively. It
+// mirrors scope.MatchGlob, which this package cannot import.
+func matchFilterGlob(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	re, err := CompileCached("(?i)^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// hostWithoutPort drops a trailing :port from a host.
+func hostWithoutPort(host string) string {
+	if idx := strings.LastIndexByte(host, ':'); idx >= 0 && !strings.Contains(host[idx:], "]") {
+		if _, err := strconv.Atoi(host[idx+1:]); err == nil {
+			return host[:idx]
+		}
+	}
+	return host
+}
+
+// hasHeaderLine reports whether either header block carries the named header.
+func hasHeaderLine(tx *HTTPTransaction, name string) bool {
+	prefix := strings.ToLower(name) + ":"
+	blocks := []string{tx.Request.Dump}
+	if tx.Response != nil {
+		blocks = append(blocks, tx.Response.Dump)
+	}
+	for _, dump := range blocks {
+		headers, _ := SplitDump(dump)
+		for _, line := range strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")[1:] {
+			if strings.HasPrefix(strings.ToLower(line), prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// searchTargets returns the parts of a transaction the scope covers.
+func searchTargets(tx *HTTPTransaction, scope string) []string {
+	respDump := ""
+	if tx.Response != nil {
+		respDump = tx.Response.Dump
+	}
+	switch scope {
+	case "request":
+		return []string{tx.Request.Dump}
+	case "response":
+		if respDump == "" {
+			return nil
+		}
+		return []string{respDump}
+	case "headers":
+		reqHeaders, _ := SplitDump(tx.Request.Dump)
+		targets := []string{reqHeaders}
+		if respDump != "" {
+			respHeaders, _ := SplitDump(respDump)
+			targets = append(targets, respHeaders)
+		}
+		return targets
+	default:
+		targets := []string{tx.Request.Dump}
+		if respDump != "" {
+			targets = append(targets, respDump)
+		}
+		return targets
+	}
+}
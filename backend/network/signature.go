@@ -0,0 +1,20 @@
+package network
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashBody returns the hex SHA-256 of a message body.
+func HashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// RequestSignature identifies "the same request" across repetitions: method,
+// full URL, and a hash of the request body. Transactions with equal
+// signatures should, on a stable server, produce equivalent responses.
+func RequestSignature(tx *HTTPTransaction) string {
+	_, body := SplitDump(tx.Request.Dump)
+	return tx.Request.Method + " " + tx.Request.URL + " " + HashBody([]byte(body))
+}
@@ -0,0 +1,211 @@
+package network
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema holds the full transaction as a JSON blob and duplicates the
+// hot list-view columns so Summaries and host/method/status filters never
+// deserialize dumps.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS transactions (
+	id          TEXT PRIMARY KEY,
+	seq         INTEGER NOT NULL,
+	timestamp   INTEGER NOT NULL,
+	method      TEXT NOT NULL,
+	host        TEXT NOT NULL,
+	path        TEXT NOT NULL,
+	status_code INTEGER NOT NULL DEFAULT 0,
+	body_size   INTEGER NOT NULL DEFAULT 0,
+	duration_ms INTEGER NOT NULL DEFAULT 0,
+	ttfb_ms     INTEGER NOT NULL DEFAULT 0,
+	starred     INTEGER NOT NULL DEFAULT 0,
+	tags        TEXT NOT NULL DEFAULT '',
+	data        BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_seq ON transactions(seq);
+CREATE INDEX IF NOT EXISTS idx_transactions_host ON transactions(host);
+CREATE INDEX IF NOT EXISTS idx_transactions_method ON transactions(method);
+CREATE INDEX IF NOT EXISTS idx_transactions_status ON transactions(status_code);
+`
+
+// SQLiteTransactionStore is a TransactionStore backed by a SQLite database,
+// for captures too large to hold in memory or rewrite as one JSON file on
+// every save. It is safe for concurrent use.
+type SQLiteTransactionStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteTransactionStore opens (creating if needed) a transaction
+// database at path.
+func NewSQLiteTransactionStore(path string) (*SQLiteTransactionStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transaction database: %w", err)
+	}
+	// The proxy writes from many goroutines; one connection serializes them
+	// instead of tripping SQLite's single-writer lock.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize transaction schema: %w", err)
+	}
+	// starred was added after the initial schema; the ALTER fails harmlessly
+	// on databases that already have the column.
+	db.Exec(`ALTER TABLE transactions ADD COLUMN starred INTEGER NOT NULL DEFAULT 0`)
+	return &SQLiteTransactionStore{db: db}, nil
+}
+
+// Close releases the database handle.
+func (s *SQLiteTransactionStore) Close() error {
+	return s.db.Close()
+}
+
+// Add inserts or replaces a transaction.
+func (s *SQLiteTransactionStore) Add(tx *HTTPTransaction) {
+	data, err := json.Marshal(tx)
+	if err != nil {
+		log.Printf("sqlite store: failed to encode transaction %s: %v", tx.ID, err)
+		return
+	}
+	sum := tx.Summary()
+	_, err = s.db.Exec(`INSERT INTO transactions
+		(id, seq, timestamp, method, host, path, status_code, body_size, duration_ms, ttfb_ms, starred, tags, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+		seq=excluded.seq, timestamp=excluded.timestamp, method=excluded.method,
+		host=excluded.host, path=excluded.path, status_code=excluded.status_code,
+		body_size=excluded.body_size, duration_ms=excluded.duration_ms,
+		ttfb_ms=excluded.ttfb_ms, starred=excluded.starred, tags=excluded.tags, data=excluded.data`,
+		tx.ID, sum.SeqNumber, tx.Timestamp.UnixNano(), sum.Method, sum.Host, sum.Path,
+		sum.StatusCode, sum.BodySize, sum.DurationMS, sum.TTFBMS, tx.Starred,
+		strings.Join(tx.Tags, ","), data)
+	if err != nil {
+		log.Printf("sqlite store: failed to write transaction %s: %v", tx.ID, err)
+	}
+}
+
+// Update replaces a stored transaction (e.g. when its response arrives).
+// Unknown IDs are inserted.
+func (s *SQLiteTransactionStore) Update(tx *HTTPTransaction) {
+	s.Add(tx)
+}
+
+// Get returns a transaction by ID.
+func (s *SQLiteTransactionStore) Get(id string) (*HTTPTransaction, bool) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM transactions WHERE id = ?`, id).Scan(&data)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("sqlite store: failed to read transaction %s: %v", id, err)
+		}
+		return nil, false
+	}
+	var tx HTTPTransaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		log.Printf("sqlite store: failed to decode transaction %s: %v", id, err)
+		return nil, false
+	}
+	return &tx, true
+}
+
+// All returns every transaction in sequence order.
+func (s *SQLiteTransactionStore) All() []*HTTPTransaction {
+	rows, err := s.db.Query(`SELECT data FROM transactions ORDER BY seq, id`)
+	if err != nil {
+		log.Printf("sqlite store: failed to list transactions: %v", err)
+		return nil
+	}
+	defer rows.Close()
+	var out []*HTTPTransaction
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			continue
+		}
+		var tx HTTPTransaction
+		if err := json.Unmarshal(data, &tx); err != nil {
+			continue
+		}
+		out = append(out, &tx)
+	}
+	return out
+}
+
+// ForEach streams transactions to fn in sequence order, decoding one row at
+// a time instead of materializing the whole capture.
+func (s *SQLiteTransactionStore) ForEach(fn func(tx *HTTPTransaction) bool) {
+	rows, err := s.db.Query(`SELECT data FROM transactions ORDER BY seq, id`)
+	if err != nil {
+		log.Printf("sqlite store: failed to list transactions: %v", err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			continue
+		}
+		var tx HTTPTransaction
+		if err := json.Unmarshal(data, &tx); err != nil {
+			continue
+		}
+		if !fn(&tx) {
+			return
+		}
+	}
+}
+
+// Summaries returns the list-view projection of every transaction in
+// sequence order, straight from the indexed columns.
+func (s *SQLiteTransactionStore) Summaries() []HTTPTransactionSummary {
+	rows, err := s.db.Query(`SELECT id, seq, timestamp, method, host, path,
+		status_code, body_size, duration_ms, ttfb_ms, starred, tags
+		FROM transactions ORDER BY seq, id`)
+	if err != nil {
+		log.Printf("sqlite store: failed to list summaries: %v", err)
+		return nil
+	}
+	defer rows.Close()
+	var out []HTTPTransactionSummary
+	for rows.Next() {
+		var sum HTTPTransactionSummary
+		var ts int64
+		var tags string
+		if err := rows.Scan(&sum.ID, &sum.SeqNumber, &ts, &sum.Method, &sum.Host,
+			&sum.Path, &sum.StatusCode, &sum.BodySize, &sum.DurationMS, &sum.TTFBMS,
+			&sum.Starred, &tags); err != nil {
+			continue
+		}
+		sum.Timestamp = time.Unix(0, ts)
+		if tags != "" {
+			sum.Tags = strings.Split(tags, ",")
+		}
+		out = append(out, sum)
+	}
+	return out
+}
+
+// Count returns the number of stored transactions.
+func (s *SQLiteTransactionStore) Count() int {
+	var n int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM transactions`).Scan(&n); err != nil {
+		log.Printf("sqlite store: failed to count transactions: %v", err)
+		return 0
+	}
+	return n
+}
+
+// Clear removes every transaction.
+func (s *SQLiteTransactionStore) Clear() {
+	if _, err := s.db.Exec(`DELETE FROM transactions`); err != nil {
+		log.Printf("sqlite store: failed to clear transactions: %v", err)
+	}
+}
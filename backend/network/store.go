@@ -0,0 +1,266 @@
+package network
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// TransactionStore is the storage contract for captured traffic. The default
+// implementation keeps everything in memory; alternative backends must honor
+// the same ordering guarantees (All returns transactions in sequence order).
+// Transactions fetched from a store may be materialized copies: mutations
+// must be written back via Update to stick.
+type TransactionStore interface {
+	Add(tx *HTTPTransaction)
+	Update(tx *HTTPTransaction)
+	Get(id string) (*HTTPTransaction, bool)
+	All() []*HTTPTransaction
+	// Summaries returns the list-view projection of every transaction in
+	// sequence order, without materializing full dumps.
+	Summaries() []HTTPTransactionSummary
+	// ForEach streams every transaction to fn in insertion order, one at a
+	// time, without holding the whole capture in memory at once. Returning
+	// false stops the iteration. fn must not call back into the store.
+	ForEach(fn func(tx *HTTPTransaction) bool)
+	Count() int
+	Clear()
+}
+
+// recentWindow is how many of the most recent transactions stay uncompressed.
+// Everything older with a recorded response is sealed: serialized, gzipped,
+// and kept only as a blob plus its summary, which shrinks long captures
+// dramatically since dumps dominate memory use.
+const recentWindow = 512
+
+// storedTransaction is one store entry: either live, sealed to an in-memory
+// blob, or offloaded to a file (leaving only the summary resident).
+type storedTransaction struct {
+	live    *HTTPTransaction
+	sealed  []byte
+	file    string
+	summary HTTPTransactionSummary
+}
+
+// InMemoryTransactionStore is the default TransactionStore. It is safe for
+// concurrent use by the proxy goroutines and App bindings.
+type InMemoryTransactionStore struct {
+	mu    sync.RWMutex
+	byID  map[string]*storedTransaction
+	order []string
+	// sealCursor is the index into order below which sealing has been
+	// attempted already.
+	sealCursor int
+	// offloadDir, when set, receives sealed transactions as one file each so
+	// only summaries stay resident; see SetOffloadDir.
+	offloadDir string
+}
+
+// NewInMemoryTransactionStore returns an empty store.
+func NewInMemoryTransactionStore() *InMemoryTransactionStore {
+	return &InMemoryTransactionStore{byID: make(map[string]*storedTransaction)}
+}
+
+// SetOffloadDir routes sealed transactions to one file each under dir
+// instead of keeping compressed blobs in memory, so day-long captures hold
+// only summaries resident and page full dumps from disk on demand ("" seals
+// in memory as before).
+func (s *InMemoryTransactionStore) SetOffloadDir(dir string) error {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("failed to create history offload directory: %w", err)
+		}
+	}
+	s.mu.Lock()
+	s.offloadDir = dir
+	s.mu.Unlock()
+	return nil
+}
+
+// Add inserts a transaction, keeping insertion order.
+func (s *InMemoryTransactionStore) Add(tx *HTTPTransaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if prev, exists := s.byID[tx.ID]; !exists {
+		s.order = append(s.order, tx.ID)
+	} else if prev.file != "" {
+		os.Remove(prev.file)
+	}
+	s.byID[tx.ID] = &storedTransaction{live: tx}
+	s.sealOld()
+}
+
+// Update replaces a stored transaction in place (e.g. when its response
+// arrives). Unknown IDs are inserted.
+func (s *InMemoryTransactionStore) Update(tx *HTTPTransaction) {
+	s.Add(tx)
+}
+
+// Get returns the transaction with the given ID, materializing it if sealed.
+func (s *InMemoryTransactionStore) Get(id string) (*HTTPTransaction, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.byID[id]
+	if !ok {
+		return nil, false
+	}
+	return entry.transaction(), true
+}
+
+// All returns every stored transaction ordered by sequence number. Sealed
+// entries are materialized, so prefer Summaries for list views.
+func (s *InMemoryTransactionStore) All() []*HTTPTransaction {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*HTTPTransaction, 0, len(s.order))
+	for _, id := range s.order {
+		if tx := s.byID[id].transaction(); tx != nil {
+			out = append(out, tx)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].SeqNumber < out[j].SeqNumber })
+	return out
+}
+
+// Summaries returns list-view projections in sequence order without
+// decompressing sealed transactions.
+func (s *InMemoryTransactionStore) Summaries() []HTTPTransactionSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]HTTPTransactionSummary, 0, len(s.order))
+	for _, id := range s.order {
+		entry := s.byID[id]
+		if entry.live != nil {
+			out = append(out, entry.live.Summary())
+		} else {
+			out = append(out, entry.summary)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].SeqNumber < out[j].SeqNumber })
+	return out
+}
+
+// ForEach streams transactions to fn in insertion order, materializing
+// sealed entries one at a time so only a single dump is resident beyond the
+// recent window.
+func (s *InMemoryTransactionStore) ForEach(fn func(tx *HTTPTransaction) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, id := range s.order {
+		tx := s.byID[id].transaction()
+		if tx == nil {
+			continue
+		}
+		if !fn(tx) {
+			return
+		}
+	}
+}
+
+// Count returns the number of stored transactions.
+func (s *InMemoryTransactionStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.order)
+}
+
+// Clear removes all transactions, including offloaded files.
+func (s *InMemoryTransactionStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.byID {
+		if entry.file != "" {
+			os.Remove(entry.file)
+		}
+	}
+	s.byID = make(map[string]*storedTransaction)
+	s.order = nil
+	s.sealCursor = 0
+}
+
+// sealOld compresses completed transactions that have aged out of the recent
+// window. Called with the lock held.
+func (s *InMemoryTransactionStore) sealOld() {
+	limit := len(s.order) - recentWindow
+	for ; s.sealCursor < limit; s.sealCursor++ {
+		entry := s.byID[s.order[s.sealCursor]]
+		if entry.live == nil || entry.live.Response == nil {
+			continue
+		}
+		entry.seal(s.offloadDir)
+	}
+}
+
+// seal converts a live entry into its compressed form, offloaded to a file
+// under dir when set. Failures leave the entry live (or sealed in memory);
+// compression is an optimization, never a requirement.
+func (e *storedTransaction) seal(dir string) {
+	data, err := json.Marshal(e.live)
+	if err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return
+	}
+	if err := zw.Close(); err != nil {
+		return
+	}
+	summary := e.live.Summary()
+	if dir != "" {
+		path := filepath.Join(dir, summary.ID+".gz")
+		if err := os.WriteFile(path, buf.Bytes(), 0o600); err == nil {
+			e.summary = summary
+			e.file = path
+			e.sealed = nil
+			e.live = nil
+			return
+		}
+		// Fall back to in-memory sealing when the write fails.
+	}
+	e.summary = summary
+	e.sealed = buf.Bytes()
+	e.live = nil
+}
+
+// transaction materializes the entry, reading back and decompressing if
+// sealed or offloaded.
+func (e *storedTransaction) transaction() *HTTPTransaction {
+	if e.live != nil {
+		return e.live
+	}
+	sealed := e.sealed
+	if e.file != "" {
+		data, err := os.ReadFile(e.file)
+		if err != nil {
+			log.Printf("store: failed to read offloaded transaction %s: %v", e.summary.ID, err)
+			return nil
+		}
+		sealed = data
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(sealed))
+	if err != nil {
+		log.Printf("store: failed to unseal transaction %s: %v", e.summary.ID, err)
+		return nil
+	}
+	data, err := io.ReadAll(zr)
+	zr.Close()
+	if err != nil {
+		log.Printf("store: failed to unseal transaction %s: %v", e.summary.ID, err)
+		return nil
+	}
+	tx := &HTTPTransaction{}
+	if err := json.Unmarshal(data, tx); err != nil {
+		log.Printf("store: failed to unseal transaction %s: %v", e.summary.ID, err)
+		return nil
+	}
+	return tx
+}
@@ -0,0 +1,55 @@
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+)
+
+// TLSCertificate summarizes one certificate in the server's chain.
+type TLSCertificate struct {
+	Subject            string    `json:"subject"`
+	Issuer             string    `json:"issuer"`
+	DNSNames           []string  `json:"dnsNames,omitempty"`
+	NotBefore          time.Time `json:"notBefore"`
+	NotAfter           time.Time `json:"notAfter"`
+	SignatureAlgorithm string    `json:"signatureAlgorithm"`
+}
+
+// TLSInfo captures what was negotiated on the upstream TLS connection, so
+// weak server configurations can be audited straight from history.
+type TLSInfo struct {
+	Version      string           `json:"version"`
+	CipherSuite  string           `json:"cipherSuite"`
+	ALPNProtocol string           `json:"alpnProtocol,omitempty"`
+	ServerName   string           `json:"serverName,omitempty"`
+	Chain        []TLSCertificate `json:"chain,omitempty"`
+}
+
+// TLSInfoFromState converts a completed handshake's connection state.
+func TLSInfoFromState(state *tls.ConnectionState) *TLSInfo {
+	if state == nil {
+		return nil
+	}
+	info := &TLSInfo{
+		Version:      tls.VersionName(state.Version),
+		CipherSuite:  tls.CipherSuiteName(state.CipherSuite),
+		ALPNProtocol: state.NegotiatedProtocol,
+		ServerName:   state.ServerName,
+	}
+	for _, c := range state.PeerCertificates {
+		info.Chain = append(info.Chain, summarizeCertificate(c))
+	}
+	return info
+}
+
+func summarizeCertificate(c *x509.Certificate) TLSCertificate {
+	return TLSCertificate{
+		Subject:            c.Subject.String(),
+		Issuer:             c.Issuer.String(),
+		DNSNames:           c.DNSNames,
+		NotBefore:          c.NotBefore,
+		NotAfter:           c.NotAfter,
+		SignatureAlgorithm: c.SignatureAlgorithm.String(),
+	}
+}
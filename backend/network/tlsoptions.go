@@ -0,0 +1,95 @@
+package network
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// OutboundTLSOptions force specific TLS parameters on outbound connections,
+// for talking to legacy servers that Go's defaults refuse. Zero values leave
+// the corresponding parameter untouched.
+type OutboundTLSOptions struct {
+	// MinVersion / MaxVersion are "1.0" through "1.3" ("" keeps the default).
+	MinVersion string `json:"minVersion,omitempty"`
+	MaxVersion string `json:"maxVersion,omitempty"`
+	// CipherSuites lists suite names (as in tls.CipherSuiteName) to offer,
+	// in order. Insecure suites are accepted here by design.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	// AllowLegacyRenegotiation permits server-initiated renegotiation.
+	AllowLegacyRenegotiation bool `json:"allowLegacyRenegotiation"`
+}
+
+// Apply mutates cfg according to the options.
+func (o OutboundTLSOptions) Apply(cfg *tls.Config) error {
+	min, err := parseTLSVersion(o.MinVersion)
+	if err != nil {
+		return err
+	}
+	max, err := parseTLSVersion(o.MaxVersion)
+	if err != nil {
+		return err
+	}
+	cfg.MinVersion = min
+	cfg.MaxVersion = max
+	if len(o.CipherSuites) > 0 {
+		ids, err := cipherSuiteIDs(o.CipherSuites)
+		if err != nil {
+			return err
+		}
+		cfg.CipherSuites = ids
+	}
+	if o.AllowLegacyRenegotiation {
+		cfg.Renegotiation = tls.RenegotiateFreelyAsClient
+	} else {
+		cfg.Renegotiation = tls.RenegotiateNever
+	}
+	return nil
+}
+
+func parseTLSVersion(v string) (uint16, error) {
+	switch v {
+	case "":
+		return 0, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unknown TLS version %q (want 1.0-1.3)", v)
+}
+
+func cipherSuiteIDs(names []string) ([]uint16, error) {
+	byName := make(map[string]uint16)
+	for _, s := range tls.CipherSuites() {
+		byName[s.Name] = s.ID
+	}
+	for _, s := range tls.InsecureCipherSuites() {
+		byName[s.Name] = s.ID
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// CipherSuiteNames lists every suite name accepted by OutboundTLSOptions,
+// secure suites first.
+func CipherSuiteNames() []string {
+	var names []string
+	for _, s := range tls.CipherSuites() {
+		names = append(names, s.Name)
+	}
+	for _, s := range tls.InsecureCipherSuites() {
+		names = append(names, s.Name)
+	}
+	return names
+}
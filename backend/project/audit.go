@@ -0,0 +1,31 @@
+package project
+
+import "time"
+
+// AuditEntry records one notable automated action (trigger firing, API
+// invocation) so unattended behavior stays reviewable.
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Actor  string    `json:"actor"`  // "webhook", "trigger", "api-token:<id>", ...
+	Action string    `json:"action"` // short verb phrase
+	Detail string    `json:"detail,omitempty"`
+}
+
+// AppendAudit adds an entry to the project audit log.
+func (p *Project) AppendAudit(actor, action, detail string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.AuditLog = append(p.AuditLog, AuditEntry{
+		Time:   time.Now(),
+		Actor:  actor,
+		Action: action,
+		Detail: detail,
+	})
+}
+
+// Audit returns a copy of the audit log.
+func (p *Project) Audit() []AuditEntry {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]AuditEntry(nil), p.AuditLog...)
+}
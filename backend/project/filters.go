@@ -0,0 +1,17 @@
+package project
+
+import (
+	"gleip/backend/network"
+)
+
+// SavedFilter is a named, reusable history view: request filters plus a sort
+// order, so recurring views like "API errors" are one click away.
+type SavedFilter struct {
+	ID      string                 `json:"id"`
+	Name    string                 `json:"name"`
+	Filters network.RequestFilters `json:"filters"`
+	// Sort orders results by "seq", "duration", "size", or "status"
+	// ("" means sequence order); SortDesc reverses it.
+	Sort     string `json:"sort,omitempty"`
+	SortDesc bool   `json:"sortDesc,omitempty"`
+}
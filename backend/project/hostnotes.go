@@ -0,0 +1,35 @@
+package project
+
+import (
+	"strings"
+	"time"
+)
+
+// HostCredential is one stored test account for a host. Credentials live in
+// the project file, which is the engagement's credential store.
+type HostCredential struct {
+	ID       string `json:"id"`
+	Label    string `json:"label,omitempty"`
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+// HostNote carries per-host engagement context ("use test account A, avoid
+// /admin/delete") and the test credentials that go with it.
+type HostNote struct {
+	Host        string            `json:"host"`
+	Notes       string            `json:"notes,omitempty"`
+	Credentials []*HostCredential `json:"credentials,omitempty"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// NormalizeHost canonicalizes a host for note lookups: lowercase, port
+// stripped.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(host)
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	return host
+}
@@ -0,0 +1,72 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// CurrentSchemaVersion is the schema written by this build. Bump it whenever
+// a change to the Project model needs a migration, and register the
+// corresponding step in migrations below.
+const CurrentSchemaVersion = 1
+
+// migrations maps a source version to the step that lifts a raw project one
+// version forward. Steps operate on the decoded JSON object so they can
+// reshape fields that no longer exist in the Go model.
+var migrations = map[int]func(raw map[string]interface{}) error{
+	0: migrateV0toV1,
+}
+
+// migrateV0toV1 lifts pre-versioning project files: nothing to reshape, the
+// version field is simply introduced.
+func migrateV0toV1(raw map[string]interface{}) error {
+	return nil
+}
+
+// migrateRaw checks the file's schema version and runs any pending
+// migration steps. It returns the (possibly rewritten) JSON, the version
+// the file was at, and whether a migration happened.
+func migrateRaw(data []byte) (migrated []byte, fromVersion int, didMigrate bool, err error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, 0, false, fmt.Errorf("failed to parse project file: %w", err)
+	}
+	version := 0
+	if v, ok := raw["schemaVersion"].(float64); ok {
+		version = int(v)
+	}
+	if version > CurrentSchemaVersion {
+		return nil, version, false, fmt.Errorf(
+			"project file uses schema version %d but this build supports up to %d; please update Gleip",
+			version, CurrentSchemaVersion)
+	}
+	if version == CurrentSchemaVersion {
+		return data, version, false, nil
+	}
+	for v := version; v < CurrentSchemaVersion; v++ {
+		step, ok := migrations[v]
+		if !ok {
+			return nil, version, false, fmt.Errorf("no migration from schema version %d", v)
+		}
+		if err := step(raw); err != nil {
+			return nil, version, false, fmt.Errorf("migration from schema version %d failed: %w", v, err)
+		}
+	}
+	raw["schemaVersion"] = CurrentSchemaVersion
+	out, err := json.Marshal(raw)
+	if err != nil {
+		return nil, version, false, fmt.Errorf("failed to serialize migrated project: %w", err)
+	}
+	return out, version, true, nil
+}
+
+// backupBeforeMigration copies the original file aside so a failed or buggy
+// migration can never destroy the only copy.
+func backupBeforeMigration(path string, data []byte, fromVersion int) error {
+	backup := fmt.Sprintf("%s.pre-migration-v%d.bak", path, fromVersion)
+	if err := os.WriteFile(backup, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write pre-migration backup: %w", err)
+	}
+	return nil
+}
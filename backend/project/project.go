@@ -0,0 +1,193 @@
+// Package project models the on-disk .gleip project file: everything a user
+// accumulates during an engagement that should survive a restart.
+package project
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gleip/backend/gleipflow"
+	"gleip/backend/importers"
+	"gleip/backend/network"
+	"gleip/backend/oast"
+	"gleip/backend/proxy"
+	"gleip/backend/scope"
+)
+
+// Project is the persisted engagement state. Subsystems keep their state in
+// dedicated fields here so one Save call captures everything.
+type Project struct {
+	mu sync.RWMutex
+
+	// Path is where the project was loaded from / last saved to. Not
+	// serialized.
+	path string
+
+	// SchemaVersion tracks the on-disk format; see migrate.go.
+	SchemaVersion int `json:"schemaVersion"`
+
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	InterceptMacros   []*proxy.InterceptMacro   `json:"interceptMacros,omitempty"`
+	UsageSessions     []UsageSession            `json:"usageSessions,omitempty"`
+	MatchReplaceRules []*proxy.MatchReplaceRule `json:"matchReplaceRules,omitempty"`
+	InterceptRules    []*proxy.InterceptRule    `json:"interceptRules,omitempty"`
+	Scope             scope.Config              `json:"scope"`
+
+	HeaderStripEnabled bool                     `json:"headerStripEnabled"`
+	HeaderStripRules   []*proxy.HeaderStripRule `json:"headerStripRules,omitempty"`
+
+	GleipFlows     []*gleipflow.GleipFlow     `json:"gleipFlows,omitempty"`
+	ClientProfiles []*gleipflow.ClientProfile `json:"clientProfiles,omitempty"`
+	Blocklist      []*proxy.BlocklistEntry    `json:"blocklist,omitempty"`
+	HostsOverrides map[string]string          `json:"hostsOverrides,omitempty"`
+	FlowTriggers   []*gleipflow.FlowTrigger   `json:"flowTriggers,omitempty"`
+	AuditLog       []AuditEntry               `json:"auditLog,omitempty"`
+
+	// HostNotes carries per-host context and test credentials, keyed by
+	// normalized hostname.
+	HostNotes []*HostNote `json:"hostNotes,omitempty"`
+
+	// APICollections are imported API descriptions (OpenAPI), kept with
+	// their source URLs so they can be refreshed.
+	APICollections []*importers.APICollection `json:"apiCollections,omitempty"`
+
+	// TelemetryAllow and TelemetryDeny override the telemetry classifier for
+	// this project (allow wins); ExcludeTelemetry hides tagged traffic from
+	// history views and subset exports.
+	TelemetryAllow   []string `json:"telemetryAllow,omitempty"`
+	TelemetryDeny    []string `json:"telemetryDeny,omitempty"`
+	ExcludeTelemetry bool     `json:"excludeTelemetry"`
+
+	// OASTDomain is the project's out-of-band callback domain; issued
+	// payloads are kept so later interactions can be correlated.
+	OASTDomain   string                `json:"oastDomain,omitempty"`
+	OASTPayloads []*oast.IssuedPayload `json:"oastPayloads,omitempty"`
+
+	// SavedFilters are named history views; see filters.go.
+	SavedFilters []*SavedFilter `json:"savedFilters,omitempty"`
+
+	// HighlightRules auto-tag matching transactions as they are captured.
+	HighlightRules []*network.HighlightRule `json:"highlightRules,omitempty"`
+
+	// StorePath, when set, keeps this project's history in a SQLite database
+	// at that path instead of in memory; see network.SQLiteTransactionStore.
+	StorePath string `json:"storePath,omitempty"`
+
+	// Transactions embeds captured history in the file. Normal saves leave
+	// this empty (history lives in the transaction store); subset exports
+	// populate it so the evidence travels with the project.
+	Transactions []*network.HTTPTransaction `json:"transactions,omitempty"`
+}
+
+// New creates an empty, unsaved project.
+func New(name string) *Project {
+	now := time.Now()
+	return &Project{SchemaVersion: CurrentSchemaVersion, Name: name, CreatedAt: now, UpdatedAt: now}
+}
+
+// Load reads a project from path, migrating older schema versions forward
+// after writing a backup of the original file. Files written by a newer
+// build are rejected with a clear error rather than loaded lossily.
+func Load(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+	// Saves are gzip-compressed; older files are plain JSON. Sniff the magic
+	// bytes so both load transparently.
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress project file: %w", err)
+		}
+		data, err = io.ReadAll(zr)
+		zr.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress project file: %w", err)
+		}
+	}
+	migrated, fromVersion, didMigrate, err := migrateRaw(data)
+	if err != nil {
+		return nil, err
+	}
+	if didMigrate {
+		if err := backupBeforeMigration(path, data, fromVersion); err != nil {
+			return nil, err
+		}
+	}
+	p := &Project{}
+	if err := json.Unmarshal(migrated, p); err != nil {
+		return nil, fmt.Errorf("failed to parse project file: %w", err)
+	}
+	p.SchemaVersion = CurrentSchemaVersion
+	p.path = path
+	return p, nil
+}
+
+// Save writes the project to its current path, or the given path if it has
+// never been saved. Writes go through a temp file so a crash mid-save cannot
+// corrupt the project.
+func (p *Project) Save(path string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if path == "" {
+		path = p.path
+	}
+	if path == "" {
+		return fmt.Errorf("project has no path; save it explicitly first")
+	}
+	p.UpdatedAt = time.Now()
+	raw, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize project: %w", err)
+	}
+	// Dumps dominate project size, and they compress extremely well; write
+	// the file gzipped. Load accepts both forms.
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return fmt.Errorf("failed to compress project: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress project: %w", err)
+	}
+	data := buf.Bytes()
+	tmp := path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write project file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to finalize project file: %w", err)
+	}
+	p.path = path
+	return nil
+}
+
+// Path returns where the project is stored on disk ("" if never saved).
+func (p *Project) Path() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.path
+}
+
+// Lock and Unlock expose the project mutex so subsystems mutating nested
+// state can serialize with Save.
+func (p *Project) Lock()   { p.mu.Lock() }
+func (p *Project) Unlock() { p.mu.Unlock() }
+
+// RLock and RUnlock are the read-side counterparts.
+func (p *Project) RLock()   { p.mu.RLock() }
+func (p *Project) RUnlock() { p.mu.RUnlock() }
@@ -0,0 +1,78 @@
+package project
+
+import "time"
+
+// UsageSession is one contiguous stretch of active application use,
+// recorded on the project so engagement time survives restarts. End is zero
+// while the session is still open.
+type UsageSession struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+// Duration returns the session length; open sessions are measured up to now.
+func (s UsageSession) Duration() time.Duration {
+	end := s.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return end.Sub(s.Start)
+}
+
+// TimeTrackingReport summarizes recorded usage for billing.
+type TimeTrackingReport struct {
+	TotalActiveHours float64            `json:"totalActiveHours"`
+	PerDayHours      map[string]float64 `json:"perDayHours"`
+	Sessions         []UsageSession     `json:"sessions"`
+}
+
+// StartUsageSession opens a new session. An already-open session is closed
+// first so crashes can't produce overlapping entries.
+func (p *Project) StartUsageSession() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closeOpenSessionLocked(time.Now())
+	p.UsageSessions = append(p.UsageSessions, UsageSession{Start: time.Now()})
+}
+
+// StopUsageSession closes the open session, if any.
+func (p *Project) StopUsageSession() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closeOpenSessionLocked(time.Now())
+}
+
+func (p *Project) closeOpenSessionLocked(at time.Time) {
+	for i := range p.UsageSessions {
+		if p.UsageSessions[i].End.IsZero() {
+			p.UsageSessions[i].End = at
+		}
+	}
+}
+
+// TimeTracking computes the engagement time report from recorded sessions.
+// Sessions spanning midnight are attributed day by day.
+func (p *Project) TimeTracking() TimeTrackingReport {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	report := TimeTrackingReport{
+		PerDayHours: make(map[string]float64),
+		Sessions:    append([]UsageSession(nil), p.UsageSessions...),
+	}
+	for _, s := range report.Sessions {
+		report.TotalActiveHours += s.Duration().Hours()
+		end := s.End
+		if end.IsZero() {
+			end = time.Now()
+		}
+		for cur := s.Start; cur.Before(end); {
+			dayEnd := time.Date(cur.Year(), cur.Month(), cur.Day(), 0, 0, 0, 0, cur.Location()).AddDate(0, 0, 1)
+			if dayEnd.After(end) {
+				dayEnd = end
+			}
+			report.PerDayHours[cur.Format("2006-01-02")] += dayEnd.Sub(cur).Hours()
+			cur = dayEnd
+		}
+	}
+	return report
+}
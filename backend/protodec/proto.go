@@ -0,0 +1,215 @@
+package protodec
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FieldSchema describes one field of a message from a .proto file.
+type FieldSchema struct {
+	Name string
+	Type string
+	// Message is set when the field's type is another message defined in the
+	// same registry.
+	Message *MessageSchema
+}
+
+// MessageSchema maps field numbers to definitions for one message type.
+type MessageSchema struct {
+	Name   string
+	fields map[int]*FieldSchema
+}
+
+// Field returns the definition for a field number, or nil.
+func (m *MessageSchema) Field(number int) *FieldSchema {
+	if m == nil {
+		return nil
+	}
+	return m.fields[number]
+}
+
+// SchemaRegistry holds message schemas parsed from user-supplied .proto
+// files. Safe for concurrent use.
+type SchemaRegistry struct {
+	mu       sync.RWMutex
+	messages map[string]*MessageSchema
+}
+
+// NewSchemaRegistry returns an empty registry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{messages: make(map[string]*MessageSchema)}
+}
+
+// Lookup returns the schema for a message name, or nil.
+func (r *SchemaRegistry) Lookup(name string) *MessageSchema {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.messages[name]
+}
+
+// MessageNames lists the registered message types.
+func (r *SchemaRegistry) MessageNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]string, 0, len(r.messages))
+	for name := range r.messages {
+		out = append(out, name)
+	}
+	return out
+}
+
+// LoadProto parses message definitions out of .proto source and registers
+// them. This is deliberately not a full proto3 parser: it understands
+// message blocks and scalar/message fields, which is what field-name
+// decoding needs; options, services, enums, and imports are skipped.
+func (r *SchemaRegistry) LoadProto(src string) (int, error) {
+	parsed, err := parseProto(src)
+	if err != nil {
+		return 0, err
+	}
+	if len(parsed) == 0 {
+		return 0, fmt.Errorf("no message definitions found")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, msg := range parsed {
+		r.messages[name] = msg
+	}
+	// Re-resolve message-typed fields across everything registered so far.
+	for _, msg := range r.messages {
+		for _, field := range msg.fields {
+			if ref, ok := r.messages[field.Type]; ok {
+				field.Message = ref
+			}
+		}
+	}
+	return len(parsed), nil
+}
+
+// parseProto extracts message definitions from .proto source.
+func parseProto(src string) (map[string]*MessageSchema, error) {
+	src = stripProtoComments(src)
+	out := make(map[string]*MessageSchema)
+	if err := parseProtoBlock(src, "", out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// parseProtoBlock scans one brace-delimited scope for message definitions
+// and field lines. Nested messages are registered under their dotted name
+// and their bare name for convenient lookup.
+func parseProtoBlock(src, prefix string, out map[string]*MessageSchema) error {
+	for {
+		i := strings.Index(src, "message ")
+		if i < 0 {
+			return nil
+		}
+		rest := src[i+len("message "):]
+		brace := strings.IndexByte(rest, '{')
+		if brace < 0 {
+			return fmt.Errorf("message without body")
+		}
+		name := strings.TrimSpace(rest[:brace])
+		body, remainder, err := splitBraceBlock(rest[brace:])
+		if err != nil {
+			return fmt.Errorf("message %s: %w", name, err)
+		}
+		full := name
+		if prefix != "" {
+			full = prefix + "." + name
+		}
+		msg := &MessageSchema{Name: full, fields: make(map[int]*FieldSchema)}
+		parseProtoFields(body, msg)
+		out[full] = msg
+		if _, exists := out[name]; !exists {
+			out[name] = msg
+		}
+		if err := parseProtoBlock(body, full, out); err != nil {
+			return err
+		}
+		src = remainder
+	}
+}
+
+// parseProtoFields pulls `type name = N;` lines out of a message body,
+// skipping nested blocks.
+func parseProtoFields(body string, msg *MessageSchema) {
+	depth := 0
+	for _, raw := range strings.Split(body, "\n") {
+		atTop := depth == 0
+		depth += strings.Count(raw, "{") - strings.Count(raw, "}")
+		if !atTop || strings.Contains(raw, "{") {
+			continue
+		}
+		line := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(raw), ";"))
+		eq := strings.LastIndexByte(line, '=')
+		if eq < 0 {
+			continue
+		}
+		number := 0
+		if _, err := fmt.Sscanf(strings.TrimSpace(line[eq+1:]), "%d", &number); err != nil || number <= 0 {
+			continue
+		}
+		parts := strings.Fields(line[:eq])
+		// [repeated|optional|required] type name
+		for len(parts) > 2 {
+			parts = parts[1:]
+		}
+		if len(parts) != 2 || parts[0] == "message" || parts[0] == "enum" {
+			continue
+		}
+		fieldType := parts[0]
+		if strings.HasPrefix(fieldType, "map<") {
+			continue
+		}
+		msg.fields[number] = &FieldSchema{Name: parts[1], Type: fieldType}
+	}
+}
+
+// splitBraceBlock returns the contents of the leading {...} block and what
+// follows it.
+func splitBraceBlock(src string) (body, remainder string, err error) {
+	depth := 0
+	for i, c := range src {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return src[1:i], src[i+1:], nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("unbalanced braces")
+}
+
+// stripProtoComments removes // and /* */ comments.
+func stripProtoComments(src string) string {
+	var b strings.Builder
+	for {
+		line := strings.Index(src, "//")
+		block := strings.Index(src, "/*")
+		switch {
+		case line >= 0 && (block < 0 || line < block):
+			b.WriteString(src[:line])
+			if end := strings.IndexByte(src[line:], '\n'); end >= 0 {
+				src = src[line+end:]
+			} else {
+				return b.String()
+			}
+		case block >= 0:
+			b.WriteString(src[:block])
+			if end := strings.Index(src[block:], "*/"); end >= 0 {
+				src = src[block+end+2:]
+			} else {
+				return b.String()
+			}
+		default:
+			b.WriteString(src)
+			return b.String()
+		}
+	}
+}
@@ -0,0 +1,253 @@
+// Package protodec decodes protobuf wire format and gRPC message framing so
+// gRPC and gRPC-web traffic is readable in history. Without a schema the
+// decoder falls back to wire-level heuristics; user-supplied .proto files
+// (see proto.go) add field names and types.
+package protodec
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// IsProtobufContentType reports whether a Content-Type carries protobuf
+// payloads (gRPC, gRPC-web, or bare protobuf).
+func IsProtobufContentType(contentType string) bool {
+	mediaType := contentType
+	if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+		mediaType = mediaType[:i]
+	}
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	switch {
+	case mediaType == "application/protobuf", mediaType == "application/x-protobuf":
+		return true
+	case mediaType == "application/grpc" || strings.HasPrefix(mediaType, "application/grpc+"):
+		return true
+	case strings.HasPrefix(mediaType, "application/grpc-web"):
+		return true
+	}
+	return false
+}
+
+// isTextEncoded reports whether the content type is base64-wrapped
+// (grpc-web-text).
+func isTextEncoded(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "grpc-web-text")
+}
+
+// Frame is one gRPC message frame.
+type Frame struct {
+	// Compressed mirrors the frame's compression flag; compressed frames are
+	// not decoded.
+	Compressed bool `json:"compressed"`
+	// Trailer is set for gRPC-web trailer frames, whose payload is
+	// HTTP/1-style headers rather than a message.
+	Trailer string `json:"trailer,omitempty"`
+	Length  int    `json:"length"`
+	// Fields is the decoded message (empty for compressed/trailer frames).
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// Field is one decoded protobuf field. Nested messages populate Fields;
+// scalar values are rendered into Value.
+type Field struct {
+	Number int     `json:"number"`
+	Name   string  `json:"name,omitempty"`
+	Type   string  `json:"type"`
+	Value  string  `json:"value,omitempty"`
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// DecodeBody decodes a request or response body per its content type:
+// gRPC(-web) bodies are split into frames first, bare protobuf is a single
+// unframed message. schema may be nil.
+func DecodeBody(contentType string, body []byte, schema *MessageSchema) ([]Frame, error) {
+	if isTextEncoded(contentType) {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(body)))
+		if err != nil {
+			return nil, fmt.Errorf("grpc-web-text body is not valid base64: %w", err)
+		}
+		body = decoded
+	}
+	mediaType := strings.ToLower(contentType)
+	if strings.Contains(mediaType, "grpc") {
+		return decodeFrames(body, schema)
+	}
+	fields, err := decodeMessage(body, schema)
+	if err != nil {
+		return nil, err
+	}
+	return []Frame{{Length: len(body), Fields: fields}}, nil
+}
+
+// decodeFrames splits gRPC length-prefixed framing: 1 flag byte + 4 byte
+// big-endian length per message.
+func decodeFrames(body []byte, schema *MessageSchema) ([]Frame, error) {
+	var frames []Frame
+	for len(body) > 0 {
+		if len(body) < 5 {
+			return nil, fmt.Errorf("truncated gRPC frame header (%d bytes left)", len(body))
+		}
+		flag := body[0]
+		length := binary.BigEndian.Uint32(body[1:5])
+		if uint32(len(body)-5) < length {
+			return nil, fmt.Errorf("gRPC frame longer than body (%d > %d)", length, len(body)-5)
+		}
+		payload := body[5 : 5+length]
+		body = body[5+length:]
+
+		frame := Frame{Length: int(length)}
+		switch {
+		case flag&0x80 != 0:
+			// gRPC-web trailer frame: the payload is header lines.
+			frame.Trailer = strings.TrimSpace(string(payload))
+		case flag&0x01 != 0:
+			frame.Compressed = true
+		default:
+			fields, err := decodeMessage(payload, schema)
+			if err != nil {
+				return nil, err
+			}
+			frame.Fields = fields
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// decodeMessage walks the wire format. With a schema, field names and types
+// guide rendering; without one, length-delimited fields are decoded as
+// nested messages when they parse cleanly, as strings when printable, and
+// as hex otherwise.
+func decodeMessage(data []byte, schema *MessageSchema) ([]Field, error) {
+	var fields []Field
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid field tag")
+		}
+		data = data[n:]
+		number := int(tag >> 3)
+		wireType := tag & 0x7
+		if number == 0 {
+			return nil, fmt.Errorf("invalid field number 0")
+		}
+		field := Field{Number: number}
+		var def *FieldSchema
+		if schema != nil {
+			def = schema.Field(number)
+		}
+		if def != nil {
+			field.Name = def.Name
+		}
+		switch wireType {
+		case 0: // varint
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, fmt.Errorf("field %d: invalid varint", number)
+			}
+			data = data[n:]
+			field.Type = "varint"
+			field.Value = renderVarint(v, def)
+		case 1: // 64-bit
+			if len(data) < 8 {
+				return nil, fmt.Errorf("field %d: truncated fixed64", number)
+			}
+			field.Type = "fixed64"
+			field.Value = renderFixed64(binary.LittleEndian.Uint64(data[:8]), def)
+			data = data[8:]
+		case 5: // 32-bit
+			if len(data) < 4 {
+				return nil, fmt.Errorf("field %d: truncated fixed32", number)
+			}
+			field.Type = "fixed32"
+			field.Value = renderFixed32(binary.LittleEndian.Uint32(data[:4]), def)
+			data = data[4:]
+		case 2: // length-delimited
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return nil, fmt.Errorf("field %d: truncated length-delimited value", number)
+			}
+			payload := data[n : n+int(length)]
+			data = data[n+int(length):]
+			decodeLengthDelimited(&field, payload, def)
+		default:
+			return nil, fmt.Errorf("field %d: unsupported wire type %d", number, wireType)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func decodeLengthDelimited(field *Field, payload []byte, def *FieldSchema) {
+	if def != nil && def.Message != nil {
+		if nested, err := decodeMessage(payload, def.Message); err == nil {
+			field.Type = "message"
+			field.Fields = nested
+			return
+		}
+	}
+	if def != nil && def.Type == "string" {
+		field.Type = "string"
+		field.Value = string(payload)
+		return
+	}
+	if def == nil && len(payload) > 0 {
+		if nested, err := decodeMessage(payload, nil); err == nil {
+			field.Type = "message"
+			field.Fields = nested
+			return
+		}
+	}
+	if utf8.Valid(payload) && isMostlyPrintable(payload) {
+		field.Type = "string"
+		field.Value = string(payload)
+		return
+	}
+	field.Type = "bytes"
+	field.Value = hex.EncodeToString(payload)
+}
+
+func renderVarint(v uint64, def *FieldSchema) string {
+	if def != nil {
+		switch def.Type {
+		case "bool":
+			return strconv.FormatBool(v != 0)
+		case "sint32", "sint64":
+			return strconv.FormatInt(int64(v>>1)^-int64(v&1), 10)
+		}
+	}
+	return strconv.FormatUint(v, 10)
+}
+
+func renderFixed64(v uint64, def *FieldSchema) string {
+	if def != nil && def.Type == "double" {
+		return strconv.FormatFloat(math.Float64frombits(v), 'g', -1, 64)
+	}
+	return strconv.FormatUint(v, 10)
+}
+
+func renderFixed32(v uint32, def *FieldSchema) string {
+	if def != nil && def.Type == "float" {
+		return strconv.FormatFloat(float64(math.Float32frombits(v)), 'g', -1, 32)
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func isMostlyPrintable(b []byte) bool {
+	if len(b) == 0 {
+		return true
+	}
+	printable := 0
+	for _, r := range string(b) {
+		if r == '\n' || r == '\r' || r == '\t' || (r >= 0x20 && r != 0x7f) {
+			printable++
+		}
+	}
+	return printable*10 >= utf8.RuneCount(b)*9
+}
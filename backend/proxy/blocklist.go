@@ -0,0 +1,98 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gleip/backend/network"
+	"gleip/backend/scope"
+)
+
+// BlockMode says how a blocklisted request is answered.
+type BlockMode string
+
+const (
+	// BlockDrop closes the connection without a response.
+	BlockDrop BlockMode = "drop"
+	// BlockEmpty200 answers with an empty 200 so clients don't retry.
+	BlockEmpty200 BlockMode = "empty-200"
+)
+
+// BlocklistEntry blocks one host glob (analytics, ads, telemetry). Matching
+// requests are answered by the proxy itself and never recorded, keeping
+// history clean during engagements.
+type BlocklistEntry struct {
+	ID      string    `json:"id"`
+	Pattern string    `json:"pattern"`
+	Mode    BlockMode `json:"mode"`
+	Enabled bool      `json:"enabled"`
+}
+
+// Validate checks the entry before it is stored.
+func (e *BlocklistEntry) Validate() error {
+	if e.Pattern == "" {
+		return fmt.Errorf("host pattern is required")
+	}
+	switch e.Mode {
+	case BlockDrop, BlockEmpty200:
+	default:
+		return fmt.Errorf("unknown block mode %q", e.Mode)
+	}
+	return nil
+}
+
+// Blocklist is the active set of blocked host patterns.
+type Blocklist struct {
+	mu      sync.RWMutex
+	entries []*BlocklistEntry
+}
+
+// NewBlocklist returns an empty blocklist.
+func NewBlocklist() *Blocklist {
+	return &Blocklist{}
+}
+
+// Set replaces the active entries.
+func (b *Blocklist) Set(entries []*BlocklistEntry) {
+	b.mu.Lock()
+	b.entries = append([]*BlocklistEntry(nil), entries...)
+	b.mu.Unlock()
+	network.FlushRegexpCache()
+}
+
+// Entries returns a copy of the active entries.
+func (b *Blocklist) Entries() []*BlocklistEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]*BlocklistEntry(nil), b.entries...)
+}
+
+// Lookup returns the block mode for a host, or "" when the host is allowed.
+func (b *Blocklist) Lookup(host string) BlockMode {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	host = stripPort(host)
+	for _, entry := range b.entries {
+		if entry.Enabled && scope.MatchGlob(entry.Pattern, host) {
+			return entry.Mode
+		}
+	}
+	return ""
+}
+
+// emptyOKResponse builds the synthetic response used by BlockEmpty200.
+func emptyOKResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Length": []string{"0"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}
+}
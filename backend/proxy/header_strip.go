@@ -0,0 +1,102 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// HeaderStripAction says what to do with a matched response header.
+type HeaderStripAction string
+
+const (
+	StripRemove  HeaderStripAction = "remove"
+	StripRewrite HeaderStripAction = "rewrite"
+)
+
+// HeaderStripRule removes or rewrites one response header on the fly, so
+// protections like CSP or HSTS don't get in the way of in-browser testing.
+type HeaderStripRule struct {
+	ID      string            `json:"id"`
+	Header  string            `json:"header"`
+	Action  HeaderStripAction `json:"action"`
+	Value   string            `json:"value,omitempty"`
+	Enabled bool              `json:"enabled"`
+}
+
+// Validate checks the rule before it is stored.
+func (r *HeaderStripRule) Validate() error {
+	if r.Header == "" {
+		return fmt.Errorf("header name is required")
+	}
+	switch r.Action {
+	case StripRemove, StripRewrite:
+	default:
+		return fmt.Errorf("unknown header strip action %q", r.Action)
+	}
+	return nil
+}
+
+// HeaderStripper applies the active stripping rules to responses as they
+// pass through the proxy. A master switch disables the whole set at once.
+type HeaderStripper struct {
+	mu      sync.RWMutex
+	enabled bool
+	rules   []*HeaderStripRule
+}
+
+// NewHeaderStripper returns a disabled stripper with no rules.
+func NewHeaderStripper() *HeaderStripper {
+	return &HeaderStripper{}
+}
+
+// Set replaces the rules and master switch together.
+func (h *HeaderStripper) Set(enabled bool, rules []*HeaderStripRule) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.enabled = enabled
+	h.rules = append([]*HeaderStripRule(nil), rules...)
+}
+
+// Apply mutates a response's headers in place according to the active rules.
+func (h *HeaderStripper) Apply(header http.Header) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if !h.enabled {
+		return
+	}
+	for _, rule := range h.rules {
+		if !rule.Enabled {
+			continue
+		}
+		name := http.CanonicalHeaderKey(strings.TrimSpace(rule.Header))
+		if _, present := header[name]; !present {
+			continue
+		}
+		switch rule.Action {
+		case StripRemove:
+			header.Del(name)
+		case StripRewrite:
+			header.Set(name, rule.Value)
+		}
+	}
+}
+
+// DefaultHeaderStripRules is the starter set offered when a project has no
+// rules yet: the headers that most commonly interfere with testing.
+func DefaultHeaderStripRules() []*HeaderStripRule {
+	headers := []string{
+		"Content-Security-Policy",
+		"Content-Security-Policy-Report-Only",
+		"Strict-Transport-Security",
+		"X-Frame-Options",
+		"Cache-Control",
+		"Expires",
+	}
+	rules := make([]*HeaderStripRule, 0, len(headers))
+	for _, name := range headers {
+		rules = append(rules, &HeaderStripRule{Header: name, Action: StripRemove})
+	}
+	return rules
+}
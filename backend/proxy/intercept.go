@@ -0,0 +1,244 @@
+package proxy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gleip/backend/events"
+)
+
+// InterceptedRequest is one request parked in the intercept queue, waiting
+// for the user to forward, modify, or drop it.
+type InterceptedRequest struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transactionId"`
+	Host          string    `json:"host"`
+	Method        string    `json:"method"`
+	Path          string    `json:"path"`
+	RequestDump   string    `json:"requestDump"`
+	ReceivedAt    time.Time `json:"receivedAt"`
+
+	decision chan interceptDecision
+}
+
+type interceptDecision struct {
+	forward bool
+	dump    string
+	// interceptResponse parks the response of this exchange for editing
+	// before it reaches the client.
+	interceptResponse bool
+}
+
+// OverflowPolicy says what happens when the intercept queue is at its cap.
+type OverflowPolicy string
+
+const (
+	// OverflowForwardOldest releases the oldest pending entry unmodified to
+	// make room for the new arrival.
+	OverflowForwardOldest OverflowPolicy = "forward-oldest"
+	// OverflowRejectNew forwards the new arrival unmodified instead of
+	// queueing it.
+	OverflowRejectNew OverflowPolicy = "reject-new"
+)
+
+// InterceptQueue holds requests paused by interception. Proxy goroutines
+// block on their entry's decision channel; App bindings resolve them.
+type InterceptQueue struct {
+	mu      sync.Mutex
+	pending []*InterceptedRequest
+	byID    map[string]*InterceptedRequest
+
+	// limit caps pending entries (0 = unlimited); overflow picks which entry
+	// gives way at the cap.
+	limit    int
+	overflow OverflowPolicy
+
+	// autoTimeout resolves untouched entries after this long (0 disables);
+	// autoDrop drops them instead of forwarding.
+	autoTimeout atomic.Int64
+	autoDrop    atomic.Bool
+}
+
+// SetLimit caps the number of pending entries so a runaway client cannot
+// grow the queue without bound. A zero limit removes the cap; an empty
+// policy defaults to forwarding the oldest entry.
+func (q *InterceptQueue) SetLimit(limit int, policy OverflowPolicy) error {
+	switch policy {
+	case OverflowForwardOldest, OverflowRejectNew, "":
+	default:
+		return fmt.Errorf("unknown overflow policy %q", policy)
+	}
+	q.mu.Lock()
+	q.limit = limit
+	q.overflow = policy
+	q.mu.Unlock()
+	return nil
+}
+
+// SetAutoForward configures automatic resolution of untouched entries:
+// after timeout, a pending request is forwarded unmodified (or dropped when
+// drop is set), so background app traffic never hangs forever when the
+// tester steps away. A zero timeout disables the behavior.
+func (q *InterceptQueue) SetAutoForward(timeout time.Duration, drop bool) {
+	q.autoTimeout.Store(int64(timeout))
+	q.autoDrop.Store(drop)
+}
+
+// NewInterceptQueue returns an empty queue.
+func NewInterceptQueue() *InterceptQueue {
+	return &InterceptQueue{byID: make(map[string]*InterceptedRequest)}
+}
+
+// Add parks a request and notifies the UI. The returned channel yields the
+// user's decision.
+func (q *InterceptQueue) Add(req *InterceptedRequest) <-chan interceptDecision {
+	req.decision = make(chan interceptDecision, 1)
+	q.mu.Lock()
+	if q.limit > 0 && len(q.pending) >= q.limit {
+		if q.overflow == OverflowRejectNew {
+			q.mu.Unlock()
+			req.decision <- interceptDecision{forward: true}
+			return req.decision
+		}
+		oldest := q.pending[0]
+		q.pending = q.pending[1:]
+		delete(q.byID, oldest.ID)
+		oldest.decision <- interceptDecision{forward: true}
+		events.Emit("intercept:resolved", oldest.ID)
+	}
+	q.pending = append(q.pending, req)
+	q.byID[req.ID] = req
+	q.emitStatsLocked()
+	q.mu.Unlock()
+	events.Emit("intercept:request", req)
+	if timeout := time.Duration(q.autoTimeout.Load()); timeout > 0 {
+		time.AfterFunc(timeout, func() {
+			// resolve fails harmlessly when the user already acted.
+			if q.autoDrop.Load() {
+				q.Drop(req.ID)
+			} else {
+				q.Forward(req.ID, "")
+			}
+		})
+	}
+	return req.decision
+}
+
+// InterceptQueueStats summarizes the queue for UI badges ("12 pending, 3
+// for api.example.com") without the frontend polling and filtering.
+type InterceptQueueStats struct {
+	Pending int            `json:"pending"`
+	ByHost  map[string]int `json:"byHost,omitempty"`
+}
+
+// Stats returns the current queue depth, broken down by host.
+func (q *InterceptQueue) Stats() InterceptQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.statsLocked()
+}
+
+func (q *InterceptQueue) statsLocked() InterceptQueueStats {
+	stats := InterceptQueueStats{Pending: len(q.pending)}
+	if len(q.pending) > 0 {
+		stats.ByHost = make(map[string]int)
+		for _, req := range q.pending {
+			stats.ByHost[req.Host]++
+		}
+	}
+	return stats
+}
+
+// emitStatsLocked publishes the queue depth; callers hold q.mu.
+func (q *InterceptQueue) emitStatsLocked() {
+	events.Emit("intercept:queue", q.statsLocked())
+}
+
+// Search returns pending requests whose host, method, path, or dump
+// contains the query (case-insensitive). An empty query returns everything.
+func (q *InterceptQueue) Search(query string) []*InterceptedRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if query == "" {
+		out := make([]*InterceptedRequest, len(q.pending))
+		copy(out, q.pending)
+		return out
+	}
+	needle := strings.ToLower(query)
+	var out []*InterceptedRequest
+	for _, req := range q.pending {
+		if strings.Contains(strings.ToLower(req.Host), needle) ||
+			strings.Contains(strings.ToLower(req.Method), needle) ||
+			strings.Contains(strings.ToLower(req.Path), needle) ||
+			strings.Contains(strings.ToLower(req.RequestDump), needle) {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// Get returns a pending request by ID.
+func (q *InterceptQueue) Get(id string) (*InterceptedRequest, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	req, ok := q.byID[id]
+	return req, ok
+}
+
+// List returns the currently pending requests in arrival order.
+func (q *InterceptQueue) List() []*InterceptedRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]*InterceptedRequest, len(q.pending))
+	copy(out, q.pending)
+	return out
+}
+
+// Forward releases a pending request, optionally with a modified dump (empty
+// string keeps the original).
+func (q *InterceptQueue) Forward(id string, modifiedDump string) error {
+	return q.resolve(id, interceptDecision{forward: true, dump: modifiedDump})
+}
+
+// ForwardAndInterceptResponse releases a pending request like Forward, and
+// additionally parks its response for editing before it reaches the client.
+func (q *InterceptQueue) ForwardAndInterceptResponse(id string, modifiedDump string) error {
+	return q.resolve(id, interceptDecision{forward: true, dump: modifiedDump, interceptResponse: true})
+}
+
+// Drop discards a pending request; the client receives no response beyond a
+// closed connection.
+func (q *InterceptQueue) Drop(id string) error {
+	return q.resolve(id, interceptDecision{forward: false})
+}
+
+// Len returns the number of pending requests.
+func (q *InterceptQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+func (q *InterceptQueue) resolve(id string, d interceptDecision) error {
+	q.mu.Lock()
+	req, ok := q.byID[id]
+	if !ok {
+		q.mu.Unlock()
+		return fmt.Errorf("no intercepted request with ID %s", id)
+	}
+	delete(q.byID, id)
+	for i, pending := range q.pending {
+		if pending.ID == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			break
+		}
+	}
+	q.emitStatsLocked()
+	q.mu.Unlock()
+	req.decision <- d
+	events.Emit("intercept:resolved", id)
+	return nil
+}
@@ -0,0 +1,91 @@
+package proxy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gleip/backend/events"
+)
+
+// InterceptedResponse is one response parked before delivery to the client,
+// waiting for the user to forward, modify, or drop it. Responses end up
+// here when the request was forwarded with "also intercept this response"
+// or when an enabled intercept rule targets the response direction.
+type InterceptedResponse struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transactionId"`
+	Host          string    `json:"host"`
+	Method        string    `json:"method"`
+	Path          string    `json:"path"`
+	StatusCode    int       `json:"statusCode"`
+	ResponseDump  string    `json:"responseDump"`
+	ReceivedAt    time.Time `json:"receivedAt"`
+
+	decision chan interceptDecision
+}
+
+// ResponseQueue holds responses paused by interception, mirroring
+// InterceptQueue on the request side.
+type ResponseQueue struct {
+	mu      sync.Mutex
+	pending []*InterceptedResponse
+	byID    map[string]*InterceptedResponse
+}
+
+// NewResponseQueue returns an empty queue.
+func NewResponseQueue() *ResponseQueue {
+	return &ResponseQueue{byID: make(map[string]*InterceptedResponse)}
+}
+
+// Add parks a response and notifies the UI. The returned channel yields the
+// user's decision.
+func (q *ResponseQueue) Add(resp *InterceptedResponse) <-chan interceptDecision {
+	resp.decision = make(chan interceptDecision, 1)
+	q.mu.Lock()
+	q.pending = append(q.pending, resp)
+	q.byID[resp.ID] = resp
+	q.mu.Unlock()
+	events.Emit("intercept:response", resp)
+	return resp.decision
+}
+
+// List returns the currently pending responses in arrival order.
+func (q *ResponseQueue) List() []*InterceptedResponse {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]*InterceptedResponse, len(q.pending))
+	copy(out, q.pending)
+	return out
+}
+
+// Forward releases a pending response, optionally with a modified dump
+// (empty string keeps the original).
+func (q *ResponseQueue) Forward(id string, modifiedDump string) error {
+	return q.resolve(id, interceptDecision{forward: true, dump: modifiedDump})
+}
+
+// Drop discards a pending response; the client connection is torn down.
+func (q *ResponseQueue) Drop(id string) error {
+	return q.resolve(id, interceptDecision{forward: false})
+}
+
+func (q *ResponseQueue) resolve(id string, d interceptDecision) error {
+	q.mu.Lock()
+	resp, ok := q.byID[id]
+	if !ok {
+		q.mu.Unlock()
+		return fmt.Errorf("no intercepted response with ID %s", id)
+	}
+	delete(q.byID, id)
+	for i, pending := range q.pending {
+		if pending.ID == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			break
+		}
+	}
+	q.mu.Unlock()
+	resp.decision <- d
+	events.Emit("intercept:resolved", id)
+	return nil
+}
@@ -0,0 +1,200 @@
+package proxy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"gleip/backend/network"
+	"gleip/backend/scope"
+)
+
+// InterceptDirection says which side of a transaction a rule applies to.
+type InterceptDirection string
+
+const (
+	DirectionRequest  InterceptDirection = "request"
+	DirectionResponse InterceptDirection = "response"
+	DirectionBoth     InterceptDirection = "both"
+)
+
+// InterceptRule selects which traffic is worth pausing. All populated
+// criteria must match; empty criteria match anything. Extensions are
+// compared against the request path ("css", "js", ...); Negate inverts the
+// rule so static assets can be auto-forwarded with a single exclusion.
+type InterceptRule struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Enabled     bool               `json:"enabled"`
+	Direction   InterceptDirection `json:"direction"`
+	Methods     []string           `json:"methods,omitempty"`
+	HostPattern string             `json:"hostPattern,omitempty"`
+	// PathPattern matches the request path as a glob ("/api/users/*").
+	PathPattern  string   `json:"pathPattern,omitempty"`
+	Extensions   []string `json:"extensions,omitempty"`
+	ContentTypes []string `json:"contentTypes,omitempty"`
+	Negate       bool     `json:"negate"`
+	// DisplayOps are applied to a matching intercepted message before it is
+	// queued for the user (e.g. decode a custom base64 envelope); ForwardOps
+	// re-encode the edited message before it continues.
+	DisplayOps []TamperOp `json:"displayOps,omitempty"`
+	ForwardOps []TamperOp `json:"forwardOps,omitempty"`
+}
+
+// Validate checks the rule before it is stored.
+func (r *InterceptRule) Validate() error {
+	switch r.Direction {
+	case DirectionRequest, DirectionResponse, DirectionBoth, "":
+	default:
+		return fmt.Errorf("unknown intercept direction %q", r.Direction)
+	}
+	return nil
+}
+
+func (r *InterceptRule) appliesTo(direction InterceptDirection) bool {
+	return r.Direction == "" || r.Direction == DirectionBoth || r.Direction == direction
+}
+
+func (r *InterceptRule) matches(method, host, reqPath, contentType string) bool {
+	matched := r.criteriaMatch(method, host, reqPath, contentType)
+	if r.Negate {
+		return !matched
+	}
+	return matched
+}
+
+func (r *InterceptRule) criteriaMatch(method, host, reqPath, contentType string) bool {
+	if len(r.Methods) > 0 && !containsFold(r.Methods, method) {
+		return false
+	}
+	if r.HostPattern != "" && !scope.MatchGlob(r.HostPattern, stripPort(host)) {
+		return false
+	}
+	if r.PathPattern != "" && !scope.MatchGlob(r.PathPattern, reqPath) {
+		return false
+	}
+	if len(r.Extensions) > 0 {
+		ext := strings.TrimPrefix(strings.ToLower(path.Ext(reqPath)), ".")
+		if !containsFold(r.Extensions, ext) {
+			return false
+		}
+	}
+	if len(r.ContentTypes) > 0 {
+		found := false
+		for _, ct := range r.ContentTypes {
+			if strings.Contains(strings.ToLower(contentType), strings.ToLower(ct)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// InterceptRuleSet is the active set of conditional intercept rules. With no
+// enabled rules every request is interceptable (the historical behavior);
+// with rules present, a request must match at least one non-negated rule and
+// no negated "exclusion" rule.
+type InterceptRuleSet struct {
+	mu    sync.RWMutex
+	rules []*InterceptRule
+}
+
+// NewInterceptRuleSet returns an empty rule set.
+func NewInterceptRuleSet() *InterceptRuleSet {
+	return &InterceptRuleSet{}
+}
+
+// Set replaces the active rules and flushes the shared pattern cache.
+func (s *InterceptRuleSet) Set(rules []*InterceptRule) {
+	s.mu.Lock()
+	s.rules = append([]*InterceptRule(nil), rules...)
+	s.mu.Unlock()
+	network.FlushRegexpCache()
+}
+
+// Rules returns the active rules.
+func (s *InterceptRuleSet) Rules() []*InterceptRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]*InterceptRule(nil), s.rules...)
+}
+
+// HasResponseRules reports whether any enabled rule targets the response
+// direction. Response interception is opt-in per rule: with none, only
+// explicitly flagged exchanges park their responses.
+func (s *InterceptRuleSet) HasResponseRules() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rule := range s.rules {
+		if rule.Enabled && !rule.Negate && rule.appliesTo(DirectionResponse) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldIntercept decides whether a message in the given direction should be
+// queued for the user.
+func (s *InterceptRuleSet) ShouldIntercept(direction InterceptDirection, method, host, reqPath, contentType string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hasPositive := false
+	positiveMatch := false
+	for _, rule := range s.rules {
+		if !rule.Enabled || !rule.appliesTo(direction) {
+			continue
+		}
+		if rule.Negate {
+			if !rule.matches(method, host, reqPath, contentType) {
+				return false
+			}
+			continue
+		}
+		hasPositive = true
+		if rule.matches(method, host, reqPath, contentType) {
+			positiveMatch = true
+		}
+	}
+	return !hasPositive || positiveMatch
+}
+
+// TransformOps returns the display and forward tamper chains of the first
+// enabled rule matching the message, or nil chains when no rule carries
+// transforms for it.
+func (s *InterceptRuleSet) TransformOps(direction InterceptDirection, method, host, reqPath, contentType string) (display, forward []TamperOp) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rule := range s.rules {
+		if !rule.Enabled || rule.Negate || !rule.appliesTo(direction) {
+			continue
+		}
+		if len(rule.DisplayOps) == 0 && len(rule.ForwardOps) == 0 {
+			continue
+		}
+		if rule.matches(method, host, reqPath, contentType) {
+			return rule.DisplayOps, rule.ForwardOps
+		}
+	}
+	return nil, nil
+}
+
+func stripPort(host string) string {
+	if idx := strings.IndexByte(host, ':'); idx >= 0 {
+		return host[:idx]
+	}
+	return host
+}
+
+func containsFold(haystack []string, needle string) bool {
+	for _, h := range haystack {
+		if strings.EqualFold(h, needle) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// ppv2Signature is the fixed 12-byte preamble of a PROXY protocol v2 header.
+var ppv2Signature = []byte{0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A}
+
+// proxyProtocolListener strips an optional PROXY protocol v2 header from each
+// accepted connection, so a relay in front of Gleip can forward the original
+// client address. Connections without the signature pass through untouched.
+type proxyProtocolListener struct {
+	net.Listener
+}
+
+func (l *proxyProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := stripProxyProtocol(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return wrapped, nil
+}
+
+// proxyProtocolConn replays header bytes already buffered during detection
+// and reports the relayed client address from the PROXY header, if present.
+type proxyProtocolConn struct {
+	net.Conn
+	reader     *bufio.Reader
+	remoteAddr net.Addr
+}
+
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+func (c *proxyProtocolConn) RemoteAddr() net.Addr {
+	if c.remoteAddr != nil {
+		return c.remoteAddr
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// stripProxyProtocol peeks for a PROXY protocol v2 header and consumes it,
+// recording the original client address it carries.
+func stripProxyProtocol(conn net.Conn) (net.Conn, error) {
+	reader := bufio.NewReader(conn)
+	wrapped := &proxyProtocolConn{Conn: conn, reader: reader}
+	sig, err := reader.Peek(len(ppv2Signature) + 4)
+	if err != nil || !bytes.Equal(sig[:len(ppv2Signature)], ppv2Signature) {
+		// Not PROXY protocol; hand the connection through unchanged.
+		return wrapped, nil
+	}
+	if sig[12]>>4 != 0x2 {
+		return nil, fmt.Errorf("unsupported PROXY protocol version %d", sig[12]>>4)
+	}
+	length := int(binary.BigEndian.Uint16(sig[14:16]))
+	header := make([]byte, len(ppv2Signature)+4+length)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, fmt.Errorf("failed to read PROXY protocol header: %w", err)
+	}
+	addr := header[len(ppv2Signature)+4:]
+	command := header[12] & 0x0F
+	family := header[13] >> 4
+	if command != 0x1 {
+		// LOCAL command: header present but no address to apply.
+		return wrapped, nil
+	}
+	switch family {
+	case 0x1: // AF_INET: 4-byte src/dst addresses, 2-byte ports
+		if len(addr) >= 12 {
+			wrapped.remoteAddr = &net.TCPAddr{
+				IP:   net.IP(addr[0:4]),
+				Port: int(binary.BigEndian.Uint16(addr[8:10])),
+			}
+		}
+	case 0x2: // AF_INET6: 16-byte src/dst addresses, 2-byte ports
+		if len(addr) >= 36 {
+			wrapped.remoteAddr = &net.TCPAddr{
+				IP:   net.IP(addr[0:16]),
+				Port: int(binary.BigEndian.Uint16(addr[32:34])),
+			}
+		}
+	}
+	return wrapped, nil
+}
@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Magic hostnames answered by the proxy itself instead of being forwarded.
+// Devices pointed at the proxy can fetch the root CA from http://gleip/
+// without any file transfer.
+var magicHosts = map[string]bool{
+	"gleip":      true,
+	"gleip.cert": true,
+}
+
+const magicIndexHTML = `<!DOCTYPE html>
+<html>
+<head><title>Gleip Proxy</title></head>
+<body>
+<h1>Gleip Proxy</h1>
+<p>Download and trust the root CA certificate to intercept HTTPS traffic:</p>
+<ul>
+<li><a href="/ca.pem">ca.pem</a> (PEM, most platforms)</li>
+<li><a href="/ca.der">ca.der</a> (DER, Android / iOS)</li>
+</ul>
+<p>Proxy auto-config: <a href="/proxy.pac">proxy.pac</a></p>
+</body>
+</html>
+`
+
+// isMagicHost reports whether a request host (possibly with port) addresses
+// the proxy's built-in endpoint.
+func isMagicHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return magicHosts[strings.ToLower(host)]
+}
+
+// serveMagic answers requests to the magic hostnames with the CA download
+// page and certificate files.
+func (p *ProxyServer) serveMagic(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/", "/index.html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(magicIndexHTML))
+	case "/ca.pem", "/cert", "/cert.pem":
+		w.Header().Set("Content-Type", "application/x-pem-file")
+		w.Header().Set("Content-Disposition", `attachment; filename="gleip-ca.pem"`)
+		w.Write(p.certs.CACertPEM())
+	case "/proxy.pac":
+		w.Header().Set("Content-Type", "application/x-ns-proxy-autoconfig")
+		w.Write([]byte(p.GeneratePAC()))
+	case "/ca.der", "/ca.crt", "/cert.der":
+		w.Header().Set("Content-Type", "application/x-x509-ca-cert")
+		w.Header().Set("Content-Disposition", `attachment; filename="gleip-ca.crt"`)
+		w.Write(p.certs.CACertDER())
+	default:
+		http.NotFound(w, r)
+	}
+}
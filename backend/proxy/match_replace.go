@@ -0,0 +1,138 @@
+package proxy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gleip/backend/network"
+)
+
+// MatchReplaceTarget selects which part of which message a rule applies to.
+type MatchReplaceTarget string
+
+const (
+	TargetRequestLine     MatchReplaceTarget = "request-line"
+	TargetRequestHeaders  MatchReplaceTarget = "request-headers"
+	TargetRequestBody     MatchReplaceTarget = "request-body"
+	TargetResponseLine    MatchReplaceTarget = "response-line"
+	TargetResponseHeaders MatchReplaceTarget = "response-headers"
+	TargetResponseBody    MatchReplaceTarget = "response-body"
+)
+
+// MatchReplaceRule rewrites matching content in proxied traffic, in the
+// spirit of Burp's match & replace. Match is a literal unless IsRegex is
+// set; an empty Match on a header target means "add the Replace line".
+type MatchReplaceRule struct {
+	ID      string             `json:"id"`
+	Name    string             `json:"name"`
+	Enabled bool               `json:"enabled"`
+	Target  MatchReplaceTarget `json:"target"`
+	IsRegex bool               `json:"isRegex"`
+	Match   string             `json:"match"`
+	Replace string             `json:"replace"`
+}
+
+// Validate checks the rule is executable before it is stored.
+func (r *MatchReplaceRule) Validate() error {
+	switch r.Target {
+	case TargetRequestLine, TargetRequestHeaders, TargetRequestBody,
+		TargetResponseLine, TargetResponseHeaders, TargetResponseBody:
+	default:
+		return fmt.Errorf("unknown match/replace target %q", r.Target)
+	}
+	if r.Match == "" && r.Target != TargetRequestHeaders && r.Target != TargetResponseHeaders {
+		return fmt.Errorf("match pattern is required for target %q", r.Target)
+	}
+	if r.IsRegex && r.Match != "" {
+		if _, err := regexp.Compile(r.Match); err != nil {
+			return fmt.Errorf("invalid match regex: %w", err)
+		}
+	}
+	return nil
+}
+
+// MatchReplaceEngine holds the active rule set and applies it to dumps. The
+// proxy consults it for every transaction.
+type MatchReplaceEngine struct {
+	mu    sync.RWMutex
+	rules []*MatchReplaceRule
+}
+
+// NewMatchReplaceEngine returns an engine with no rules.
+func NewMatchReplaceEngine() *MatchReplaceEngine {
+	return &MatchReplaceEngine{}
+}
+
+// SetRules replaces the active rule set. The shared regexp cache is flushed
+// so patterns from removed rules don't linger.
+func (e *MatchReplaceEngine) SetRules(rules []*MatchReplaceRule) {
+	e.mu.Lock()
+	e.rules = append([]*MatchReplaceRule(nil), rules...)
+	e.mu.Unlock()
+	network.FlushRegexpCache()
+}
+
+// ApplyToRequest runs all enabled request-side rules over a request dump.
+func (e *MatchReplaceEngine) ApplyToRequest(dump string) string {
+	return e.apply(dump, TargetRequestLine, TargetRequestHeaders, TargetRequestBody)
+}
+
+// ApplyToResponse runs all enabled response-side rules over a response dump.
+func (e *MatchReplaceEngine) ApplyToResponse(dump string) string {
+	return e.apply(dump, TargetResponseLine, TargetResponseHeaders, TargetResponseBody)
+}
+
+func (e *MatchReplaceEngine) apply(dump string, lineTarget, headerTarget, bodyTarget MatchReplaceTarget) string {
+	e.mu.RLock()
+	rules := e.rules
+	e.mu.RUnlock()
+	if len(rules) == 0 {
+		return dump
+	}
+	headers, body := network.SplitDump(dump)
+	headerLines := strings.Split(headers, "\r\n")
+	firstLine := headerLines[0]
+	rest := strings.Join(headerLines[1:], "\r\n")
+
+	bodyChanged := false
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		switch rule.Target {
+		case lineTarget:
+			firstLine = rule.rewrite(firstLine)
+		case headerTarget:
+			if rule.Match == "" {
+				rest += "\r\n" + rule.Replace
+			} else {
+				rest = rule.rewrite(rest)
+			}
+		case bodyTarget:
+			if rewritten := rule.rewrite(body); rewritten != body {
+				body = rewritten
+				bodyChanged = true
+			}
+		}
+	}
+	out := firstLine + "\r\n" + rest + "\r\n\r\n" + body
+	if bodyChanged {
+		// Reframe Content-Length so the rewritten body re-parses whole
+		// instead of being truncated (or rejected) at the stale length.
+		out = network.ReplaceDumpBody(out, []byte(body))
+	}
+	return out
+}
+
+func (r *MatchReplaceRule) rewrite(s string) string {
+	if r.IsRegex {
+		re, err := network.CompileCached(r.Match)
+		if err != nil {
+			return s
+		}
+		return re.ReplaceAllString(s, r.Replace)
+	}
+	return strings.ReplaceAll(s, r.Match, r.Replace)
+}
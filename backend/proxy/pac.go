@@ -0,0 +1,44 @@
+package proxy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GeneratePAC renders a proxy auto-config script reflecting the current
+// scope and blocklist, so any external browser or OS proxy setting can be
+// pointed at one URL. Blocklisted hosts bypass the proxy entirely; when a
+// scope with include rules is enabled, only in-scope hosts are routed
+// through Gleip.
+func (p *ProxyServer) GeneratePAC() string {
+	var b strings.Builder
+	b.WriteString("function FindProxyForURL(url, host) {\n")
+
+	for _, entry := range p.Blocklist.Entries() {
+		if entry.Enabled {
+			fmt.Fprintf(&b, "    if (shExpMatch(host, %q)) return \"DIRECT\";\n", entry.Pattern)
+		}
+	}
+
+	proxyClause := fmt.Sprintf("return \"PROXY %s\";", p.Addr())
+	cfg := p.Scope.Config()
+	if cfg.Enabled {
+		for _, rule := range cfg.Exclude {
+			if rule.HostPattern != "" {
+				fmt.Fprintf(&b, "    if (shExpMatch(host, %q)) return \"DIRECT\";\n", rule.HostPattern)
+			}
+		}
+		if len(cfg.Include) > 0 {
+			for _, rule := range cfg.Include {
+				if rule.HostPattern != "" {
+					fmt.Fprintf(&b, "    if (shExpMatch(host, %q)) %s\n", rule.HostPattern, proxyClause)
+				}
+			}
+			b.WriteString("    return \"DIRECT\";\n}\n")
+			return b.String()
+		}
+	}
+
+	b.WriteString("    " + proxyClause + "\n}\n")
+	return b.String()
+}
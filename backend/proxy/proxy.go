@@ -0,0 +1,796 @@
+// Package proxy implements the intercepting HTTP/HTTPS proxy at the heart of
+// Gleip. Plain HTTP requests are forwarded directly; CONNECT tunnels are
+// man-in-the-middled using certificates minted by the cert package. Every
+// observed request/response pair is recorded in the transaction store.
+package proxy
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gleip/backend/cert"
+	"gleip/backend/events"
+	"gleip/backend/extension"
+	"gleip/backend/network"
+	"gleip/backend/scope"
+)
+
+// ProxyServer is the listening proxy. All exported methods are safe for
+// concurrent use.
+type ProxyServer struct {
+	mu       sync.Mutex
+	addr     string
+	listener net.Listener
+	certs    *cert.CertificateManager
+	store    network.TransactionStore
+	seq      atomic.Int64
+
+	interceptEnabled    atomic.Bool
+	interceptScopeOnly  atomic.Bool
+	preserveChunked     atomic.Bool
+	preserveHeaderBytes atomic.Bool
+	Intercepts          *InterceptQueue
+	InterceptResponses  *ResponseQueue
+	InterceptRules      *InterceptRuleSet
+	MatchReplace        *MatchReplaceEngine
+	HeaderStrip         *HeaderStripper
+	Blocklist           *Blocklist
+	Hosts               *network.HostsOverrides
+	Scope               *scope.Matcher
+	Hooks               *extension.Registry
+
+	// spoolDir holds large-body spool files; see spool.go.
+	spoolDir string
+
+	// listenerTLS and acceptProxyProto configure the listener itself; both
+	// must be set before Start. See listener.go for the PROXY protocol side.
+	listenerTLS      bool
+	acceptProxyProto bool
+
+	// remote guards non-loopback clients; see remote.go.
+	remote remoteAccess
+
+	observerMu sync.RWMutex
+	observers  []func(*network.HTTPTransaction)
+
+	// retry configures automatic re-sends on transient outbound errors; see
+	// retry.go.
+	retryMu sync.Mutex
+	retry   network.RetryPolicy
+
+	// tunnels logs CONNECT tunnels that carried non-HTTP traffic; see
+	// tunnel.go.
+	tunnels            tunnelLog
+	captureTunnelBytes atomic.Bool
+
+	transport *http.Transport
+}
+
+// NewProxyServer creates a proxy bound to addr (host:port) that records
+// traffic into store and terminates TLS with certificates from certs.
+func NewProxyServer(addr string, certs *cert.CertificateManager, store network.TransactionStore) *ProxyServer {
+	hosts := network.NewHostsOverrides()
+	return &ProxyServer{
+		addr:               addr,
+		certs:              certs,
+		store:              store,
+		Intercepts:         NewInterceptQueue(),
+		InterceptResponses: NewResponseQueue(),
+		InterceptRules:     NewInterceptRuleSet(),
+		MatchReplace:       NewMatchReplaceEngine(),
+		HeaderStrip:        NewHeaderStripper(),
+		Blocklist:          NewBlocklist(),
+		Hosts:              hosts,
+		Scope:              scope.NewMatcher(),
+		Hooks:              extension.NewRegistry(),
+		transport: &http.Transport{
+			Proxy:              nil,
+			DialContext:        hosts.DialContext,
+			TLSClientConfig:    &tls.Config{InsecureSkipVerify: true},
+			DisableCompression: true,
+		},
+	}
+}
+
+// Start begins accepting connections. It returns once the listener is bound;
+// serving continues in the background until Stop is called.
+func (p *ProxyServer) Start() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.listener != nil {
+		return fmt.Errorf("proxy already running on %s", p.addr)
+	}
+	listener, err := net.Listen("tcp", p.addr)
+	if err != nil {
+		return fmt.Errorf("failed to bind proxy listener on %s: %w", p.addr, err)
+	}
+	if p.acceptProxyProto {
+		listener = &proxyProtocolListener{Listener: listener}
+	}
+	if p.listenerTLS {
+		listener = tls.NewListener(listener, &tls.Config{
+			GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+				name := hello.ServerName
+				if name == "" {
+					name = "gleip"
+				}
+				return p.certs.CertificateFor(name)
+			},
+		})
+	}
+	p.listener = listener
+	server := &http.Server{Handler: p}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed && !isClosedErr(err) {
+			log.Printf("proxy: serve error: %v", err)
+		}
+	}()
+	events.Emit("proxy:started", p.addr)
+	return nil
+}
+
+// Stop closes the listener. In-flight requests are allowed to finish.
+func (p *ProxyServer) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.listener == nil {
+		return nil
+	}
+	err := p.listener.Close()
+	p.listener = nil
+	events.Emit("proxy:stopped", p.addr)
+	return err
+}
+
+// Addr returns the configured listen address.
+func (p *ProxyServer) Addr() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.addr
+}
+
+// SetListenerTLS makes the proxy listener itself speak TLS, for clients
+// configured with an https:// proxy URL. Takes effect on the next Start.
+func (p *ProxyServer) SetListenerTLS(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.listenerTLS = enabled
+}
+
+// SetAcceptProxyProtocol enables PROXY protocol v2 parsing on incoming
+// connections. Takes effect on the next Start.
+func (p *ProxyServer) SetAcceptProxyProtocol(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.acceptProxyProto = enabled
+}
+
+// SetTLSFingerprint switches the outbound transport's ClientHello to mimic
+// the named client (see network.TLSFingerprintNames). Idle connections are
+// closed so new handshakes use the new shape.
+func (p *ProxyServer) SetTLSFingerprint(name string) error {
+	if err := network.ApplyTLSFingerprint(p.transport.TLSClientConfig, name); err != nil {
+		return err
+	}
+	p.transport.CloseIdleConnections()
+	return nil
+}
+
+// SetOutboundTLSOptions forces TLS versions, cipher suites, and legacy
+// renegotiation on the outbound transport.
+func (p *ProxyServer) SetOutboundTLSOptions(opts network.OutboundTLSOptions) error {
+	if err := opts.Apply(p.transport.TLSClientConfig); err != nil {
+		return err
+	}
+	p.transport.CloseIdleConnections()
+	return nil
+}
+
+// SetConnectionPoolOptions tunes connection reuse on the outbound
+// transport: per-host limits, idle timeouts, and keep-alive.
+func (p *ProxyServer) SetConnectionPoolOptions(opts network.ConnectionPoolOptions) {
+	opts.Apply(p.transport)
+}
+
+// SetInterceptEnabled toggles request interception globally.
+func (p *ProxyServer) SetInterceptEnabled(enabled bool) {
+	p.interceptEnabled.Store(enabled)
+	events.Emit("intercept:enabled", enabled)
+}
+
+// InterceptEnabled reports whether interception is active.
+func (p *ProxyServer) InterceptEnabled() bool {
+	return p.interceptEnabled.Load()
+}
+
+// SetUpstreamProxy chains outbound traffic through an upstream proxy,
+// authenticating with the configured scheme. An empty Addr restores direct
+// dialing.
+func (p *ProxyServer) SetUpstreamProxy(cfg network.UpstreamProxyConfig) error {
+	if cfg.Addr == "" {
+		p.transport.DialContext = p.Hosts.DialContext
+	} else {
+		p.transport.DialContext = network.NewUpstreamDialer(cfg, p.Hosts.DialContext).DialContext
+	}
+	p.transport.CloseIdleConnections()
+	return nil
+}
+
+// SetPreserveChunkedForwarding controls how requests that arrived with
+// Transfer-Encoding: chunked are forwarded after editing. Dumps are always
+// shown and edited with Content-Length framing; with this option on, chunked
+// framing is restored on the wire when forwarding.
+func (p *ProxyServer) SetPreserveChunkedForwarding(enabled bool) {
+	p.preserveChunked.Store(enabled)
+}
+
+// SetInterceptScopeOnly restricts interception to in-scope requests;
+// out-of-scope traffic is forwarded without queueing.
+func (p *ProxyServer) SetInterceptScopeOnly(only bool) {
+	p.interceptScopeOnly.Store(only)
+}
+
+// NextSeq reserves and returns the next transaction sequence number, for
+// callers (importers, flow execution) that add transactions out of band.
+func (p *ProxyServer) NextSeq() int64 {
+	return p.seq.Add(1)
+}
+
+// AddTransactionObserver registers a callback invoked once per transaction,
+// after its response (if any) has been recorded. Observers run on the proxy
+// goroutine and must be fast.
+func (p *ProxyServer) AddTransactionObserver(fn func(*network.HTTPTransaction)) {
+	p.observerMu.Lock()
+	defer p.observerMu.Unlock()
+	p.observers = append(p.observers, fn)
+}
+
+func (p *ProxyServer) notifyObservers(tx *network.HTTPTransaction) {
+	p.observerMu.RLock()
+	observers := p.observers
+	p.observerMu.RUnlock()
+	for _, fn := range observers {
+		fn(tx)
+	}
+}
+
+// Store exposes the backing transaction store to App bindings.
+func (p *ProxyServer) Store() network.TransactionStore {
+	return p.store
+}
+
+// SetStore swaps the backing transaction store; use it when switching
+// projects, not while traffic is in flight — exchanges already underway keep
+// writing to the store they started with.
+func (p *ProxyServer) SetStore(store network.TransactionStore) {
+	p.store = store
+}
+
+// ServeHTTP dispatches between CONNECT tunneling and plain proxying.
+func (p *ProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !p.remoteClientAllowed(r) {
+		http.Error(w, "proxy access denied", http.StatusForbidden)
+		return
+	}
+	if r.Method == http.MethodConnect {
+		p.handleConnect(w, r)
+		return
+	}
+	if isMagicHost(r.Host) {
+		p.serveMagic(w, r)
+		return
+	}
+	p.handleHTTP(w, r, false)
+}
+
+func (p *ProxyServer) handleConnect(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if !strings.Contains(host, ":") {
+		host += ":443"
+	}
+	hostname := host[:strings.LastIndex(host, ":")]
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	clientConn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	defer clientConn.Close()
+	if _, err := clientConn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		return
+	}
+
+	// Tunnels that never speak TLS (thick clients, plain TCP protocols)
+	// can't be MITM'd; relay them raw and log the exchange so they aren't
+	// silently invisible.
+	clientReader := bufio.NewReader(clientConn)
+	prefix, err := clientReader.Peek(1)
+	if err != nil {
+		return
+	}
+	if !looksLikeTLS(prefix) {
+		p.relayTunnel(clientConn, clientReader, host, "raw", false)
+		return
+	}
+
+	leaf, err := p.certs.CertificateFor(hostname)
+	if err != nil {
+		log.Printf("proxy: failed to mint certificate for %s: %v", hostname, err)
+		return
+	}
+	// The peek buffered part of the ClientHello; hand the handshake a conn
+	// that drains the buffer first.
+	tlsConn := tls.Server(&peekedConn{Conn: clientConn, r: clientReader}, &tls.Config{Certificates: []tls.Certificate{*leaf}})
+	if err := tlsConn.Handshake(); err != nil {
+		return
+	}
+	defer tlsConn.Close()
+
+	reader := bufio.NewReader(tlsConn)
+	// A TLS tunnel carrying something other than HTTP (gRPC-less protocols,
+	// custom framing) gets the same relay treatment inside the decrypted
+	// stream.
+	if prefix, err := reader.Peek(8); err == nil && !looksLikeHTTP(prefix) {
+		p.relayTunnel(tlsConn, reader, host, "tls", true)
+		return
+	}
+	for {
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			return
+		}
+		req.URL.Scheme = "https"
+		req.URL.Host = host
+		if !p.serveInner(tlsConn, req, true) {
+			return
+		}
+	}
+}
+
+// peekedConn is a net.Conn whose reads drain an already-buffered reader
+// before touching the underlying connection.
+type peekedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *peekedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// serveInner handles one request read from a MITM'd tunnel. It returns false
+// when the tunnel should be torn down.
+func (p *ProxyServer) serveInner(conn net.Conn, req *http.Request, isTLS bool) bool {
+	resp, drop := p.roundTrip(req, isTLS)
+	if drop {
+		return false
+	}
+	if resp == nil {
+		conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\nContent-Length: 0\r\n\r\n"))
+		return true
+	}
+	defer resp.Body.Close()
+	if err := resp.Write(conn); err != nil {
+		return false
+	}
+	return !resp.Close && !req.Close
+}
+
+func (p *ProxyServer) handleHTTP(w http.ResponseWriter, r *http.Request, isTLS bool) {
+	resp, drop := p.roundTrip(r, isTLS)
+	if drop {
+		panic(http.ErrAbortHandler)
+	}
+	if resp == nil {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for name, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(name, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	// Streaming responses (SSE) must reach the client as they arrive, not
+	// when the buffer fills.
+	if flusher, ok := w.(http.Flusher); ok && resp.ContentLength < 0 {
+		buf := make([]byte, 4<<10)
+		for {
+			n, err := resp.Body.Read(buf)
+			if n > 0 {
+				if _, werr := w.Write(buf[:n]); werr != nil {
+					return
+				}
+				flusher.Flush()
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+	io.Copy(w, resp.Body)
+}
+
+// roundTrip records, optionally intercepts, and forwards one request. The
+// returned response has a fully buffered body. drop is true when the user
+// dropped the request from the intercept queue.
+func (p *ProxyServer) roundTrip(req *http.Request, isTLS bool) (resp *http.Response, drop bool) {
+	switch p.Blocklist.Lookup(req.Host) {
+	case BlockDrop:
+		return nil, true
+	case BlockEmpty200:
+		return emptyOKResponse(req), false
+	}
+
+	wasChunked := len(req.TransferEncoding) > 0
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, false
+	}
+	req.Body = io.NopCloser(strings.NewReader(string(body)))
+
+	dump, err := network.DumpRequest(cloneWithBody(req, body))
+	if err != nil {
+		log.Printf("proxy: failed to dump request: %v", err)
+		return nil, false
+	}
+
+	if rewritten := p.MatchReplace.ApplyToRequest(dump); rewritten != dump {
+		if modified, err := network.ParseRequestDump(rewritten); err == nil {
+			modified.URL.Scheme = req.URL.Scheme
+			modified.URL.Host = req.URL.Host
+			req = modified
+			dump = rewritten
+		} else {
+			log.Printf("proxy: match/replace produced invalid request, using original: %v", err)
+		}
+	}
+
+	tx := &network.HTTPTransaction{
+		ID:        network.NewID(),
+		SeqNumber: p.seq.Add(1),
+		Timestamp: time.Now(),
+		Request: network.HTTPRequest{
+			Dump:   dump,
+			Method: req.Method,
+			Host:   req.Host,
+			Path:   req.URL.Path,
+			URL:    req.URL.String(),
+			TLS:    isTLS,
+		},
+	}
+	// Pre-store hooks may scrub the recorded dump or veto recording entirely;
+	// a vetoed transaction is still forwarded, just not kept in history.
+	record := true
+	if storeDump, vetoed := p.Hooks.Run(extension.PhasePreStore, tx, dump); vetoed {
+		record = false
+	} else if storeDump != dump {
+		tx.Request.Dump = storeDump
+	}
+	if record {
+		p.store.Add(tx)
+		events.Emit("proxy:request", tx.Summary())
+	}
+
+	shouldIntercept := p.interceptEnabled.Load()
+	if shouldIntercept && p.interceptScopeOnly.Load() && !p.Scope.InScope(req.Host, req.URL.Path) {
+		shouldIntercept = false
+	}
+	if shouldIntercept && !p.InterceptRules.ShouldIntercept(DirectionRequest, req.Method, req.Host, req.URL.Path, req.Header.Get("Content-Type")) {
+		shouldIntercept = false
+	}
+	interceptResponse := false
+	if shouldIntercept {
+		// A matching rule's display transform decodes the dump before it is
+		// queued; the forward transform re-encodes what the user releases.
+		displayOps, forwardOps := p.InterceptRules.TransformOps(DirectionRequest, req.Method, req.Host, req.URL.Path, req.Header.Get("Content-Type"))
+		displayDump := dump
+		if len(displayOps) > 0 {
+			if out, err := applyTamperOps(displayOps, dump); err == nil {
+				displayDump = out
+			} else {
+				log.Printf("proxy: intercept display transform failed, showing raw request: %v", err)
+				forwardOps = nil
+			}
+		}
+		decision := <-p.Intercepts.Add(&InterceptedRequest{
+			ID:            network.NewID(),
+			TransactionID: tx.ID,
+			Host:          req.Host,
+			Method:        req.Method,
+			Path:          req.URL.Path,
+			RequestDump:   displayDump,
+			ReceivedAt:    time.Now(),
+		})
+		if !decision.forward {
+			return nil, true
+		}
+		interceptResponse = decision.interceptResponse
+		finalDump := decision.dump
+		if finalDump == "" {
+			finalDump = displayDump
+		}
+		if len(forwardOps) > 0 {
+			if out, err := applyTamperOps(forwardOps, finalDump); err == nil {
+				finalDump = out
+			} else {
+				log.Printf("proxy: intercept forward transform failed, using displayed request: %v", err)
+			}
+		}
+		if finalDump != dump {
+			modified, err := network.ParseRequestDump(finalDump)
+			if err == nil {
+				modified.URL.Scheme = req.URL.Scheme
+				modified.URL.Host = req.URL.Host
+				req = modified
+				dump = finalDump
+				tx.Request.Dump = finalDump
+				tx.Request.Method = req.Method
+				tx.Request.Path = req.URL.Path
+				if record {
+					p.store.Update(tx)
+				}
+			} else {
+				log.Printf("proxy: modified dump is invalid, forwarding original: %v", err)
+			}
+		}
+	}
+
+	// Pre-forward hooks see the final dump (post match/replace, post
+	// interception) and may veto the request or rewrite it before it goes
+	// upstream.
+	if hookDump, vetoed := p.Hooks.Run(extension.PhasePreForward, tx, dump); vetoed {
+		return nil, true
+	} else if hookDump != dump {
+		if modified, err := network.ParseRequestDump(hookDump); err == nil {
+			modified.URL.Scheme = req.URL.Scheme
+			modified.URL.Host = req.URL.Host
+			req = modified
+			dump = hookDump
+			tx.Request.Dump = hookDump
+			tx.Request.Method = req.Method
+			tx.Request.Path = req.URL.Path
+			if record {
+				p.store.Update(tx)
+			}
+		} else {
+			log.Printf("proxy: extension produced invalid request, forwarding original: %v", err)
+		}
+	}
+
+	// An edited dump always parses back with Content-Length framing. When
+	// the client originally sent chunked and the option is on, restore
+	// chunked framing upstream; otherwise the Content-Length conversion
+	// stands.
+	if wasChunked && p.preserveChunked.Load() {
+		req.TransferEncoding = []string{"chunked"}
+		req.ContentLength = -1
+		req.Header.Del("Content-Length")
+	}
+
+	// Only advertise encodings history can decode, so servers never answer
+	// with something the UI would show as binary garbage.
+	if req.Header.Get("Accept-Encoding") != "" {
+		req.Header.Set("Accept-Encoding", strings.Join(network.SupportedContentEncodings(), ", "))
+	}
+
+	start := time.Now()
+	req.RequestURI = ""
+	trace := newTimingTrace()
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace.clientTrace()))
+	if p.preserveHeaderBytes.Load() {
+		// Byte-for-byte forwarding keeps the dump's header order and casing
+		// on the wire; see rawforward.go.
+		resp, err = p.rawForward(req.URL.Host, isTLS, dump)
+	} else {
+		resp, err = p.forward(req, tx, record)
+	}
+	if err != nil {
+		log.Printf("proxy: forward to %s failed: %v", req.Host, err)
+		return nil, false
+	}
+	tx.Duration = time.Since(start)
+	tx.Timings = trace.breakdown(tx.Duration)
+	tx.TLSInfo = network.TLSInfoFromState(resp.TLS)
+
+	// SSE responses never end, so they can't be buffered like everything
+	// else: record the headers now, stream the body through, and capture
+	// events incrementally as they arrive.
+	if isEventStream(resp.Header.Get("Content-Type")) {
+		p.HeaderStrip.Apply(resp.Header)
+		headerOnly := *resp
+		headerOnly.Body = io.NopCloser(strings.NewReader(""))
+		if dump, err := network.DumpResponse(&headerOnly); err == nil {
+			tx.Response = &network.HTTPResponse{
+				Dump:        dump,
+				Status:      resp.Status,
+				StatusCode:  resp.StatusCode,
+				EventStream: true,
+			}
+			if record {
+				p.store.Update(tx)
+				events.Emit("proxy:response", tx.Summary())
+			}
+		}
+		if record {
+			resp.Body = newSSERecorder(resp.Body, tx, p.store, p.notifyObservers)
+		}
+		return resp, false
+	}
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, spoolThreshold+1))
+	if err != nil {
+		respBody = nil
+	}
+	var spooled *spooledBody
+	if len(respBody) > spoolThreshold && p.getSpoolDir() != "" {
+		spooled, err = p.spoolBody(respBody, resp.Body)
+		if err != nil {
+			log.Printf("proxy: %v, buffering body instead", err)
+		}
+	}
+	if spooled == nil && len(respBody) > spoolThreshold {
+		rest, _ := io.ReadAll(resp.Body)
+		respBody = append(respBody, rest...)
+	}
+	resp.Body.Close()
+	if spooled != nil {
+		respBody = nil
+		resp.Body = io.NopCloser(strings.NewReader(""))
+		resp.ContentLength = spooled.size
+	} else {
+		resp.Body = io.NopCloser(strings.NewReader(string(respBody)))
+		resp.ContentLength = int64(len(respBody))
+	}
+	resp.Header.Del("Transfer-Encoding")
+	resp.TransferEncoding = nil
+	p.HeaderStrip.Apply(resp.Header)
+
+	respDump, err := network.DumpResponse(resp)
+	if err == nil {
+		// Match/replace would have to rewrite the file; spooled bodies are
+		// passed through untouched.
+		if rewritten := p.MatchReplace.ApplyToResponse(respDump); spooled == nil && rewritten != respDump {
+			if modified, err := network.ParseResponseDump(rewritten); err == nil {
+				resp = modified
+				respDump = rewritten
+				_, newBody := network.SplitDump(respDump)
+				respBody = []byte(newBody)
+				resp.ContentLength = int64(len(respBody))
+			} else {
+				log.Printf("proxy: match/replace produced invalid response, using original: %v", err)
+			}
+		}
+		// Post-response hooks may veto the exchange or rewrite the response
+		// (skipped for spooled bodies, as with match/replace).
+		if hookDump, vetoed := p.Hooks.Run(extension.PhasePostResponse, tx, respDump); vetoed {
+			return nil, true
+		} else if spooled == nil && hookDump != respDump {
+			if modified, err := network.ParseResponseDump(hookDump); err == nil {
+				resp = modified
+				respDump = hookDump
+				_, newBody := network.SplitDump(respDump)
+				respBody = []byte(newBody)
+				resp.ContentLength = int64(len(respBody))
+			} else {
+				log.Printf("proxy: extension produced invalid response, using original: %v", err)
+			}
+		}
+		// Park the response for editing when the forwarder asked for it or an
+		// enabled rule targets responses (spooled bodies pass through, as
+		// with match/replace).
+		if spooled == nil && !interceptResponse && p.interceptEnabled.Load() && p.InterceptRules.HasResponseRules() {
+			interceptResponse = p.InterceptRules.ShouldIntercept(DirectionResponse, req.Method, req.Host, req.URL.Path, resp.Header.Get("Content-Type"))
+		}
+		if spooled == nil && interceptResponse {
+			displayOps, forwardOps := p.InterceptRules.TransformOps(DirectionResponse, req.Method, req.Host, req.URL.Path, resp.Header.Get("Content-Type"))
+			displayDump := respDump
+			if len(displayOps) > 0 {
+				if out, err := applyTamperOps(displayOps, respDump); err == nil {
+					displayDump = out
+				} else {
+					log.Printf("proxy: intercept display transform failed, showing raw response: %v", err)
+					forwardOps = nil
+				}
+			}
+			decision := <-p.InterceptResponses.Add(&InterceptedResponse{
+				ID:            network.NewID(),
+				TransactionID: tx.ID,
+				Host:          req.Host,
+				Method:        req.Method,
+				Path:          req.URL.Path,
+				StatusCode:    resp.StatusCode,
+				ResponseDump:  displayDump,
+				ReceivedAt:    time.Now(),
+			})
+			if !decision.forward {
+				return nil, true
+			}
+			finalDump := decision.dump
+			if finalDump == "" {
+				finalDump = displayDump
+			}
+			if len(forwardOps) > 0 {
+				if out, err := applyTamperOps(forwardOps, finalDump); err == nil {
+					finalDump = out
+				} else {
+					log.Printf("proxy: intercept forward transform failed, using displayed response: %v", err)
+				}
+			}
+			if finalDump != respDump {
+				if modified, err := network.ParseResponseDump(finalDump); err == nil {
+					resp = modified
+					respDump = finalDump
+					_, newBody := network.SplitDump(respDump)
+					respBody = []byte(newBody)
+					resp.ContentLength = int64(len(respBody))
+				} else {
+					log.Printf("proxy: modified response dump is invalid, using original: %v", err)
+				}
+			}
+		}
+		tx.Response = &network.HTTPResponse{
+			Dump:       respDump,
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			BodySize:   len(respBody),
+			BodyHash:   network.HashBody(respBody),
+		}
+		if spooled != nil {
+			tx.Response.BodySize = int(spooled.size)
+			tx.Response.BodyHash = spooled.hash
+			tx.Response.BodyFile = spooled.path
+		}
+		if record {
+			p.store.Update(tx)
+			events.Emit("proxy:response", tx.Summary())
+		}
+	}
+	if record {
+		p.notifyObservers(tx)
+	}
+	if spooled != nil {
+		f, err := os.Open(spooled.path)
+		if err != nil {
+			log.Printf("proxy: failed to reopen spool file: %v", err)
+			return nil, false
+		}
+		resp.Body = f
+	} else {
+		resp.Body = io.NopCloser(strings.NewReader(string(respBody)))
+	}
+	return resp, false
+}
+
+func cloneWithBody(req *http.Request, body []byte) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(strings.NewReader(string(body)))
+	clone.ContentLength = int64(len(body))
+	// The body has been decoded at this point; dump chunked requests with a
+	// Content-Length so the recorded dump stays editable without the user
+	// having to maintain chunk framing by hand.
+	clone.TransferEncoding = nil
+	clone.Header.Del("Transfer-Encoding")
+	return clone
+}
+
+func isClosedErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "use of closed network connection")
+}
@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// rawForwardTimeout bounds a raw forward end to end; the raw socket has no
+// transport machinery to time out for us.
+const rawForwardTimeout = 30 * time.Second
+
+// SetPreserveHeaderBytes toggles byte-for-byte forwarding: the request dump
+// is written to the upstream socket exactly as captured (or edited), keeping
+// the original header order and casing. Go's transport normalizes both,
+// which breaks servers and WAF-evasion tests that depend on exact bytes.
+// Raw forwards bypass connection pooling and retries.
+func (p *ProxyServer) SetPreserveHeaderBytes(enabled bool) {
+	p.preserveHeaderBytes.Store(enabled)
+}
+
+// rawForward writes dump to the request's target byte-for-byte and parses
+// the response. The response body is fully buffered so the connection can
+// be closed before the caller consumes it.
+func (p *ProxyServer) rawForward(host string, isTLS bool, dump string) (*http.Response, error) {
+	addr := host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		if isTLS {
+			addr = net.JoinHostPort(addr, "443")
+		} else {
+			addr = net.JoinHostPort(addr, "80")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rawForwardTimeout)
+	defer cancel()
+	conn, err := p.Hosts.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(rawForwardTimeout))
+
+	if isTLS {
+		cfg := p.transport.TLSClientConfig.Clone()
+		cfg.ServerName, _, _ = net.SplitHostPort(addr)
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, fmt.Errorf("TLS handshake with %s failed: %w", addr, err)
+		}
+		conn = tlsConn
+	}
+
+	if _, err := conn.Write([]byte(dump)); err != nil {
+		return nil, fmt.Errorf("failed to write raw request: %w", err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(strings.NewReader(string(body)))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Transfer-Encoding")
+	resp.TransferEncoding = nil
+	return resp, nil
+}
@@ -0,0 +1,95 @@
+package proxy
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// remoteAccess guards a proxy bound to a non-loopback address: clients must
+// come from the allowlist, and when a token is set they must present it.
+// Loopback clients are always trusted.
+type remoteAccess struct {
+	mu    sync.RWMutex
+	allow []*net.IPNet
+	token string
+}
+
+// SetRemoteAccess configures which remote clients may use the proxy.
+// Entries are IPs or CIDRs (e.g. "192.168.1.23", "10.0.0.0/8"); token, when
+// non-empty, must additionally be presented in the Proxy-Authorization
+// header ("Bearer <token>") or an X-Gleip-Token header.
+func (p *ProxyServer) SetRemoteAccess(clients []string, token string) error {
+	var nets []*net.IPNet
+	for _, entry := range clients {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil {
+				bits := 32
+				if ip.To4() == nil {
+					bits = 128
+				}
+				entry = fmt.Sprintf("%s/%d", entry, bits)
+			}
+		}
+		_, ipnet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return fmt.Errorf("invalid allowlist entry %q: %w", entry, err)
+		}
+		nets = append(nets, ipnet)
+	}
+	p.remote.mu.Lock()
+	p.remote.allow = nets
+	p.remote.token = token
+	p.remote.mu.Unlock()
+	return nil
+}
+
+// remoteClientAllowed decides whether a request may use the proxy. It runs
+// once per plain request and once per CONNECT; requests inside an
+// established tunnel inherit the tunnel's authorization.
+func (p *ProxyServer) remoteClientAllowed(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+
+	p.remote.mu.RLock()
+	allow := p.remote.allow
+	token := p.remote.token
+	p.remote.mu.RUnlock()
+
+	allowed := false
+	for _, ipnet := range allow {
+		if ipnet.Contains(ip) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return false
+	}
+	if token == "" {
+		return true
+	}
+	presented := r.Header.Get("X-Gleip-Token")
+	if presented == "" {
+		if bearer, found := strings.CutPrefix(r.Header.Get("Proxy-Authorization"), "Bearer "); found {
+			presented = bearer
+		}
+	}
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
@@ -0,0 +1,69 @@
+package proxy
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// SetRetryPolicy configures automatic retries of forwarded requests that
+// fail with transient transport errors. A zero policy disables retrying.
+func (p *ProxyServer) SetRetryPolicy(policy network.RetryPolicy) {
+	p.retryMu.Lock()
+	defer p.retryMu.Unlock()
+	p.retry = policy
+}
+
+func (p *ProxyServer) retryPolicy() network.RetryPolicy {
+	p.retryMu.Lock()
+	defer p.retryMu.Unlock()
+	return p.retry
+}
+
+// forward sends req upstream, retrying transient failures (resets,
+// timeouts) per the configured policy. Each failed attempt is recorded on
+// the transaction so retried exchanges are visible in history.
+func (p *ProxyServer) forward(req *http.Request, tx *network.HTTPTransaction, record bool) (*http.Response, error) {
+	policy := p.retryPolicy()
+	if policy.MaxRetries <= 0 {
+		return p.transport.RoundTrip(req)
+	}
+
+	// Bodies parsed from dumps are not replayable; buffer once so every
+	// attempt sends the same bytes.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(policy.Backoff(attempt))
+		}
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		resp, err := p.transport.RoundTrip(req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !network.IsTransientErr(err) || attempt == policy.MaxRetries {
+			break
+		}
+		tx.Retries = append(tx.Retries, network.RetryAttempt{Error: err.Error(), At: time.Now()})
+		if record {
+			p.store.Update(tx)
+		}
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,76 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RulePack is a shareable bundle of match/replace and intercept rules,
+// exported as JSON so teams can standardize rule sets across projects and
+// machines.
+type RulePack struct {
+	Name              string              `json:"name"`
+	Description       string              `json:"description,omitempty"`
+	MatchReplaceRules []*MatchReplaceRule `json:"matchReplaceRules,omitempty"`
+	InterceptRules    []*InterceptRule    `json:"interceptRules,omitempty"`
+}
+
+// Validate checks every rule in the pack is executable.
+func (p *RulePack) Validate() error {
+	if len(p.MatchReplaceRules) == 0 && len(p.InterceptRules) == 0 {
+		return fmt.Errorf("rule pack %q contains no rules", p.Name)
+	}
+	for _, rule := range p.MatchReplaceRules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("match/replace rule %q: %w", rule.Name, err)
+		}
+	}
+	for _, rule := range p.InterceptRules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("intercept rule %q: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// ParseRulePack decodes an exported pack and validates it.
+func ParseRulePack(data []byte) (*RulePack, error) {
+	pack := &RulePack{}
+	if err := json.Unmarshal(data, pack); err != nil {
+		return nil, fmt.Errorf("failed to parse rule pack: %w", err)
+	}
+	if err := pack.Validate(); err != nil {
+		return nil, err
+	}
+	return pack, nil
+}
+
+// BuiltinRulePacks returns the shipped rule library. Rules come disabled so
+// importing a pack never changes traffic until the user opts in.
+func BuiltinRulePacks() []*RulePack {
+	return []*RulePack{
+		{
+			Name:        "Remove caching headers",
+			Description: "Strips conditional request headers so responses are always served fresh instead of 304s.",
+			MatchReplaceRules: []*MatchReplaceRule{
+				{Name: "Drop If-None-Match", Target: TargetRequestHeaders, IsRegex: true, Match: `(?mi)^If-None-Match:.*\r?\n?`, Replace: ""},
+				{Name: "Drop If-Modified-Since", Target: TargetRequestHeaders, IsRegex: true, Match: `(?mi)^If-Modified-Since:.*\r?\n?`, Replace: ""},
+				{Name: "Drop Cache-Control", Target: TargetRequestHeaders, IsRegex: true, Match: `(?mi)^Cache-Control:.*\r?\n?`, Replace: ""},
+			},
+		},
+		{
+			Name:        "Add test marker header",
+			Description: "Tags every request with X-Pentest so server-side teams can distinguish test traffic.",
+			MatchReplaceRules: []*MatchReplaceRule{
+				{Name: "Add X-Pentest", Target: TargetRequestHeaders, Match: "", Replace: "X-Pentest: gleip"},
+			},
+		},
+		{
+			Name:        "Downgrade HSTS",
+			Description: "Rewrites Strict-Transport-Security to max-age=0 so the browser doesn't pin HTTPS during testing.",
+			MatchReplaceRules: []*MatchReplaceRule{
+				{Name: "Expire HSTS", Target: TargetResponseHeaders, IsRegex: true, Match: `(?mi)^Strict-Transport-Security:.*$`, Replace: "Strict-Transport-Security: max-age=0"},
+			},
+		},
+	}
+}
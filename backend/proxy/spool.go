@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gleip/backend/network"
+)
+
+// spoolThreshold is the largest response body kept inline in the transaction
+// dump. Bigger bodies are streamed to a spool file on disk so a multi-hundred
+// megabyte download doesn't live in memory.
+const spoolThreshold = 8 << 20
+
+// spooledBody describes a response body that was written to disk instead of
+// being buffered.
+type spooledBody struct {
+	path string
+	size int64
+	hash string
+}
+
+// SetSpoolDir sets the directory used for large-body spool files. Spooling is
+// disabled (bodies are fully buffered) until a directory is configured.
+func (p *ProxyServer) SetSpoolDir(dir string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.spoolDir = dir
+}
+
+func (p *ProxyServer) getSpoolDir() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.spoolDir
+}
+
+// spoolBody streams an oversized response body to a spool file: the prefix
+// already read from the wire, then the remainder. The body is hashed as it is
+// written so integrity tracking works the same as for buffered bodies.
+func (p *ProxyServer) spoolBody(prefix []byte, rest io.Reader) (*spooledBody, error) {
+	dir := p.getSpoolDir()
+	if dir == "" {
+		return nil, fmt.Errorf("no spool directory configured")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+	path := filepath.Join(dir, network.NewID()+".body")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	hash := sha256.New()
+	out := io.MultiWriter(f, hash)
+	n, err := out.Write(prefix)
+	size := int64(n)
+	if err == nil {
+		var copied int64
+		copied, err = io.Copy(out, rest)
+		size += copied
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to spool response body: %w", err)
+	}
+	return &spooledBody{path: path, size: size, hash: hex.EncodeToString(hash.Sum(nil))}, nil
+}
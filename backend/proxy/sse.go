@@ -0,0 +1,117 @@
+package proxy
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"gleip/backend/events"
+	"gleip/backend/network"
+)
+
+// isEventStream reports whether a response is a Server-Sent Events stream.
+func isEventStream(contentType string) bool {
+	mediaType := contentType
+	if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+		mediaType = mediaType[:i]
+	}
+	return strings.EqualFold(strings.TrimSpace(mediaType), "text/event-stream")
+}
+
+// sseRecorder wraps an event-stream body: bytes pass through to the client
+// unchanged while complete events are parsed out and appended to the
+// transaction, so the stream can be inspected while it is still open.
+type sseRecorder struct {
+	inner io.ReadCloser
+	tx    *network.HTTPTransaction
+	store network.TransactionStore
+	done  func(*network.HTTPTransaction)
+
+	buf     []byte
+	pending network.SSEEvent
+	hasData bool
+}
+
+func newSSERecorder(inner io.ReadCloser, tx *network.HTTPTransaction, store network.TransactionStore, done func(*network.HTTPTransaction)) *sseRecorder {
+	return &sseRecorder{inner: inner, tx: tx, store: store, done: done}
+}
+
+func (r *sseRecorder) Read(p []byte) (int, error) {
+	n, err := r.inner.Read(p)
+	if n > 0 {
+		r.consume(p[:n])
+	}
+	return n, err
+}
+
+func (r *sseRecorder) Close() error {
+	err := r.inner.Close()
+	if r.done != nil {
+		r.done(r.tx)
+		r.done = nil
+	}
+	return err
+}
+
+// consume feeds streamed bytes into the line-based SSE parser.
+func (r *sseRecorder) consume(data []byte) {
+	r.buf = append(r.buf, data...)
+	for {
+		i := indexNewline(r.buf)
+		if i < 0 {
+			return
+		}
+		line := strings.TrimSuffix(string(r.buf[:i]), "\r")
+		r.buf = r.buf[i+1:]
+		r.consumeLine(line)
+	}
+}
+
+// consumeLine handles one line per the SSE wire format: field lines
+// accumulate into the pending event, a blank line dispatches it.
+func (r *sseRecorder) consumeLine(line string) {
+	if line == "" {
+		if r.hasData || r.pending.Event != "" || r.pending.ID != "" {
+			r.dispatch()
+		}
+		return
+	}
+	if strings.HasPrefix(line, ":") {
+		return // comment / keep-alive
+	}
+	field, value := line, ""
+	if i := strings.IndexByte(line, ':'); i >= 0 {
+		field = line[:i]
+		value = strings.TrimPrefix(line[i+1:], " ")
+	}
+	switch field {
+	case "event":
+		r.pending.Event = value
+	case "data":
+		if r.hasData {
+			r.pending.Data += "\n"
+		}
+		r.pending.Data += value
+		r.hasData = true
+	case "id":
+		r.pending.ID = value
+	}
+}
+
+func (r *sseRecorder) dispatch() {
+	r.pending.ReceivedAt = time.Now()
+	r.tx.SSEEvents = append(r.tx.SSEEvents, r.pending)
+	r.store.Update(r.tx)
+	events.Emit("proxy:sse", map[string]any{"transactionId": r.tx.ID, "events": len(r.tx.SSEEvents)})
+	r.pending = network.SSEEvent{}
+	r.hasData = false
+}
+
+func indexNewline(b []byte) int {
+	for i, c := range b {
+		if c == '\n' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,142 @@
+package proxy
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// TamperOpType enumerates the edit operations that can be recorded against
+// an intercepted request and replayed later.
+type TamperOpType string
+
+const (
+	TamperAddHeader        TamperOpType = "add-header"
+	TamperSetHeader        TamperOpType = "set-header"
+	TamperRemoveHeader     TamperOpType = "remove-header"
+	TamperReplace          TamperOpType = "replace"
+	TamperRegexReplace     TamperOpType = "regex-replace"
+	TamperBase64EncodeBody TamperOpType = "base64-encode-body"
+	TamperBase64DecodeBody TamperOpType = "base64-decode-body"
+)
+
+// TamperOp is one recorded edit. Name/Value are interpreted per op type:
+// header ops use Name+Value, replace ops use Name as the pattern and Value
+// as the replacement.
+type TamperOp struct {
+	Type  TamperOpType `json:"type"`
+	Name  string       `json:"name,omitempty"`
+	Value string       `json:"value,omitempty"`
+}
+
+// Apply performs the operation on a raw request dump and returns the result.
+// Operations that change the body reframe Content-Length so the tampered
+// dump re-parses with the full body intact.
+func (op TamperOp) Apply(dump string) (string, error) {
+	headers, body := network.SplitDump(dump)
+	switch op.Type {
+	case TamperAddHeader:
+		headers += "\r\n" + op.Name + ": " + op.Value
+	case TamperSetHeader:
+		headers = removeHeaderLines(headers, op.Name) + "\r\n" + op.Name + ": " + op.Value
+	case TamperRemoveHeader:
+		headers = removeHeaderLines(headers, op.Name)
+	case TamperReplace:
+		return reframeIfBodyChanged(dump, strings.ReplaceAll(dump, op.Name, op.Value)), nil
+	case TamperRegexReplace:
+		re, err := network.CompileCached(op.Name)
+		if err != nil {
+			return "", fmt.Errorf("invalid tamper regex %q: %w", op.Name, err)
+		}
+		return reframeIfBodyChanged(dump, re.ReplaceAllString(dump, op.Value)), nil
+	case TamperBase64EncodeBody:
+		encoded := base64.StdEncoding.EncodeToString([]byte(body))
+		if encoded == body {
+			return dump, nil
+		}
+		return network.ReplaceDumpBody(dump, []byte(encoded)), nil
+	case TamperBase64DecodeBody:
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(body))
+		if err != nil {
+			return "", fmt.Errorf("body is not valid base64: %w", err)
+		}
+		return network.ReplaceDumpBody(dump, decoded), nil
+	default:
+		return "", fmt.Errorf("unknown tamper operation %q", op.Type)
+	}
+	return headers + "\r\n\r\n" + body, nil
+}
+
+// reframeIfBodyChanged updates Content-Length when a whole-dump replacement
+// touched the body; header-only replacements pass through untouched.
+func reframeIfBodyChanged(before, after string) string {
+	_, oldBody := network.SplitDump(before)
+	_, newBody := network.SplitDump(after)
+	if newBody == oldBody {
+		return after
+	}
+	return network.ReplaceDumpBody(after, []byte(newBody))
+}
+
+// applyTamperOps runs a chain of operations in order against a dump.
+func applyTamperOps(ops []TamperOp, dump string) (string, error) {
+	for i, op := range ops {
+		next, err := op.Apply(dump)
+		if err != nil {
+			return "", fmt.Errorf("op %d: %w", i+1, err)
+		}
+		dump = next
+	}
+	return dump, nil
+}
+
+// InterceptMacro is a saved sequence of tamper operations, bound to the
+// endpoint it was recorded on so it can be offered for matching intercepts.
+type InterceptMacro struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Method    string     `json:"method,omitempty"`
+	Host      string     `json:"host"`
+	Path      string     `json:"path"`
+	Ops       []TamperOp `json:"ops"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// Matches reports whether the macro was recorded against the same endpoint
+// as the given intercepted request. An empty Method matches any method.
+func (m *InterceptMacro) Matches(req *InterceptedRequest) bool {
+	if m.Method != "" && !strings.EqualFold(m.Method, req.Method) {
+		return false
+	}
+	return strings.EqualFold(m.Host, req.Host) && m.Path == req.Path
+}
+
+// Apply runs the macro's operations in order against a request dump.
+func (m *InterceptMacro) Apply(dump string) (string, error) {
+	for i, op := range m.Ops {
+		next, err := op.Apply(dump)
+		if err != nil {
+			return "", fmt.Errorf("macro %q op %d: %w", m.Name, i+1, err)
+		}
+		dump = next
+	}
+	return dump, nil
+}
+
+// removeHeaderLines drops all lines for the named header from a header
+// block, leaving the request line untouched.
+func removeHeaderLines(headers, name string) string {
+	lines := strings.Split(strings.ReplaceAll(headers, "\r\n", "\n"), "\n")
+	out := lines[:1]
+	prefix := strings.ToLower(name) + ":"
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(strings.ToLower(line), prefix) {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\r\n")
+}
@@ -0,0 +1,69 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// timingTrace collects connection-phase timestamps for one round trip via
+// net/http/httptrace. Callbacks can fire on transport goroutines, so access
+// is serialized.
+type timingTrace struct {
+	mu           sync.Mutex
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+}
+
+func newTimingTrace() *timingTrace {
+	return &timingTrace{}
+}
+
+func (t *timingTrace) clientTrace() *httptrace.ClientTrace {
+	stamp := func(field *time.Time) {
+		t.mu.Lock()
+		if field.IsZero() {
+			*field = time.Now()
+		}
+		t.mu.Unlock()
+	}
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { stamp(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { stamp(&t.dnsDone) },
+		ConnectStart:         func(string, string) { stamp(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { stamp(&t.connectDone) },
+		TLSHandshakeStart:    func() { stamp(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { stamp(&t.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { stamp(&t.wroteRequest) },
+		GotFirstResponseByte: func() { stamp(&t.firstByte) },
+	}
+}
+
+// breakdown converts the collected timestamps into a per-phase breakdown.
+func (t *timingTrace) breakdown(total time.Duration) *network.TimingBreakdown {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b := &network.TimingBreakdown{TotalMS: total.Milliseconds()}
+	if !t.dnsStart.IsZero() && !t.dnsDone.IsZero() {
+		b.DNSMS = t.dnsDone.Sub(t.dnsStart).Milliseconds()
+	}
+	if !t.connectStart.IsZero() && !t.connectDone.IsZero() {
+		b.ConnectMS = t.connectDone.Sub(t.connectStart).Milliseconds()
+	}
+	if !t.tlsStart.IsZero() && !t.tlsDone.IsZero() {
+		b.TLSMS = t.tlsDone.Sub(t.tlsStart).Milliseconds()
+	}
+	if !t.wroteRequest.IsZero() && !t.firstByte.IsZero() {
+		b.TTFBMS = t.firstByte.Sub(t.wroteRequest).Milliseconds()
+	}
+	return b
+}
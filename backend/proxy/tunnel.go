@@ -0,0 +1,181 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"gleip/backend/events"
+	"gleip/backend/network"
+)
+
+// tunnelCaptureLimit caps how much raw tunnel data is kept per direction
+// when byte capture is on.
+const tunnelCaptureLimit = 64 << 10
+
+// tunnelRecordLimit caps the in-memory tunnel log; older records are
+// dropped first.
+const tunnelRecordLimit = 1024
+
+// TunnelRecord logs a CONNECT tunnel that carried something other than
+// HTTP, so thick-client traffic is visible instead of silently relayed.
+type TunnelRecord struct {
+	ID     string `json:"id"`
+	Client string `json:"client"`
+	Host   string `json:"host"`
+	// Protocol is "raw" for tunnels that never spoke TLS and "tls" for TLS
+	// tunnels carrying a non-HTTP protocol inside.
+	Protocol      string        `json:"protocol"`
+	StartedAt     time.Time     `json:"startedAt"`
+	Duration      time.Duration `json:"duration"`
+	BytesToServer int64         `json:"bytesToServer"`
+	BytesToClient int64         `json:"bytesToClient"`
+	// ClientBytes and ServerBytes hold the first bytes each side sent when
+	// capture is enabled (capped at tunnelCaptureLimit).
+	ClientBytes []byte `json:"clientBytes,omitempty"`
+	ServerBytes []byte `json:"serverBytes,omitempty"`
+}
+
+// tunnelLog accumulates TunnelRecords on the proxy.
+type tunnelLog struct {
+	mu      sync.Mutex
+	records []*TunnelRecord
+}
+
+func (l *tunnelLog) add(record *TunnelRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, record)
+	if len(l.records) > tunnelRecordLimit {
+		l.records = l.records[len(l.records)-tunnelRecordLimit:]
+	}
+}
+
+func (l *tunnelLog) all() []*TunnelRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]*TunnelRecord(nil), l.records...)
+}
+
+// Tunnels returns the recorded non-HTTP CONNECT tunnels, oldest first.
+func (p *ProxyServer) Tunnels() []*TunnelRecord {
+	return p.tunnels.all()
+}
+
+// SetTunnelByteCapture toggles keeping the first bytes of non-HTTP tunnel
+// traffic alongside the endpoint/byte-count record.
+func (p *ProxyServer) SetTunnelByteCapture(enabled bool) {
+	p.captureTunnelBytes.Store(enabled)
+}
+
+// cappedBuffer collects up to limit bytes and discards the rest.
+type cappedBuffer struct {
+	buf   []byte
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - len(b.buf); remaining > 0 {
+		if len(p) > remaining {
+			b.buf = append(b.buf, p[:remaining]...)
+		} else {
+			b.buf = append(b.buf, p...)
+		}
+	}
+	return len(p), nil
+}
+
+// relayTunnel pumps bytes between a client connection and the upstream
+// host, recording endpoints, byte counts, and (optionally) the raw bytes.
+// viaTLS relays inside TLS: the client side is already a TLS server
+// connection and the upstream side gets a TLS client connection.
+func (p *ProxyServer) relayTunnel(client net.Conn, clientReader io.Reader, host, protocol string, viaTLS bool) {
+	upstream, err := p.dialTunnel(host)
+	if err != nil {
+		return
+	}
+	if viaTLS {
+		tlsUpstream := tls.Client(upstream, &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         stripPort(host),
+		})
+		if err := tlsUpstream.Handshake(); err != nil {
+			upstream.Close()
+			return
+		}
+		upstream = tlsUpstream
+	}
+	defer upstream.Close()
+
+	record := &TunnelRecord{
+		ID:        network.NewID(),
+		Client:    client.RemoteAddr().String(),
+		Host:      host,
+		Protocol:  protocol,
+		StartedAt: time.Now(),
+	}
+	capture := p.captureTunnelBytes.Load()
+	var toServer, toClient io.Writer = upstream, client
+	clientCap := &cappedBuffer{limit: tunnelCaptureLimit}
+	serverCap := &cappedBuffer{limit: tunnelCaptureLimit}
+	if capture {
+		toServer = io.MultiWriter(upstream, clientCap)
+		toClient = io.MultiWriter(client, serverCap)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		n, _ := io.Copy(toClient, upstream)
+		record.BytesToClient = n
+		client.Close()
+		close(done)
+	}()
+	n, _ := io.Copy(toServer, clientReader)
+	record.BytesToServer = n
+	upstream.Close()
+	<-done
+
+	record.Duration = time.Since(record.StartedAt)
+	if capture {
+		record.ClientBytes = clientCap.buf
+		record.ServerBytes = serverCap.buf
+	}
+	p.tunnels.add(record)
+	events.Emit("proxy:tunnel", record)
+}
+
+// dialTunnel opens the upstream side of a relayed tunnel, honoring host
+// overrides.
+func (p *ProxyServer) dialTunnel(host string) (net.Conn, error) {
+	if p.Hosts != nil {
+		return p.Hosts.DialContext(context.Background(), "tcp", host)
+	}
+	return net.DialTimeout("tcp", host, 30*time.Second)
+}
+
+// looksLikeTLS reports whether the first tunnel byte is a TLS handshake
+// record.
+func looksLikeTLS(prefix []byte) bool {
+	return len(prefix) > 0 && prefix[0] == 0x16
+}
+
+// looksLikeHTTP reports whether a tunnel prefix starts with an HTTP method
+// token followed by a space.
+func looksLikeHTTP(prefix []byte) bool {
+	for i, c := range prefix {
+		if c == ' ' {
+			return i > 0
+		}
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+		if i >= 9 {
+			return false
+		}
+	}
+	// Prefix shorter than a full token; all method bytes so far.
+	return len(prefix) > 0
+}
@@ -0,0 +1,145 @@
+// Package scope implements the project-wide target scope: include/exclude
+// patterns over hosts and paths that other subsystems (history views,
+// interception, fuzzing) consult to restrict themselves to the engagement's
+// targets.
+package scope
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"gleip/backend/network"
+)
+
+// Rule is one include or exclude entry. Patterns are glob-style: '*'
+// matches any run of characters. An empty PathPattern matches every path.
+type Rule struct {
+	HostPattern string `json:"hostPattern"`
+	PathPattern string `json:"pathPattern,omitempty"`
+}
+
+// Config is the persisted scope definition. With Enabled false, or with no
+// include rules, everything is considered in scope (minus excludes).
+type Config struct {
+	Enabled bool   `json:"enabled"`
+	Include []Rule `json:"include,omitempty"`
+	Exclude []Rule `json:"exclude,omitempty"`
+}
+
+// Matcher is the runtime form of a Config, safe for concurrent use by proxy
+// goroutines. Patterns are compiled once per Set call.
+type Matcher struct {
+	mu      sync.RWMutex
+	cfg     Config
+	include []compiledRule
+	exclude []compiledRule
+}
+
+type compiledRule struct {
+	host *regexp.Regexp
+	path *regexp.Regexp
+}
+
+// NewMatcher returns a matcher with everything in scope.
+func NewMatcher() *Matcher {
+	return &Matcher{}
+}
+
+// Set replaces the active scope configuration.
+func (m *Matcher) Set(cfg Config) {
+	include := compileRules(cfg.Include)
+	exclude := compileRules(cfg.Exclude)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cfg = cfg
+	m.include = include
+	m.exclude = exclude
+}
+
+// Config returns the active configuration.
+func (m *Matcher) Config() Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cfg
+}
+
+// InScope reports whether host+path falls inside the configured scope.
+// Excludes always win over includes.
+func (m *Matcher) InScope(host, path string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.cfg.Enabled {
+		return true
+	}
+	host = stripPort(host)
+	for _, rule := range m.exclude {
+		if rule.matches(host, path) {
+			return false
+		}
+	}
+	if len(m.include) == 0 {
+		return true
+	}
+	for _, rule := range m.include {
+		if rule.matches(host, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r compiledRule) matches(host, path string) bool {
+	if r.host != nil && !r.host.MatchString(host) {
+		return false
+	}
+	if r.path != nil && !r.path.MatchString(path) {
+		return false
+	}
+	return true
+}
+
+func compileRules(rules []Rule) []compiledRule {
+	out := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		var c compiledRule
+		if rule.HostPattern != "" {
+			c.host = globToRegexp(rule.HostPattern)
+		}
+		if rule.PathPattern != "" {
+			c.path = globToRegexp(rule.PathPattern)
+		}
+		if c.host == nil && c.path == nil {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// MatchGlob reports whether value matches a '*' glob pattern,
+// case-insensitively. It is shared by other subsystems (intercept rules,
+// blocklists) that use the same pattern syntax as scope rules.
+func MatchGlob(pattern, value string) bool {
+	return globToRegexp(pattern).MatchString(value)
+}
+
+// globToRegexp compiles a '*' glob into an anchored, case-insensitive
+// regexp, via the shared pattern cache: MatchGlob runs per transaction in
+// intercept rules and blocklists, often with the same few patterns. Invalid
+// patterns cannot occur since everything else is quoted.
+func globToRegexp(glob string) *regexp.Regexp {
+	parts := strings.Split(glob, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	re, _ := network.CompileCached("(?i)^" + strings.Join(parts, ".*") + "$")
+	return re
+}
+
+func stripPort(host string) string {
+	if idx := strings.IndexByte(host, ':'); idx >= 0 {
+		return host[:idx]
+	}
+	return host
+}
@@ -0,0 +1,114 @@
+// Package settings persists application-level configuration (as opposed to
+// per-project state, which lives in the project file).
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gleip/backend/network"
+)
+
+const settingsFile = "settings.json"
+
+// Settings is the application configuration, stored as JSON in the data
+// directory. Zero values must be usable defaults.
+type Settings struct {
+	ProxyAddr string `json:"proxyAddr"`
+	// ListenerTLS serves TLS on the proxy listener itself, for clients that
+	// speak HTTPS to their proxy.
+	ListenerTLS bool `json:"listenerTLS"`
+	// AcceptProxyProtocol parses HAProxy PROXY protocol v2 headers on
+	// incoming connections when Gleip sits behind a forwarding relay.
+	AcceptProxyProtocol bool `json:"acceptProxyProtocol"`
+	// TLSFingerprint names the ClientHello shape used for outbound TLS
+	// ("" or "go-default" keeps stdlib behavior).
+	TLSFingerprint string `json:"tlsFingerprint,omitempty"`
+	// OutboundTLS forces TLS versions/ciphers on outbound connections, for
+	// legacy servers that fail with Go defaults.
+	OutboundTLS network.OutboundTLSOptions `json:"outboundTLS"`
+	// ConnectionPool tunes outbound connection reuse (per-host limits, idle
+	// timeouts, keep-alive).
+	ConnectionPool network.ConnectionPoolOptions `json:"connectionPool"`
+	// Retry re-sends forwarded requests that fail with transient transport
+	// errors (resets, timeouts).
+	Retry network.RetryPolicy `json:"retry"`
+	// UpstreamProxy chains all outbound traffic through an authenticated
+	// corporate proxy when Addr is set.
+	UpstreamProxy network.UpstreamProxyConfig `json:"upstreamProxy"`
+	// AutoLockMinutes locks the session after this much idle time (0
+	// disables auto-lock).
+	AutoLockMinutes int `json:"autoLockMinutes,omitempty"`
+	// OTLPEndpoint, when set, exports internal spans to this OTLP/HTTP
+	// collector base URL.
+	OTLPEndpoint string `json:"otlpEndpoint,omitempty"`
+	// InterceptAutoForwardSeconds auto-resolves untouched intercepted
+	// requests after this long (0 disables); InterceptAutoDrop drops them
+	// instead of forwarding.
+	InterceptAutoForwardSeconds int  `json:"interceptAutoForwardSeconds,omitempty"`
+	InterceptAutoDrop           bool `json:"interceptAutoDrop,omitempty"`
+	// InterceptQueueLimit caps pending intercepted requests (0 = unlimited);
+	// InterceptOverflowPolicy picks which entry gives way at the cap
+	// ("forward-oldest" or "reject-new").
+	InterceptQueueLimit     int    `json:"interceptQueueLimit,omitempty"`
+	InterceptOverflowPolicy string `json:"interceptOverflowPolicy,omitempty"`
+	// OffloadHistory moves sealed transactions to disk so only summaries
+	// stay in memory during long captures.
+	OffloadHistory bool `json:"offloadHistory,omitempty"`
+	// RemoteAllowedClients and RemoteAccessToken gate non-loopback proxy
+	// clients when ProxyAddr binds a routable interface.
+	RemoteAllowedClients []string `json:"remoteAllowedClients,omitempty"`
+	RemoteAccessToken    string   `json:"remoteAccessToken,omitempty"`
+}
+
+// Manager loads, caches, and saves Settings.
+type Manager struct {
+	mu       sync.RWMutex
+	dataDir  string
+	settings Settings
+}
+
+// NewManager loads settings from dataDir, falling back to defaults when no
+// settings file exists yet.
+func NewManager(dataDir string) (*Manager, error) {
+	m := &Manager{dataDir: dataDir, settings: Settings{ProxyAddr: "127.0.0.1:9090"}}
+	data, err := os.ReadFile(filepath.Join(dataDir, settingsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &m.settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+	return m, nil
+}
+
+// Get returns a copy of the current settings.
+func (m *Manager) Get() Settings {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.settings
+}
+
+// Update applies fn to the settings under lock and persists the result.
+func (m *Manager) Update(fn func(*Settings)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(&m.settings)
+	data, err := json.MarshalIndent(m.settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize settings: %w", err)
+	}
+	if err := os.MkdirAll(m.dataDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create data dir: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(m.dataDir, settingsFile), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Package stats maintains incrementally-updated traffic aggregates, so
+// dashboards can show per-host totals without rescanning history.
+package stats
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"gleip/backend/network"
+)
+
+// HostStats aggregates the traffic observed for one host.
+type HostStats struct {
+	Host string `json:"host"`
+	// RequestCount is the total number of transactions seen.
+	RequestCount int `json:"requestCount"`
+	// ErrorCount counts responses with a 4xx or 5xx status.
+	ErrorCount int `json:"errorCount"`
+	// TotalBytes sums response body sizes.
+	TotalBytes int64 `json:"totalBytes"`
+	// Methods counts transactions per HTTP method.
+	Methods map[string]int `json:"methods"`
+	// Paths counts transactions per request path.
+	Paths     map[string]int `json:"paths"`
+	FirstSeen time.Time      `json:"firstSeen"`
+	LastSeen  time.Time      `json:"lastSeen"`
+}
+
+// Aggregator accumulates HostStats as traffic arrives. It is safe for
+// concurrent use by the proxy goroutines.
+type Aggregator struct {
+	mu     sync.Mutex
+	byHost map[string]*HostStats
+}
+
+// NewAggregator returns an empty aggregator.
+func NewAggregator() *Aggregator {
+	return &Aggregator{byHost: make(map[string]*HostStats)}
+}
+
+// Record folds one transaction into the aggregates.
+func (a *Aggregator) Record(tx *network.HTTPTransaction) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	host := tx.Request.Host
+	s, ok := a.byHost[host]
+	if !ok {
+		s = &HostStats{
+			Host:      host,
+			Methods:   make(map[string]int),
+			Paths:     make(map[string]int),
+			FirstSeen: tx.Timestamp,
+		}
+		a.byHost[host] = s
+	}
+	s.RequestCount++
+	s.Methods[tx.Request.Method]++
+	s.Paths[tx.Request.Path]++
+	if tx.Response != nil {
+		s.TotalBytes += int64(tx.Response.BodySize)
+		if tx.Response.StatusCode >= 400 {
+			s.ErrorCount++
+		}
+	}
+	if tx.Timestamp.After(s.LastSeen) {
+		s.LastSeen = tx.Timestamp
+	}
+	if tx.Timestamp.Before(s.FirstSeen) {
+		s.FirstSeen = tx.Timestamp
+	}
+}
+
+// Snapshot returns a copy of the aggregates, busiest hosts first.
+func (a *Aggregator) Snapshot() []HostStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]HostStats, 0, len(a.byHost))
+	for _, s := range a.byHost {
+		copied := *s
+		copied.Methods = make(map[string]int, len(s.Methods))
+		for k, v := range s.Methods {
+			copied.Methods[k] = v
+		}
+		copied.Paths = make(map[string]int, len(s.Paths))
+		for k, v := range s.Paths {
+			copied.Paths[k] = v
+		}
+		out = append(out, copied)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].RequestCount != out[j].RequestCount {
+			return out[i].RequestCount > out[j].RequestCount
+		}
+		return out[i].Host < out[j].Host
+	})
+	return out
+}
+
+// Reset discards all aggregates (e.g. when history is cleared or a project
+// is switched).
+func (a *Aggregator) Reset() {
+	a.mu.Lock()
+	a.byHost = make(map[string]*HostStats)
+	a.mu.Unlock()
+}
@@ -0,0 +1,115 @@
+// Package telemetry classifies traffic to known analytics and telemetry
+// domains so third-party noise can be tagged and filtered out of history,
+// exports, and persistence.
+package telemetry
+
+import (
+	"strings"
+	"sync"
+)
+
+// Tag is applied to transactions the classifier identifies as telemetry.
+const Tag = "telemetry"
+
+// defaultDomains is the built-in offline list of analytics/telemetry
+// providers, matched as domain suffixes. It can be replaced wholesale via
+// SetDomains when a newer list is available.
+var defaultDomains = []string{
+	"adjust.com",
+	"amplitude.com",
+	"app-measurement.com",
+	"appsflyer.com",
+	"branch.io",
+	"braze.com",
+	"bugsnag.com",
+	"clarity.ms",
+	"crashlytics.com",
+	"datadoghq.com",
+	"demdex.net",
+	"doubleclick.net",
+	"facebook.net",
+	"fullstory.com",
+	"google-analytics.com",
+	"googleadservices.com",
+	"googlesyndication.com",
+	"googletagmanager.com",
+	"hotjar.com",
+	"intercom.io",
+	"launchdarkly.com",
+	"mixpanel.com",
+	"newrelic.com",
+	"nr-data.net",
+	"omtrdc.net",
+	"optimizely.com",
+	"scorecardresearch.com",
+	"segment.com",
+	"segment.io",
+	"sentry.io",
+}
+
+// Classifier decides whether a hostname is a telemetry endpoint. The
+// project-level allow list always wins (never tagged), then the deny list
+// (always tagged), then the offline domain list. Safe for concurrent use.
+type Classifier struct {
+	mu      sync.RWMutex
+	domains []string
+	allow   []string
+	deny    []string
+}
+
+// NewClassifier returns a classifier using the built-in domain list.
+func NewClassifier() *Classifier {
+	return &Classifier{domains: defaultDomains}
+}
+
+// SetDomains replaces the offline domain list; nil restores the built-in
+// one.
+func (c *Classifier) SetDomains(domains []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if domains == nil {
+		c.domains = defaultDomains
+		return
+	}
+	c.domains = domains
+}
+
+// SetProjectLists installs the project's allow and deny overrides.
+func (c *Classifier) SetProjectLists(allow, deny []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.allow = allow
+	c.deny = deny
+}
+
+// IsTelemetry reports whether host (with or without port) is a telemetry
+// endpoint under the current lists.
+func (c *Classifier) IsTelemetry(host string) bool {
+	host = strings.ToLower(host)
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if matchesAny(host, c.allow) {
+		return false
+	}
+	if matchesAny(host, c.deny) {
+		return true
+	}
+	return matchesAny(host, c.domains)
+}
+
+// matchesAny reports whether host equals or is a subdomain of any entry.
+func matchesAny(host string, domains []string) bool {
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" {
+			continue
+		}
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+// Package tracing records internal spans (proxy handling, flow step
+// execution) and exports them to an OTLP/HTTP collector, so performance
+// issues can be diagnosed with standard observability tooling. Only the
+// JSON encoding of OTLP traces is implemented; no external SDK is pulled
+// in.
+package tracing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// flushInterval is how often buffered spans are posted to the collector.
+const flushInterval = 5 * time.Second
+
+// bufferLimit caps buffered spans between flushes; beyond it the oldest
+// spans are dropped rather than growing without bound.
+const bufferLimit = 4096
+
+// Span is one finished unit of internal work.
+type Span struct {
+	TraceID string
+	SpanID  string
+	Name    string
+	Start   time.Time
+	End     time.Time
+	Attrs   map[string]string
+}
+
+// Tracer buffers spans and exports them to an OTLP/HTTP endpoint. A tracer
+// with no endpoint discards spans cheaply, so callers can record
+// unconditionally.
+type Tracer struct {
+	mu       sync.Mutex
+	endpoint string
+	buffer   []Span
+	stop     chan struct{}
+	client   *http.Client
+}
+
+// NewTracer returns a disabled tracer; SetEndpoint turns exporting on.
+func NewTracer() *Tracer {
+	return &Tracer{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// SetEndpoint points the tracer at an OTLP/HTTP collector base URL (e.g.
+// "http://localhost:4318"); spans are posted to <endpoint>/v1/traces. An
+// empty endpoint disables exporting and drops buffered spans.
+func (t *Tracer) SetEndpoint(endpoint string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endpoint = endpoint
+	if endpoint == "" {
+		t.buffer = nil
+		if t.stop != nil {
+			close(t.stop)
+			t.stop = nil
+		}
+		return
+	}
+	if t.stop == nil {
+		t.stop = make(chan struct{})
+		go t.flushLoop(t.stop)
+	}
+}
+
+// Enabled reports whether spans are being exported.
+func (t *Tracer) Enabled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.endpoint != ""
+}
+
+// Record buffers a finished span. It is a no-op while no endpoint is
+// configured.
+func (t *Tracer) Record(name string, start, end time.Time, attrs map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.endpoint == "" {
+		return
+	}
+	t.buffer = append(t.buffer, Span{
+		TraceID: randomHex(16),
+		SpanID:  randomHex(8),
+		Name:    name,
+		Start:   start,
+		End:     end,
+		Attrs:   attrs,
+	})
+	if len(t.buffer) > bufferLimit {
+		t.buffer = t.buffer[len(t.buffer)-bufferLimit:]
+	}
+}
+
+func (t *Tracer) flushLoop(stop chan struct{}) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			t.flush()
+			return
+		case <-ticker.C:
+			t.flush()
+		}
+	}
+}
+
+func (t *Tracer) flush() {
+	t.mu.Lock()
+	spans := t.buffer
+	t.buffer = nil
+	endpoint := t.endpoint
+	t.mu.Unlock()
+	if len(spans) == 0 || endpoint == "" {
+		return
+	}
+	body, err := json.Marshal(otlpPayload(spans))
+	if err != nil {
+		return
+	}
+	resp, err := t.client.Post(endpoint+"/v1/traces", "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("tracing: failed to export %d spans: %v", len(spans), err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("tracing: collector returned %s", resp.Status)
+	}
+}
+
+// otlpPayload renders spans in the OTLP/JSON trace format.
+func otlpPayload(spans []Span) map[string]any {
+	rendered := make([]map[string]any, 0, len(spans))
+	for _, s := range spans {
+		attrs := make([]map[string]any, 0, len(s.Attrs))
+		for k, v := range s.Attrs {
+			attrs = append(attrs, map[string]any{
+				"key":   k,
+				"value": map[string]any{"stringValue": v},
+			})
+		}
+		rendered = append(rendered, map[string]any{
+			"traceId":           s.TraceID,
+			"spanId":            s.SpanID,
+			"name":              s.Name,
+			"kind":              1,
+			"startTimeUnixNano": strconv.FormatInt(s.Start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.End.UnixNano(), 10),
+			"attributes":        attrs,
+		})
+	}
+	return map[string]any{
+		"resourceSpans": []map[string]any{{
+			"resource": map[string]any{
+				"attributes": []map[string]any{{
+					"key":   "service.name",
+					"value": map[string]any{"stringValue": "gleip"},
+				}},
+			},
+			"scopeSpans": []map[string]any{{
+				"scope": map[string]any{"name": "gleip"},
+				"spans": rendered,
+			}},
+		}},
+	}
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		// Fall back to a time-derived ID; uniqueness matters more than
+		// unpredictability here.
+		return fmt.Sprintf("%0*x", n*2, time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,134 @@
+// Package wordlist prepares fuzzing wordlists: merging, deduplicating,
+// filtering, and sampling. Inputs are streamed line by line so lists of
+// hundreds of megabytes can be processed without loading them into memory.
+package wordlist
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"regexp"
+)
+
+// Options controls a processing run. Zero values disable each filter.
+type Options struct {
+	// Dedupe drops lines already seen across all inputs.
+	Dedupe bool `json:"dedupe"`
+	// MinLength and MaxLength drop lines outside the byte-length range
+	// (MaxLength 0 means unlimited).
+	MinLength int `json:"minLength"`
+	MaxLength int `json:"maxLength"`
+	// Pattern keeps only lines matching this regular expression.
+	Pattern string `json:"pattern"`
+	// Sample keeps a uniform random sample of this many lines (0 keeps all).
+	Sample int `json:"sample"`
+}
+
+// Stats reports what a processing run did.
+type Stats struct {
+	LinesRead    int `json:"linesRead"`
+	LinesWritten int `json:"linesWritten"`
+	Duplicates   int `json:"duplicates"`
+	Filtered     int `json:"filtered"`
+}
+
+// Process streams the input files through the configured filters into output.
+// Deduplication keeps only 64-bit line hashes in memory, so even very large
+// lists stay cheap; a hash collision can at worst drop a unique line.
+func Process(inputs []string, output string, opts Options) (*Stats, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no input files given")
+	}
+	var pattern *regexp.Regexp
+	if opts.Pattern != "" {
+		var err error
+		pattern, err = regexp.Compile(opts.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern: %w", err)
+		}
+	}
+
+	stats := &Stats{}
+	seen := make(map[uint64]struct{})
+	keep := func(line string) bool {
+		if len(line) < opts.MinLength || (opts.MaxLength > 0 && len(line) > opts.MaxLength) {
+			stats.Filtered++
+			return false
+		}
+		if pattern != nil && !pattern.MatchString(line) {
+			stats.Filtered++
+			return false
+		}
+		if opts.Dedupe {
+			h := fnv.New64a()
+			h.Write([]byte(line))
+			sum := h.Sum64()
+			if _, dup := seen[sum]; dup {
+				stats.Duplicates++
+				return false
+			}
+			seen[sum] = struct{}{}
+		}
+		return true
+	}
+
+	// Sampling uses reservoir sampling so it works in one streaming pass;
+	// the reservoir holds at most opts.Sample lines.
+	var reservoir []string
+	sampled := 0
+
+	out, err := os.Create(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer out.Close()
+	w := bufio.NewWriter(out)
+
+	for _, input := range inputs {
+		f, err := os.Open(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open wordlist: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			stats.LinesRead++
+			line := scanner.Text()
+			if !keep(line) {
+				continue
+			}
+			if opts.Sample > 0 {
+				sampled++
+				if len(reservoir) < opts.Sample {
+					reservoir = append(reservoir, line)
+				} else if j := rand.Intn(sampled); j < opts.Sample {
+					reservoir[j] = line
+				}
+				continue
+			}
+			if _, err := w.WriteString(line + "\n"); err != nil {
+				f.Close()
+				return nil, fmt.Errorf("failed to write output: %w", err)
+			}
+			stats.LinesWritten++
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read wordlist %s: %w", input, err)
+		}
+	}
+
+	for _, line := range reservoir {
+		if _, err := w.WriteString(line + "\n"); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+		stats.LinesWritten++
+	}
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to flush output: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,113 @@
+// Command gleip-agent runs a headless capture proxy on a remote host (jump
+// box, cloud VM) and streams transactions back to the desktop app; see the
+// agent package for the protocol.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"gleip/backend/agent"
+	"gleip/backend/cert"
+	"gleip/backend/network"
+	"gleip/backend/proxy"
+)
+
+func main() {
+	proxyAddr := flag.String("proxy", "0.0.0.0:9090", "proxy listen address")
+	controlAddr := flag.String("control", "0.0.0.0:9092", "control/stream listen address")
+	token := flag.String("token", "", "access token for the stream (generated when empty)")
+	allow := flag.String("allow", "", "comma-separated IPs/CIDRs allowed to use the proxy (default: any client with the proxy token)")
+	proxyToken := flag.String("proxy-token", "", "token proxy clients must present (generated when empty and -allow is unset)")
+	dataDir := flag.String("data", "", "data directory (default: user config dir)")
+	flag.Parse()
+
+	dir := *dataDir
+	if dir == "" {
+		base, err := os.UserConfigDir()
+		if err != nil {
+			log.Fatalf("failed to resolve config dir: %v", err)
+		}
+		dir = filepath.Join(base, "gleip-agent")
+	}
+	certs, err := cert.NewCertificateManager(dir)
+	if err != nil {
+		log.Fatalf("failed to initialize certificates: %v", err)
+	}
+
+	accessToken := *token
+	if accessToken == "" {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			log.Fatalf("failed to generate token: %v", err)
+		}
+		accessToken = hex.EncodeToString(buf)
+		log.Printf("generated access token: %s", accessToken)
+	}
+
+	store := network.NewInMemoryTransactionStore()
+	p := proxy.NewProxyServer(*proxyAddr, certs, store)
+	p.SetSpoolDir(filepath.Join(dir, "spool"))
+
+	// An empty allowlist rejects every non-loopback client, which would make
+	// a remote agent useless: without -allow, open the proxy to any client
+	// that presents the proxy token (generating one when needed).
+	allowedClients := splitList(*allow)
+	clientToken := *proxyToken
+	if len(allowedClients) == 0 {
+		allowedClients = []string{"0.0.0.0/0", "::/0"}
+		if clientToken == "" {
+			buf := make([]byte, 16)
+			if _, err := rand.Read(buf); err != nil {
+				log.Fatalf("failed to generate proxy token: %v", err)
+			}
+			clientToken = hex.EncodeToString(buf)
+			log.Printf("generated proxy token: %s", clientToken)
+		}
+	}
+	if err := p.SetRemoteAccess(allowedClients, clientToken); err != nil {
+		log.Fatalf("failed to configure proxy access: %v", err)
+	}
+	policy := "clients " + strings.Join(allowedClients, ", ")
+	if clientToken != "" {
+		policy += ", proxy token required"
+	} else {
+		policy += ", no proxy token"
+	}
+	log.Printf("proxy access: %s (loopback always allowed)", policy)
+	a, err := agent.New(p, accessToken)
+	if err != nil {
+		log.Fatalf("failed to create agent: %v", err)
+	}
+	if err := p.Start(); err != nil {
+		log.Fatalf("failed to start proxy: %v", err)
+	}
+	if err := a.Start(*controlAddr); err != nil {
+		log.Fatalf("failed to start agent: %v", err)
+	}
+	log.Printf("gleip-agent: proxy on %s, stream on %s", *proxyAddr, *controlAddr)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+	a.Stop()
+	p.Stop()
+}
+
+// splitList parses a comma-separated flag value, dropping blanks.
+func splitList(value string) []string {
+	var out []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	app, err := NewApp()
+	if err != nil {
+		log.Fatalf("failed to initialize: %v", err)
+	}
+	run(app)
+}
+
+// run starts the backend headlessly and blocks until interrupted. The
+// desktop build replaces this with the UI runtime loop, which calls
+// App.Startup/Shutdown at the same points.
+func run(app *App) {
+	app.Startup(context.Background())
+	if err := app.StartProxy(); err != nil {
+		log.Fatalf("failed to start proxy: %v", err)
+	}
+	log.Printf("gleip proxy listening on %s", app.proxy.Addr())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+	app.Shutdown()
+}